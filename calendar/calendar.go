@@ -0,0 +1,643 @@
+// Package calendar parses the calendar event expressions of
+// systemd.time(7), as used by OnCalendar= in timer units, and computes
+// when they elapse, like systemd-analyze calendar.
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javadh75/systemd-config/internal/timename"
+)
+
+// ErrInvalidSpec is wrapped by the error Parse returns for a malformed
+// calendar event expression.
+var ErrInvalidSpec = errors.New("invalid calendar specification")
+
+const (
+	minYear = 1970
+	// maxYear is the last year systemd computes elapse times in
+	maxYear = 2199
+)
+
+// component is one element of a comma-separated list of values, such as
+// "5", "1..5", "0/15" or "1..20/5": start, optionally up to stop (-1
+// when unbounded) and optionally every repeat values (0 for a single
+// value, or every value of a range).
+type component struct {
+	start, stop, repeat int
+}
+
+// next returns the smallest value matching c that is at least from.
+func (c component) next(from int) (int, bool) {
+	if from <= c.start {
+		return c.start, true
+	}
+	repeat := c.repeat
+	if repeat == 0 {
+		if c.stop < 0 {
+			return 0, false
+		}
+		repeat = 1
+	}
+	v := c.start + (from-c.start+repeat-1)/repeat*repeat
+	if c.stop >= 0 && v > c.stop {
+		return 0, false
+	}
+	return v, true
+}
+
+// chain is the list of components of a field; a nil chain matches any
+// value.
+type chain []component
+
+// next returns the smallest value matching ch in [from, limit].
+func (ch chain) next(from, limit int) (int, bool) {
+	if ch == nil {
+		return from, from <= limit
+	}
+	best, found := 0, false
+	for _, c := range ch {
+		if v, ok := c.next(from); ok && v <= limit && (!found || v < best) {
+			best, found = v, true
+		}
+	}
+	return best, found
+}
+
+// matches reports whether v matches ch.
+func (ch chain) matches(v int) bool {
+	n, ok := ch.next(v, v)
+	return ok && n == v
+}
+
+// Spec is a parsed calendar event expression.
+type Spec struct {
+	// weekdays has bit 0 set for Monday through bit 6 for Sunday; 0
+	// matches every day
+	weekdays uint8
+	year     chain
+	month    chain
+	// endOfMonth makes day count back from the last day of the month,
+	// as "~" does
+	endOfMonth bool
+	day        chain
+	hour       chain
+	minute     chain
+	// second is in microseconds
+	second chain
+	loc    *time.Location
+}
+
+// specials are the shorthand expressions and what they stand for.
+var specials = map[string]string{
+	"minutely":     "*-*-* *:*:00",
+	"hourly":       "*-*-* *:00:00",
+	"daily":        "*-*-* 00:00:00",
+	"monthly":      "*-*-01 00:00:00",
+	"weekly":       "Mon *-*-* 00:00:00",
+	"yearly":       "*-01-01 00:00:00",
+	"annually":     "*-01-01 00:00:00",
+	"quarterly":    "*-01,04,07,10-01 00:00:00",
+	"semiannually": "*-01,07-01 00:00:00",
+}
+
+// weekdayNames are the abbreviated day names, Monday first, as systemd
+// writes them.
+var weekdayNames = []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+
+// Parse parses a calendar event expression of the form
+//
+//	[WEEKDAYS] [[YEAR-]MONTH-DAY] [HOUR:MINUTE[:SECOND]] [TIMEZONE]
+//
+// e.g. "Mon..Fri *-*-* 02:00:00", "*:0/15", "*-02~01" (the last day of
+// February) or "Sat,Sun 10:00 Europe/Berlin", or one of the shorthands
+// minutely, hourly, daily, weekly, monthly, yearly, annually, quarterly
+// and semiannually. Each field is "*" or a comma-separated list of
+// values, ranges ("1..5") and repetitions ("0/15", "1..20/5"). An omitted
+// date matches every day, and an omitted time is midnight. The time zone
+// is "UTC" or a name from the IANA database, loaded with
+// time.LoadLocation.
+func Parse(s string) (*Spec, error) {
+	invalid := func(reason string) (*Spec, error) {
+		return nil, fmt.Errorf("%w %q: %s", ErrInvalidSpec, s, reason)
+	}
+
+	spec := &Spec{}
+	fields := strings.Fields(s)
+	if len(fields) > 1 {
+		if loc, ok := timename.Location(fields[len(fields)-1]); ok {
+			spec.loc = loc
+			fields = fields[:len(fields)-1]
+		}
+	}
+	if len(fields) == 1 {
+		for name, expansion := range specials {
+			if strings.EqualFold(fields[0], name) {
+				fields = strings.Fields(expansion)
+				break
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return invalid("empty")
+	}
+
+	if c := fields[0][0]; 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' {
+		weekdays, err := parseWeekdays(fields[0])
+		if err != nil {
+			return invalid(err.Error())
+		}
+		spec.weekdays = weekdays
+		fields = fields[1:]
+	}
+
+	var date, clock string
+	for _, f := range fields {
+		switch {
+		case strings.Contains(f, ":") && clock == "":
+			clock = f
+		case strings.ContainsAny(f, "-~") && date == "" && clock == "":
+			date = f
+		default:
+			return invalid(fmt.Sprintf("unexpected %q", f))
+		}
+	}
+	if date != "" {
+		if err := spec.parseDate(date); err != nil {
+			return invalid(err.Error())
+		}
+	}
+	if clock == "" {
+		clock = "00:00:00"
+	}
+	if err := spec.parseTime(clock); err != nil {
+		return invalid(err.Error())
+	}
+	return spec, nil
+}
+
+// parseWeekdays parses a comma-separated list of day names and ranges of
+// them, e.g. "Mon..Fri,Sun".
+func parseWeekdays(s string) (uint8, error) {
+	var bits uint8
+	for _, item := range strings.Split(s, ",") {
+		from, to, isRange := strings.Cut(item, "..")
+		if !isRange {
+			from, to, isRange = strings.Cut(item, "-")
+		}
+		first, ok := weekday(from)
+		if !ok {
+			return 0, fmt.Errorf("unknown weekday %q", from)
+		}
+		last := first
+		if isRange {
+			if last, ok = weekday(to); !ok {
+				return 0, fmt.Errorf("unknown weekday %q", to)
+			}
+		}
+		// a range may wrap around the end of the week, e.g. Sat..Mon
+		for d := first; ; d = (d + 1) % 7 {
+			bits |= 1 << d
+			if d == last {
+				break
+			}
+		}
+	}
+	return bits, nil
+}
+
+// weekday returns the number of the named day, 0 for Monday.
+func weekday(name string) (int, bool) {
+	d, ok := timename.Weekday(name)
+	return (int(d) + 6) % 7, ok
+}
+
+// parseDate parses "[YEAR-]MONTH-DAY", where the last separator may be
+// "~" to count the day back from the end of the month.
+func (s *Spec) parseDate(date string) error {
+	var day string
+	if before, after, ok := strings.Cut(date, "~"); ok {
+		s.endOfMonth = true
+		date, day = before, after
+	} else {
+		i := strings.LastIndexByte(date, '-')
+		date, day = date[:i], date[i+1:]
+	}
+
+	parts := strings.Split(date, "-")
+	var err error
+	switch len(parts) {
+	case 2:
+		if s.year, err = parseChain(parts[0], minYear, maxYear, false); err != nil {
+			return fmt.Errorf("year: %w", err)
+		}
+		if err := s.normalizeYears(); err != nil {
+			return fmt.Errorf("year: %w", err)
+		}
+	case 1:
+	default:
+		return fmt.Errorf("malformed date %q", date)
+	}
+	if s.month, err = parseChain(parts[len(parts)-1], 1, 12, false); err != nil {
+		return fmt.Errorf("month: %w", err)
+	}
+	if s.day, err = parseChain(day, 1, 31, false); err != nil {
+		return fmt.Errorf("day: %w", err)
+	}
+	return nil
+}
+
+// normalizeYears makes two-digit years four-digit ones: 70 to 99 are in
+// the 1900s, 0 to 69 in the 2000s. It returns an error for years before
+// minYear or after maxYear.
+func (s *Spec) normalizeYears() error {
+	century := func(y int) int {
+		switch {
+		case y < 0, y >= 100:
+			return y
+		case y < 70:
+			return y + 2000
+		}
+		return y + 1900
+	}
+	for i, c := range s.year {
+		c.start, c.stop = century(c.start), century(c.stop)
+		if c.start < minYear || c.start > maxYear {
+			return fmt.Errorf("%d out of range", c.start)
+		}
+		if c.stop > maxYear {
+			return fmt.Errorf("%d out of range", c.stop)
+		}
+		s.year[i] = c
+	}
+	return nil
+}
+
+// parseTime parses "HOUR:MINUTE[:SECOND]".
+func (s *Spec) parseTime(clock string) error {
+	parts := strings.Split(clock, ":")
+	if len(parts) == 2 {
+		parts = append(parts, "00")
+	}
+	if len(parts) != 3 {
+		return fmt.Errorf("malformed time %q", clock)
+	}
+	var err error
+	if s.hour, err = parseChain(parts[0], 0, 23, false); err != nil {
+		return fmt.Errorf("hour: %w", err)
+	}
+	if s.minute, err = parseChain(parts[1], 0, 59, false); err != nil {
+		return fmt.Errorf("minute: %w", err)
+	}
+	if s.second, err = parseChain(parts[2], 0, 60e6-1, true); err != nil {
+		return fmt.Errorf("second: %w", err)
+	}
+	return nil
+}
+
+// parseChain parses "*" or a comma-separated list of components, whose
+// values must lie in [low, high]. Values with a fraction are allowed when
+// micro is set, and are then in millionths.
+func parseChain(s string, low, high int, micro bool) (chain, error) {
+	if s == "*" {
+		return nil, nil
+	}
+	var ch chain
+	for _, item := range strings.Split(s, ",") {
+		c := component{stop: -1}
+		value, repeat, hasRepeat := strings.Cut(item, "/")
+		from, to, hasRange := strings.Cut(value, "..")
+
+		var err error
+		if from == "*" && !hasRange && hasRepeat {
+			c.start = low
+		} else if c.start, err = parseNumber(from, micro); err != nil {
+			return nil, err
+		}
+		if hasRange {
+			if c.stop, err = parseNumber(to, micro); err != nil {
+				return nil, err
+			}
+			if c.stop < c.start {
+				return nil, fmt.Errorf("empty range %q", value)
+			}
+		}
+		if hasRepeat {
+			if c.repeat, err = parseNumber(repeat, micro); err != nil {
+				return nil, err
+			}
+			if c.repeat == 0 {
+				return nil, fmt.Errorf("zero repetition %q", item)
+			}
+		}
+		// years are checked after two-digit years are expanded
+		if high != maxYear && (c.start < low || c.start > high || c.stop > high) {
+			return nil, fmt.Errorf("%q out of range", item)
+		}
+		ch = append(ch, c)
+	}
+	slices.SortFunc(ch, func(a, b component) int {
+		if a.start != b.start {
+			return a.start - b.start
+		}
+		if a.stop != b.stop {
+			return a.stop - b.stop
+		}
+		return a.repeat - b.repeat
+	})
+	return slices.Compact(ch), nil
+}
+
+// parseNumber parses a non-negative decimal number; with micro, it may
+// have a fraction of up to six digits and is returned in millionths.
+func parseNumber(s string, micro bool) (int, error) {
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	if whole == "" || strings.TrimLeft(whole, "0123456789") != "" || len(whole) > 9 {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	n, _ := strconv.Atoi(whole)
+	if !micro {
+		if hasFrac {
+			return 0, fmt.Errorf("invalid number %q", s)
+		}
+		return n, nil
+	}
+	if len(frac) > 6 || strings.TrimLeft(frac, "0123456789") != "" || hasFrac && frac == "" {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	f, _ := strconv.Atoi((frac + "000000")[:6])
+	return n*1e6 + f, nil
+}
+
+// Location returns the time zone of the expression, or nil when it has
+// none and is evaluated in the location of the time given to Next.
+func (s *Spec) Location() *time.Location {
+	return s.loc
+}
+
+// String returns the normalized form of the expression, as
+// systemd-analyze calendar prints it, e.g. "Mon *-*-* 00:00:00" for
+// "weekly".
+func (s *Spec) String() string {
+	var b strings.Builder
+	if s.weekdays != 0 && s.weekdays != 0x7f {
+		formatWeekdays(&b, s.weekdays)
+		b.WriteByte(' ')
+	}
+	formatChain(&b, s.year, 4, false)
+	b.WriteByte('-')
+	formatChain(&b, s.month, 2, false)
+	if s.endOfMonth {
+		b.WriteByte('~')
+	} else {
+		b.WriteByte('-')
+	}
+	formatChain(&b, s.day, 2, false)
+	b.WriteByte(' ')
+	formatChain(&b, s.hour, 2, false)
+	b.WriteByte(':')
+	formatChain(&b, s.minute, 2, false)
+	b.WriteByte(':')
+	formatChain(&b, s.second, 2, true)
+	if s.loc != nil {
+		b.WriteByte(' ')
+		b.WriteString(s.loc.String())
+	}
+	return b.String()
+}
+
+// MarshalText implements encoding.TextMarshaler; the text is the
+// normalized form.
+func (s *Spec) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, parsing the text
+// as Parse does.
+func (s *Spec) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*s = *parsed
+	return nil
+}
+
+// formatWeekdays writes the days in bits, with runs of three days or more
+// as ranges, as systemd does.
+func formatWeekdays(b *strings.Builder, bits uint8) {
+	first := true
+	for d := 0; d < 7; {
+		if bits&(1<<d) == 0 {
+			d++
+			continue
+		}
+		end := d
+		for end+1 < 7 && bits&(1<<(end+1)) != 0 {
+			end++
+		}
+		if !first {
+			b.WriteByte(',')
+		}
+		first = false
+		b.WriteString(weekdayNames[d])
+		switch {
+		case end-d >= 2:
+			b.WriteString("..")
+			b.WriteString(weekdayNames[end])
+		case end > d:
+			b.WriteByte(',')
+			b.WriteString(weekdayNames[end])
+		}
+		d = end + 1
+	}
+}
+
+// formatChain writes ch, its values zero-padded to width digits, and in
+// millionths when micro is set.
+func formatChain(b *strings.Builder, ch chain, width int, micro bool) {
+	if ch == nil {
+		b.WriteByte('*')
+		return
+	}
+	for i, c := range ch {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		formatNumber(b, c.start, width, micro)
+		if c.stop >= 0 {
+			b.WriteString("..")
+			formatNumber(b, c.stop, width, micro)
+		}
+		if c.repeat > 0 {
+			b.WriteByte('/')
+			formatNumber(b, c.repeat, 0, micro)
+		}
+	}
+}
+
+// formatNumber writes n zero-padded to width digits; in millionths when
+// micro is set, with a fraction if it has one.
+func formatNumber(b *strings.Builder, n, width int, micro bool) {
+	frac := 0
+	if micro {
+		n, frac = n/1e6, n%1e6
+	}
+	fmt.Fprintf(b, "%0*d", width, n)
+	if frac != 0 {
+		fmt.Fprintf(b, ".%06d", frac)
+	}
+}
+
+// Next returns the first time after the given one at which the
+// expression elapses, with microsecond precision, and false when it
+// never does again (before the year 2200). The expression is evaluated in
+// its time zone, or else in the location of after. A wall clock time
+// skipped by a daylight saving time change elapses at the corresponding
+// time after the change, as time.Date normalizes it; one repeated by a
+// change elapses at its first occurrence and, if after is in the
+// repeated hour, at its second.
+func (s *Spec) Next(after time.Time) (time.Time, bool) {
+	loc := s.loc
+	if loc == nil {
+		loc = after.Location()
+	}
+	start := after.In(loc).Truncate(time.Microsecond).Add(time.Microsecond)
+	c := clock{
+		year: start.Year(), month: int(start.Month()), day: start.Day(),
+		hour: start.Hour(), minute: start.Minute(), usec: start.Second()*1e6 + start.Nanosecond()/1e3,
+	}
+
+	for c.year <= maxYear {
+		year, ok := s.year.next(c.year, maxYear)
+		if !ok {
+			return time.Time{}, false
+		}
+		if year != c.year {
+			c = clock{year: year, month: 1, day: 1}
+		}
+
+		month, ok := s.month.next(c.month, 12)
+		if !ok {
+			c = clock{year: c.year + 1, month: 1, day: 1}
+			continue
+		}
+		if month != c.month {
+			c = clock{year: c.year, month: month, day: 1}
+		}
+
+		day, ok := s.nextDay(c.year, c.month, c.day)
+		if !ok {
+			c = clock{year: c.year, month: c.month + 1, day: 1}.normalize()
+			continue
+		}
+		if day != c.day {
+			c = clock{year: c.year, month: c.month, day: day}
+		}
+
+		hour, ok := s.hour.next(c.hour, 23)
+		if !ok {
+			c = clock{year: c.year, month: c.month, day: c.day + 1}.normalize()
+			continue
+		}
+		if hour != c.hour {
+			c = clock{year: c.year, month: c.month, day: c.day, hour: hour}
+		}
+
+		minute, ok := s.minute.next(c.minute, 59)
+		if !ok {
+			c = clock{year: c.year, month: c.month, day: c.day, hour: c.hour + 1}.normalize()
+			continue
+		}
+		if minute != c.minute {
+			c = clock{year: c.year, month: c.month, day: c.day, hour: c.hour, minute: minute}
+		}
+
+		usec, ok := s.second.next(c.usec, 60e6-1)
+		if !ok {
+			c = clock{year: c.year, month: c.month, day: c.day, hour: c.hour, minute: c.minute + 1}.normalize()
+			continue
+		}
+		c.usec = usec
+
+		t := time.Date(c.year, time.Month(c.month), c.day, c.hour, c.minute, 0, c.usec*1e3, loc)
+		if t.After(after) {
+			return t, true
+		}
+		// A wall clock time repeated by a daylight saving time change, of
+		// which time.Date chose the first occurrence while after is in the
+		// second: the time elapses again at the offset of after.
+		_, offset := t.Zone()
+		_, afterOffset := after.In(loc).Zone()
+		if later := t.Add(time.Duration(offset-afterOffset) * time.Second); later.After(after) && sameClock(later.In(loc), t) {
+			return later.In(loc), true
+		}
+		c.usec++
+		c = c.normalize()
+	}
+	return time.Time{}, false
+}
+
+// sameClock reports whether a and b show the same wall clock time.
+func sameClock(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd && a.Hour() == b.Hour() && a.Minute() == b.Minute() &&
+		a.Second() == b.Second() && a.Nanosecond() == b.Nanosecond()
+}
+
+// nextDay returns the first day of the month, from the given one on,
+// that matches the day and weekday of the expression.
+func (s *Spec) nextDay(year, month, from int) (int, bool) {
+	days := daysIn(year, month)
+	for d := from; d <= days; d++ {
+		v := d
+		if s.endOfMonth {
+			v = days - d + 1
+		}
+		if !s.day.matches(v) {
+			continue
+		}
+		wd := (int(time.Date(year, time.Month(month), d, 0, 0, 0, 0, time.UTC).Weekday()) + 6) % 7
+		if s.weekdays == 0 || s.weekdays&(1<<wd) != 0 {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// daysIn returns the number of days in the month.
+func daysIn(year, month int) int {
+	return time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// clock is a broken-down wall clock time, with the seconds in
+// microseconds.
+type clock struct {
+	year, month, day, hour, minute, usec int
+}
+
+// normalize carries overflowing fields into the next larger one.
+func (c clock) normalize() clock {
+	if c.usec >= 60e6 {
+		c.minute, c.usec = c.minute+1, c.usec-60e6
+	}
+	if c.minute >= 60 {
+		c.hour, c.minute = c.hour+1, c.minute-60
+	}
+	if c.hour >= 24 {
+		c.day, c.hour = c.day+1, c.hour-24
+	}
+	if c.month <= 12 && c.day > daysIn(c.year, c.month) {
+		c.month, c.day = c.month+1, 1
+	}
+	if c.month > 12 {
+		c.year, c.month = c.year+1, 1
+	}
+	return c
+}
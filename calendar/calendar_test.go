@@ -0,0 +1,264 @@
+package calendar
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"*-*-* 02:00:00", "*-*-* 02:00:00"},
+		{"weekly", "Mon *-*-* 00:00:00"},
+		{"Daily", "*-*-* 00:00:00"},
+		{"minutely", "*-*-* *:*:00"},
+		{"quarterly", "*-01,04,07,10-01 00:00:00"},
+		{"semiannually", "*-01,07-01 00:00:00"},
+		{"annually", "*-01-01 00:00:00"},
+		{"hourly UTC", "*-*-* *:00:00 UTC"},
+		{"*:0/15", "*-*-* *:00/15:00"},
+		{"*:*:*/10", "*-*-* *:*:00/10"},
+		{"Mon..Fri *-*-* 02:00", "Mon..Fri *-*-* 02:00:00"},
+		{"Sat,Sun 10:00", "Sat,Sun *-*-* 10:00:00"},
+		{"mon,tuesday,Wed,fri", "Mon..Wed,Fri *-*-* 00:00:00"},
+		{"Sat..Mon", "Mon,Sat,Sun *-*-* 00:00:00"},
+		{"Mon..Sun 3:0", "*-*-* 03:00:00"},
+		{"Fri *-*-13", "Fri *-*-13 00:00:00"},
+		{"*-02~01", "*-02~01 00:00:00"},
+		{"2024-*~07/1", "2024-*~07/1 00:00:00"},
+		{"21-1-2", "2021-01-02 00:00:00"},
+		{"99-12-31 23:59", "1999-12-31 23:59:00"},
+		{"*-*-7,1..5/2,3 4:00", "*-*-01..05/2,03,07 04:00:00"},
+		{"2024-05-01 12:30:15.5 UTC", "2024-05-01 12:30:15.500000 UTC"},
+		{"*-*-* 02:00 Europe/Berlin", "*-*-* 02:00:00 Europe/Berlin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			spec, err := Parse(tt.in)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.in, err)
+			}
+			if got := spec.String(); got != tt.want {
+				t.Errorf("Parse(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			reparsed, err := Parse(tt.want)
+			if err != nil || reparsed.String() != tt.want {
+				t.Errorf("Parse(%q) = %v, %v, want the normalized form back", tt.want, reparsed, err)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"   ",
+		"Mon..Fry",
+		"foo",
+		"*-13-01",
+		"*-*-00",
+		"*-*-* 24:00",
+		"*:60",
+		"*:*:60",
+		"*-*-* 1:2:3:4",
+		"*-*-5..1",
+		"*:0/0",
+		"*:1.5",
+		"*:*:1.1234567",
+		"*-*-* 02:00 02:00",
+		"02:00 *-*-*",
+		"*-*-* 02:00 Mars/Olympus",
+		"1-2-3-4",
+		"*-*-x",
+		"1000-01-01",
+		"3000-01-01",
+		"1969-12-31",
+		"2200-01-01",
+		"2020..2300-01-01",
+	} {
+		if spec, err := Parse(in); !errors.Is(err, ErrInvalidSpec) {
+			t.Errorf("Parse(%q) = %v, %v, want ErrInvalidSpec", in, spec, err)
+		}
+	}
+}
+
+func TestSpec_Next(t *testing.T) {
+	utc := func(s string) time.Time {
+		t.Helper()
+		v, err := time.Parse("2006-01-02 15:04:05.999999", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	tests := []struct {
+		spec, after, want string
+	}{
+		{"*-*-* 02:00:00", "2024-01-15 10:00:00", "2024-01-16 02:00:00"},
+		{"*-*-* 02:00:00", "2024-01-15 01:59:59.999999", "2024-01-15 02:00:00"},
+		{"*-*-* 02:00:00", "2024-01-15 02:00:00", "2024-01-16 02:00:00"},
+		{"*-*-* 02:00:00", "2024-12-31 03:00:00", "2025-01-01 02:00:00"},
+		{"Mon..Fri *-*-* 02:00", "2024-01-19 03:00:00", "2024-01-22 02:00:00"},
+		{"weekly", "2024-01-15 00:00:00", "2024-01-22 00:00:00"},
+		{"*:0/15", "2024-01-15 10:07:00", "2024-01-15 10:15:00"},
+		{"*:0/15", "2024-01-15 23:50:00", "2024-01-16 00:00:00"},
+		{"*:*:0/0.5", "2024-01-15 10:00:00.2", "2024-01-15 10:00:00.5"},
+		{"*-02~01", "2024-01-15 00:00:00", "2024-02-29 00:00:00"},
+		{"*-02~01", "2025-01-15 00:00:00", "2025-02-28 00:00:00"},
+		{"*-02-29", "2025-03-01 00:00:00", "2028-02-29 00:00:00"},
+		{"Fri *-*-13", "2024-01-01 00:00:00", "2024-09-13 00:00:00"},
+		{"quarterly", "2024-04-01 00:00:00", "2024-07-01 00:00:00"},
+		{"*-*-* 02:00 Europe/Berlin", "2024-01-15 10:00:00", "2024-01-16 01:00:00"},
+		{"*-*-* 02:00 Europe/Berlin", "2024-07-15 10:00:00", "2024-07-16 00:00:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec+"/"+tt.after, func(t *testing.T) {
+			spec, err := Parse(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, ok := spec.Next(utc(tt.after))
+			if want := utc(tt.want); !ok || !got.Equal(want) {
+				t.Errorf("Next(%s) = %v, %v, want %v", tt.after, got, ok, want)
+			}
+		})
+	}
+}
+
+func TestSpec_NextNever(t *testing.T) {
+	for _, in := range []string{"2020-01-01", "*-02-30", "Mon 2024-01-02"} {
+		spec, err := Parse(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, ok := spec.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)); ok {
+			t.Errorf("Parse(%q).Next() = %v, want none", in, got)
+		}
+	}
+}
+
+func TestSpec_NextLocation(t *testing.T) {
+	spec, err := Parse("*-*-* 02:00:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Location() != nil {
+		t.Errorf("Location() = %v, want nil", spec.Location())
+	}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := spec.Next(time.Date(2024, 1, 15, 10, 0, 0, 0, tokyo))
+	if want := time.Date(2024, 1, 16, 2, 0, 0, 0, tokyo); !got.Equal(want) || got.Location() != tokyo {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+}
+
+func TestSpec_NextFallBack(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 01:30 EST, in the hour repeated when daylight saving time ends
+	after := time.Date(2026, 11, 1, 6, 30, 0, 0, time.UTC).In(newYork)
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"*:*:*", time.Date(2026, 11, 1, 6, 30, 0, 1e3, time.UTC)},
+		{"*:*:00", time.Date(2026, 11, 1, 6, 31, 0, 0, time.UTC)},
+		{"*-*-* 01:45", time.Date(2026, 11, 1, 6, 45, 0, 0, time.UTC)},
+		{"*-*-* 01:15", time.Date(2026, 11, 2, 6, 15, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		spec, err := Parse(tt.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		done := make(chan struct{})
+		var got time.Time
+		var ok bool
+		go func() {
+			defer close(done)
+			got, ok = spec.Next(after)
+		}()
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Parse(%q).Next(%v) does not return", tt.spec, after)
+		}
+		if !ok || !got.Equal(tt.want) {
+			t.Errorf("Parse(%q).Next(%v) = %v, %v, want %v", tt.spec, after, got, ok, tt.want.In(newYork))
+		}
+	}
+
+	// the first occurrence of a repeated time elapses too
+	spec, err := Parse("*-*-* 01:45")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := spec.Next(time.Date(2026, 11, 1, 5, 0, 0, 0, time.UTC).In(newYork))
+	if want := time.Date(2026, 11, 1, 5, 45, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got, want.In(newYork))
+	}
+}
+
+func TestBackupTimer(t *testing.T) {
+	f, err := os.Open("../testdata/backup.timer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	unit, err := systemdconfig.Deserialize(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, _ := unit.Value("Timer", "OnCalendar")
+	spec, err := Parse(value)
+	if err != nil {
+		t.Fatalf("Parse(%q) error = %v", value, err)
+	}
+	if got := spec.String(); got != "*-*-* 02:00:00" {
+		t.Errorf("normalized form = %q", got)
+	}
+	delay, err := unit.Duration("Timer", "RandomizedDelaySec")
+	if err != nil || delay != 30*time.Minute {
+		t.Errorf("RandomizedDelaySec = %v, %v", delay, err)
+	}
+
+	// like systemd-analyze calendar --iterations=3
+	from := time.Date(2024, 2, 28, 12, 0, 0, 0, time.UTC)
+	want := []time.Time{
+		time.Date(2024, 2, 29, 2, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 1, 2, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 2, 2, 0, 0, 0, time.UTC),
+	}
+	for i, w := range want {
+		next, ok := spec.Next(from)
+		if !ok || !next.Equal(w) {
+			t.Fatalf("elapse %d = %v, %v, want %v", i+1, next, ok, w)
+		}
+		from = next
+	}
+}
+
+func TestSpec_Text(t *testing.T) {
+	var spec Spec
+	if err := spec.UnmarshalText([]byte("hourly UTC")); err != nil {
+		t.Fatal(err)
+	}
+	text, err := spec.MarshalText()
+	if err != nil || string(text) != "*-*-* *:00:00 UTC" {
+		t.Errorf("MarshalText() = %q, %v", text, err)
+	}
+	if err := spec.UnmarshalText([]byte("bogus")); !errors.Is(err, ErrInvalidSpec) {
+		t.Errorf("UnmarshalText() error = %v, want ErrInvalidSpec", err)
+	}
+}
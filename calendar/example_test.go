@@ -0,0 +1,26 @@
+package calendar_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/javadh75/systemd-config/calendar"
+)
+
+func ExampleParse() {
+	spec, err := calendar.Parse("Mon..Fri *-*-* 2:00 UTC")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(spec)
+
+	from := time.Date(2024, 1, 19, 12, 0, 0, 0, time.UTC)
+	for range 2 {
+		from, _ = spec.Next(from)
+		fmt.Println(from.Format(time.RFC1123))
+	}
+	// Output:
+	// Mon..Fri *-*-* 02:00:00 UTC
+	// Mon, 22 Jan 2024 02:00:00 UTC
+	// Tue, 23 Jan 2024 02:00:00 UTC
+}
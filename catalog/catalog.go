@@ -0,0 +1,162 @@
+// Package catalog knows which options systemd accepts in which section of
+// which unit type.
+//
+// The catalog is generated from the directive table systemd itself
+// prints with "systemd --dump-configuration-items", committed as
+// directives.txt, and from annotations.txt, which adds what the table
+// does not say: which options accumulate, which are deprecated and which
+// systemd version introduced them. After updating either file, run
+// "go generate ./catalog".
+package catalog
+
+import (
+	"slices"
+	"strconv"
+	"sync"
+)
+
+//go:generate go run gen.go
+
+// ValueType is the type of the value of an option.
+type ValueType int
+
+// The value types of options. Options whose values have a syntax of
+// their own, such as CPUQuota= or IPAddressAllow=, are String.
+const (
+	String ValueType = iota
+	// Bool is a boolean, see systemdconfig.ParseBool.
+	Bool
+	// Int is a decimal integer.
+	Int
+	// Duration is a time span, see systemdconfig.ParseTimespan.
+	Duration
+	// Bytes is a size in bytes, see systemdconfig.ParseBytes.
+	Bytes
+	// Mode is an octal file mode, e.g. "0644".
+	Mode
+	// Path is a file system path.
+	Path
+	// Unit is a unit name.
+	Unit
+	// Command is a command line, e.g. ExecStart=.
+	Command
+	// Calendar is a calendar event, see calendar.Parse.
+	Calendar
+)
+
+var valueTypeNames = [...]string{
+	String:   "string",
+	Bool:     "bool",
+	Int:      "int",
+	Duration: "duration",
+	Bytes:    "bytes",
+	Mode:     "mode",
+	Path:     "path",
+	Unit:     "unit",
+	Command:  "command",
+	Calendar: "calendar",
+}
+
+// String returns the name of t, e.g. "duration".
+func (t ValueType) String() string {
+	if t < 0 || int(t) >= len(valueTypeNames) {
+		return "ValueType(" + strconv.Itoa(int(t)) + ")"
+	}
+	return valueTypeNames[t]
+}
+
+// Option describes an option of a section.
+type Option struct {
+	Section, Name string
+	Type          ValueType
+	// Syntax is the syntax systemd prints for the option, e.g. "UNIT
+	// [...]" or "PATH [ARGUMENT [...]]".
+	Syntax string
+	// List reports whether repeated assignments accumulate rather than
+	// replace each other, as for After= or ExecStartPre=.
+	List bool
+	// Resettable reports whether an empty assignment empties a List.
+	// Dependencies such as After= cannot be reset.
+	Resettable bool
+	// Deprecated reports whether the option is deprecated, and
+	// ReplacedBy names the option, "Section.Option", to use instead, if
+	// any.
+	Deprecated bool
+	ReplacedBy string
+	// Since is the systemd version that introduced the option, 0 if it
+	// predates the versions the catalog records.
+	Since int
+}
+
+// SystemdVersion is the version of systemd the catalog was generated
+// from.
+const SystemdVersion = systemdVersion
+
+// unitTypes are the sections of each unit type, in the order systemd
+// documents them.
+var unitTypes = map[string][]string{
+	"automount": {"Unit", "Automount", "Install"},
+	"mount":     {"Unit", "Mount", "Install"},
+	"path":      {"Unit", "Path", "Install"},
+	"scope":     {"Unit", "Scope"},
+	"service":   {"Unit", "Service", "Install"},
+	"slice":     {"Unit", "Slice", "Install"},
+	"socket":    {"Unit", "Socket", "Install"},
+	"swap":      {"Unit", "Swap", "Install"},
+	"target":    {"Unit", "Install"},
+	"timer":     {"Unit", "Timer", "Install"},
+}
+
+// index maps section names to option names to indexes in sections.
+var index = sync.OnceValue(func() map[string]map[string]int {
+	m := make(map[string]map[string]int, len(sections))
+	for name, opts := range sections {
+		m[name] = make(map[string]int, len(opts))
+		for i, o := range opts {
+			m[name][o.Name] = i
+		}
+	}
+	return m
+})
+
+// UnitTypes returns the unit types the catalog knows, sorted, e.g.
+// "service" and "timer".
+func UnitTypes() []string {
+	types := make([]string, 0, len(unitTypes))
+	for t := range unitTypes {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+	return types
+}
+
+// Sections returns the sections a unit of the given type may have, e.g.
+// "Unit", "Service" and "Install" for "service", or nil for an unknown
+// unit type.
+func Sections(unitType string) []string {
+	return slices.Clone(unitTypes[unitType])
+}
+
+// Options returns the options of the named section of a unit of the
+// given type, in the order of systemd's table, or nil if the unit type
+// has no such section.
+func Options(unitType, section string) []Option {
+	if !slices.Contains(unitTypes[unitType], section) {
+		return nil
+	}
+	return slices.Clone(sections[section])
+}
+
+// Lookup returns the named option of the named section of a unit of the
+// given type, e.g. Lookup("service", "Service", "ExecStart"), and
+// whether there is one. Names are case-sensitive, as in systemd.
+func Lookup(unitType, section, option string) (Option, bool) {
+	if !slices.Contains(unitTypes[unitType], section) {
+		return Option{}, false
+	}
+	i, ok := index()[section][option]
+	if !ok {
+		return Option{}, false
+	}
+	return sections[section][i], true
+}
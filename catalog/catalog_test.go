@@ -0,0 +1,154 @@
+package catalog
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		unitType, section, option string
+		want                      Option
+	}{
+		{"service", "Service", "ExecStart", Option{Section: "Service", Name: "ExecStart", Type: Command, Syntax: "PATH [ARGUMENT [...]]", List: true, Resettable: true}},
+		{"service", "Unit", "After", Option{Section: "Unit", Name: "After", Type: Unit, Syntax: "UNIT [...]", List: true}},
+		{"service", "Unit", "Upholds", Option{Section: "Unit", Name: "Upholds", Type: Unit, Syntax: "UNIT [...]", List: true, Since: 249}},
+		{"timer", "Timer", "OnCalendar", Option{Section: "Timer", Name: "OnCalendar", Type: Calendar, Syntax: "TIMER", List: true, Resettable: true}},
+		{"timer", "Timer", "AccuracySec", Option{Section: "Timer", Name: "AccuracySec", Type: Duration, Syntax: "SECONDS"}},
+		{"socket", "Socket", "SocketMode", Option{Section: "Socket", Name: "SocketMode", Type: Mode, Syntax: "MODE"}},
+		{"socket", "Socket", "ReceiveBuffer", Option{Section: "Socket", Name: "ReceiveBuffer", Type: Bytes, Syntax: "SIZE"}},
+		{"mount", "Mount", "CPUShares", Option{Section: "Mount", Name: "CPUShares", Type: Int, Syntax: "SHARES", Deprecated: true, ReplacedBy: "Mount.CPUWeight"}},
+		{"service", "Service", "StartLimitInterval", Option{Section: "Service", Name: "StartLimitInterval", Type: Duration, Syntax: "SECONDS", Deprecated: true, ReplacedBy: "Unit.StartLimitIntervalSec"}},
+		{"target", "Install", "WantedBy", Option{Section: "Install", Name: "WantedBy", Type: String, Syntax: "OTHER", List: true, Resettable: true}},
+	}
+	for _, tt := range tests {
+		got, ok := Lookup(tt.unitType, tt.section, tt.option)
+		if !ok || got != tt.want {
+			t.Errorf("Lookup(%q, %q, %q) = %+v, %v, want %+v", tt.unitType, tt.section, tt.option, got, ok, tt.want)
+		}
+	}
+
+	for _, args := range [][3]string{
+		{"service", "Service", "execstart"},
+		{"service", "Timer", "OnCalendar"},
+		{"target", "Service", "ExecStart"},
+		{"scope", "Install", "WantedBy"},
+		{"network", "Network", "DHCP"},
+		{"service", "Service", "NoSuchOption"},
+	} {
+		if o, ok := Lookup(args[0], args[1], args[2]); ok {
+			t.Errorf("Lookup(%q, %q, %q) = %+v, want none", args[0], args[1], args[2], o)
+		}
+	}
+}
+
+func TestSections(t *testing.T) {
+	if got, want := Sections("service"), []string{"Unit", "Service", "Install"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Sections(service) = %q, want %q", got, want)
+	}
+	if got := Sections("network"); got != nil {
+		t.Errorf("Sections(network) = %q, want nil", got)
+	}
+	types := UnitTypes()
+	if !slices.IsSorted(types) || !slices.Contains(types, "automount") || !slices.Contains(types, "timer") {
+		t.Errorf("UnitTypes() = %q", types)
+	}
+	for _, typ := range types {
+		for _, s := range Sections(typ) {
+			if len(Options(typ, s)) == 0 {
+				t.Errorf("Options(%q, %q) is empty", typ, s)
+			}
+		}
+	}
+}
+
+func TestOptions(t *testing.T) {
+	opts := Options("path", "Path")
+	var names []string
+	for _, o := range opts {
+		names = append(names, o.Name)
+	}
+	want := []string{
+		"PathExists", "PathExistsGlob", "PathChanged", "PathModified", "DirectoryNotEmpty", "Unit",
+		"MakeDirectory", "DirectoryMode", "TriggerLimitIntervalSec", "TriggerLimitBurst",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Options(path, Path) = %q, want %q", names, want)
+	}
+
+	// the result is a copy
+	opts[0].Name = "Changed"
+	if o, ok := Lookup("path", "Path", "PathExists"); !ok || o.Name != "PathExists" {
+		t.Errorf("Lookup after modifying Options = %+v, %v", o, ok)
+	}
+	if got := Options("service", "Socket"); got != nil {
+		t.Errorf("Options(service, Socket) = %v, want nil", got)
+	}
+}
+
+func TestCatalog_Consistent(t *testing.T) {
+	for name, opts := range sections {
+		for _, o := range opts {
+			if o.Section != name {
+				t.Errorf("%s.%s is in section %s", o.Section, o.Name, name)
+			}
+			if o.Resettable && !o.List {
+				t.Errorf("%s.%s is resettable but not a list", o.Section, o.Name)
+			}
+			if o.ReplacedBy != "" {
+				section, option, _ := strings.Cut(o.ReplacedBy, ".")
+				if r, ok := index()[section][option]; !ok || sections[section][r].Deprecated {
+					t.Errorf("%s.%s is replaced by %s, which is missing or deprecated", o.Section, o.Name, o.ReplacedBy)
+				}
+			}
+			if o.Since > SystemdVersion {
+				t.Errorf("%s.%s is from systemd %d, after %d", o.Section, o.Name, o.Since, SystemdVersion)
+			}
+		}
+	}
+}
+
+// TestCatalog_Testdata checks that the catalog knows every option of the
+// unit files in testdata.
+func TestCatalog_Testdata(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("..", "testdata", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range paths {
+		unitType := strings.TrimPrefix(filepath.Ext(path), ".")
+		if Sections(unitType) == nil {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u, err := systemdconfig.Deserialize(strings.NewReader(string(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range u.Sections {
+			for _, o := range s.Options {
+				if _, ok := Lookup(unitType, s.Name, o.Option); !ok {
+					t.Errorf("%s: unknown option %s.%s", filepath.Base(path), s.Name, o.Option)
+				}
+			}
+		}
+	}
+}
+
+func TestValueType_String(t *testing.T) {
+	if got := Duration.String(); got != "duration" {
+		t.Errorf("Duration.String() = %q", got)
+	}
+	if got := ValueType(42).String(); got != "ValueType(42)" {
+		t.Errorf("ValueType(42).String() = %q", got)
+	}
+}
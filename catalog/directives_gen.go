@@ -0,0 +1,1222 @@
+// Code generated by gen.go from directives.txt and annotations.txt; DO NOT EDIT.
+
+package catalog
+
+const systemdVersion = 252
+
+// sections are the options of each section, in the order of systemd's table.
+var sections = map[string][]Option{
+	"Unit": {
+		{Section: "Unit", Name: "Description", Type: String, Syntax: "STRING"},
+		{Section: "Unit", Name: "Documentation", Type: String, Syntax: "URL", List: true, Resettable: true},
+		{Section: "Unit", Name: "SourcePath", Type: Path, Syntax: "PATH"},
+		{Section: "Unit", Name: "Requires", Type: Unit, Syntax: "UNIT [...]", List: true},
+		{Section: "Unit", Name: "Requisite", Type: Unit, Syntax: "UNIT [...]", List: true},
+		{Section: "Unit", Name: "Wants", Type: Unit, Syntax: "UNIT [...]", List: true},
+		{Section: "Unit", Name: "BindsTo", Type: Unit, Syntax: "UNIT [...]", List: true},
+		{Section: "Unit", Name: "BindTo", Type: Unit, Syntax: "UNIT [...]", List: true, Deprecated: true, ReplacedBy: "Unit.BindsTo"},
+		{Section: "Unit", Name: "Upholds", Type: Unit, Syntax: "UNIT [...]", List: true, Since: 249},
+		{Section: "Unit", Name: "Conflicts", Type: Unit, Syntax: "UNIT [...]", List: true},
+		{Section: "Unit", Name: "Before", Type: Unit, Syntax: "UNIT [...]", List: true},
+		{Section: "Unit", Name: "After", Type: Unit, Syntax: "UNIT [...]", List: true},
+		{Section: "Unit", Name: "OnSuccess", Type: Unit, Syntax: "UNIT [...]", List: true, Since: 249},
+		{Section: "Unit", Name: "OnFailure", Type: Unit, Syntax: "UNIT [...]", List: true},
+		{Section: "Unit", Name: "PropagatesReloadTo", Type: Unit, Syntax: "UNIT [...]", List: true},
+		{Section: "Unit", Name: "PropagateReloadTo", Type: Unit, Syntax: "UNIT [...]", List: true, Deprecated: true, ReplacedBy: "Unit.PropagatesReloadTo"},
+		{Section: "Unit", Name: "ReloadPropagatedFrom", Type: Unit, Syntax: "UNIT [...]", List: true},
+		{Section: "Unit", Name: "PropagateReloadFrom", Type: Unit, Syntax: "UNIT [...]", List: true, Deprecated: true, ReplacedBy: "Unit.ReloadPropagatedFrom"},
+		{Section: "Unit", Name: "PropagatesStopTo", Type: Unit, Syntax: "UNIT [...]", List: true, Since: 249},
+		{Section: "Unit", Name: "StopPropagatedFrom", Type: Unit, Syntax: "UNIT [...]", List: true, Since: 249},
+		{Section: "Unit", Name: "PartOf", Type: Unit, Syntax: "UNIT [...]", List: true},
+		{Section: "Unit", Name: "JoinsNamespaceOf", Type: Unit, Syntax: "UNIT [...]", List: true},
+		{Section: "Unit", Name: "RequiresOverridable", Type: String, Syntax: "OTHER", Deprecated: true, ReplacedBy: "Unit.Requires"},
+		{Section: "Unit", Name: "RequisiteOverridable", Type: String, Syntax: "OTHER", Deprecated: true, ReplacedBy: "Unit.Requisite"},
+		{Section: "Unit", Name: "RequiresMountsFor", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true},
+		{Section: "Unit", Name: "StopWhenUnneeded", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Unit", Name: "RefuseManualStart", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Unit", Name: "RefuseManualStop", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Unit", Name: "AllowIsolate", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Unit", Name: "DefaultDependencies", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Unit", Name: "OnSuccessJobMode", Type: String, Syntax: "MODE", Since: 249},
+		{Section: "Unit", Name: "OnFailureJobMode", Type: String, Syntax: "MODE"},
+		{Section: "Unit", Name: "OnFailureIsolate", Type: Bool, Syntax: "BOOLEAN", Deprecated: true, ReplacedBy: "Unit.OnFailureJobMode"},
+		{Section: "Unit", Name: "IgnoreOnIsolate", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Unit", Name: "JobTimeoutSec", Type: Duration, Syntax: "OTHER"},
+		{Section: "Unit", Name: "JobRunningTimeoutSec", Type: Duration, Syntax: "OTHER"},
+		{Section: "Unit", Name: "JobTimeoutAction", Type: String, Syntax: "ACTION"},
+		{Section: "Unit", Name: "JobTimeoutRebootArgument", Type: String, Syntax: "OTHER"},
+		{Section: "Unit", Name: "StartLimitIntervalSec", Type: Duration, Syntax: "SECONDS", Since: 230},
+		{Section: "Unit", Name: "StartLimitInterval", Type: Duration, Syntax: "SECONDS", Deprecated: true, ReplacedBy: "Unit.StartLimitIntervalSec"},
+		{Section: "Unit", Name: "StartLimitBurst", Type: Int, Syntax: "UNSIGNED"},
+		{Section: "Unit", Name: "StartLimitAction", Type: String, Syntax: "ACTION"},
+		{Section: "Unit", Name: "FailureAction", Type: String, Syntax: "ACTION"},
+		{Section: "Unit", Name: "SuccessAction", Type: String, Syntax: "ACTION", Since: 236},
+		{Section: "Unit", Name: "FailureActionExitStatus", Type: String, Syntax: "OTHER"},
+		{Section: "Unit", Name: "SuccessActionExitStatus", Type: String, Syntax: "OTHER"},
+		{Section: "Unit", Name: "RebootArgument", Type: String, Syntax: "OTHER"},
+		{Section: "Unit", Name: "ConditionPathExists", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionPathExistsGlob", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionPathIsDirectory", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionPathIsSymbolicLink", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionPathIsMountPoint", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionPathIsReadWrite", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionPathIsEncrypted", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 246},
+		{Section: "Unit", Name: "ConditionDirectoryNotEmpty", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionFileNotEmpty", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionFileIsExecutable", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionNeedsUpdate", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionFirstBoot", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionArchitecture", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionFirmware", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 249},
+		{Section: "Unit", Name: "ConditionVirtualization", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionHost", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionKernelCommandLine", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionKernelVersion", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionCredential", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 252},
+		{Section: "Unit", Name: "ConditionSecurity", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionCapability", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionACPower", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "ConditionMemory", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 244},
+		{Section: "Unit", Name: "ConditionCPUFeature", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 248},
+		{Section: "Unit", Name: "ConditionCPUs", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 244},
+		{Section: "Unit", Name: "ConditionEnvironment", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 246},
+		{Section: "Unit", Name: "ConditionUser", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 234},
+		{Section: "Unit", Name: "ConditionGroup", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 234},
+		{Section: "Unit", Name: "ConditionControlGroupController", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 236},
+		{Section: "Unit", Name: "ConditionOSRelease", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 249},
+		{Section: "Unit", Name: "ConditionMemoryPressure", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 250},
+		{Section: "Unit", Name: "ConditionCPUPressure", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 250},
+		{Section: "Unit", Name: "ConditionIOPressure", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 250},
+		{Section: "Unit", Name: "AssertPathExists", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertPathExistsGlob", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertPathIsDirectory", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertPathIsSymbolicLink", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertPathIsMountPoint", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertPathIsReadWrite", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertPathIsEncrypted", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 246},
+		{Section: "Unit", Name: "AssertDirectoryNotEmpty", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertFileNotEmpty", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertFileIsExecutable", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertNeedsUpdate", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertFirstBoot", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertArchitecture", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertVirtualization", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertHost", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertKernelCommandLine", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertKernelVersion", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertCredential", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 252},
+		{Section: "Unit", Name: "AssertSecurity", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertCapability", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertACPower", Type: String, Syntax: "CONDITION", List: true, Resettable: true},
+		{Section: "Unit", Name: "AssertMemory", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 244},
+		{Section: "Unit", Name: "AssertCPUFeature", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 248},
+		{Section: "Unit", Name: "AssertCPUs", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 244},
+		{Section: "Unit", Name: "AssertEnvironment", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 246},
+		{Section: "Unit", Name: "AssertUser", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 234},
+		{Section: "Unit", Name: "AssertGroup", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 234},
+		{Section: "Unit", Name: "AssertControlGroupController", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 236},
+		{Section: "Unit", Name: "AssertOSRelease", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 249},
+		{Section: "Unit", Name: "AssertMemoryPressure", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 250},
+		{Section: "Unit", Name: "AssertCPUPressure", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 250},
+		{Section: "Unit", Name: "AssertIOPressure", Type: String, Syntax: "CONDITION", List: true, Resettable: true, Since: 250},
+		{Section: "Unit", Name: "CollectMode", Type: String, Syntax: "OTHER"},
+	},
+	"Service": {
+		{Section: "Service", Name: "PIDFile", Type: Path, Syntax: "OTHER"},
+		{Section: "Service", Name: "ExecCondition", Type: Command, Syntax: "PATH [ARGUMENT [...]]", List: true, Resettable: true, Since: 243},
+		{Section: "Service", Name: "ExecStartPre", Type: Command, Syntax: "PATH [ARGUMENT [...]]", List: true, Resettable: true},
+		{Section: "Service", Name: "ExecStart", Type: Command, Syntax: "PATH [ARGUMENT [...]]", List: true, Resettable: true},
+		{Section: "Service", Name: "ExecStartPost", Type: Command, Syntax: "PATH [ARGUMENT [...]]", List: true, Resettable: true},
+		{Section: "Service", Name: "ExecReload", Type: Command, Syntax: "PATH [ARGUMENT [...]]", List: true, Resettable: true},
+		{Section: "Service", Name: "ExecStop", Type: Command, Syntax: "PATH [ARGUMENT [...]]", List: true, Resettable: true},
+		{Section: "Service", Name: "ExecStopPost", Type: Command, Syntax: "PATH [ARGUMENT [...]]", List: true, Resettable: true},
+		{Section: "Service", Name: "RestartSec", Type: Duration, Syntax: "SECONDS"},
+		{Section: "Service", Name: "TimeoutSec", Type: Duration, Syntax: "SECONDS"},
+		{Section: "Service", Name: "TimeoutStartSec", Type: Duration, Syntax: "SECONDS"},
+		{Section: "Service", Name: "TimeoutStopSec", Type: Duration, Syntax: "OTHER"},
+		{Section: "Service", Name: "TimeoutAbortSec", Type: Duration, Syntax: "OTHER", Since: 243},
+		{Section: "Service", Name: "TimeoutStartFailureMode", Type: String, Syntax: "TIMEOUTMODE", Since: 246},
+		{Section: "Service", Name: "TimeoutStopFailureMode", Type: String, Syntax: "TIMEOUTMODE", Since: 246},
+		{Section: "Service", Name: "RuntimeMaxSec", Type: Duration, Syntax: "SECONDS", Since: 229},
+		{Section: "Service", Name: "RuntimeRandomizedExtraSec", Type: Duration, Syntax: "SECONDS", Since: 250},
+		{Section: "Service", Name: "WatchdogSec", Type: Duration, Syntax: "SECONDS"},
+		{Section: "Service", Name: "StartLimitInterval", Type: Duration, Syntax: "SECONDS", Deprecated: true, ReplacedBy: "Unit.StartLimitIntervalSec"},
+		{Section: "Service", Name: "StartLimitBurst", Type: Int, Syntax: "UNSIGNED", Deprecated: true, ReplacedBy: "Unit.StartLimitBurst"},
+		{Section: "Service", Name: "StartLimitAction", Type: String, Syntax: "ACTION", Deprecated: true, ReplacedBy: "Unit.StartLimitAction"},
+		{Section: "Service", Name: "FailureAction", Type: String, Syntax: "ACTION", Deprecated: true, ReplacedBy: "Unit.FailureAction"},
+		{Section: "Service", Name: "RebootArgument", Type: String, Syntax: "STRING", Deprecated: true, ReplacedBy: "Unit.RebootArgument"},
+		{Section: "Service", Name: "Type", Type: String, Syntax: "SERVICETYPE"},
+		{Section: "Service", Name: "ExitType", Type: String, Syntax: "SERVICEEXITTYPE", Since: 250},
+		{Section: "Service", Name: "Restart", Type: String, Syntax: "SERVICERESTART"},
+		{Section: "Service", Name: "PermissionsStartOnly", Type: Bool, Syntax: "BOOLEAN", Deprecated: true},
+		{Section: "Service", Name: "RootDirectoryStartOnly", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "RemainAfterExit", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "GuessMainPID", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "RestartPreventExitStatus", Type: String, Syntax: "STATUS", List: true, Resettable: true},
+		{Section: "Service", Name: "RestartForceExitStatus", Type: String, Syntax: "STATUS", List: true, Resettable: true},
+		{Section: "Service", Name: "SuccessExitStatus", Type: String, Syntax: "STATUS", List: true, Resettable: true},
+		{Section: "Service", Name: "NonBlocking", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "BusName", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "FileDescriptorStoreMax", Type: Int, Syntax: "UNSIGNED"},
+		{Section: "Service", Name: "NotifyAccess", Type: String, Syntax: "ACCESS"},
+		{Section: "Service", Name: "Sockets", Type: String, Syntax: "SOCKETS", List: true, Resettable: true},
+		{Section: "Service", Name: "USBFunctionDescriptors", Type: Path, Syntax: "PATH"},
+		{Section: "Service", Name: "USBFunctionStrings", Type: Path, Syntax: "PATH"},
+		{Section: "Service", Name: "OOMPolicy", Type: String, Syntax: "OTHER", Since: 243},
+		{Section: "Service", Name: "WorkingDirectory", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "RootDirectory", Type: Path, Syntax: "PATH"},
+		{Section: "Service", Name: "RootImage", Type: Path, Syntax: "PATH"},
+		{Section: "Service", Name: "RootImageOptions", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "RootHash", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "RootHashSignature", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "RootVerity", Type: Path, Syntax: "PATH"},
+		{Section: "Service", Name: "ExtensionDirectories", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 251},
+		{Section: "Service", Name: "ExtensionImages", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 248},
+		{Section: "Service", Name: "MountImages", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 247},
+		{Section: "Service", Name: "User", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "Group", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "SupplementaryGroups", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Service", Name: "Nice", Type: Int, Syntax: "NICE"},
+		{Section: "Service", Name: "OOMScoreAdjust", Type: Int, Syntax: "OOMSCOREADJUST"},
+		{Section: "Service", Name: "CoredumpFilter", Type: String, Syntax: "OTHER", Since: 246},
+		{Section: "Service", Name: "IOSchedulingClass", Type: String, Syntax: "IOCLASS"},
+		{Section: "Service", Name: "IOSchedulingPriority", Type: String, Syntax: "IOPRIORITY"},
+		{Section: "Service", Name: "CPUSchedulingPolicy", Type: String, Syntax: "CPUSCHEDPOLICY"},
+		{Section: "Service", Name: "CPUSchedulingPriority", Type: Int, Syntax: "CPUSCHEDPRIO"},
+		{Section: "Service", Name: "CPUSchedulingResetOnFork", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "CPUAffinity", Type: String, Syntax: "CPUAFFINITY"},
+		{Section: "Service", Name: "NUMAPolicy", Type: String, Syntax: "OTHER", Since: 243},
+		{Section: "Service", Name: "NUMAMask", Type: String, Syntax: "OTHER", Since: 243},
+		{Section: "Service", Name: "UMask", Type: Mode, Syntax: "MODE"},
+		{Section: "Service", Name: "Environment", Type: String, Syntax: "ENVIRON", List: true, Resettable: true},
+		{Section: "Service", Name: "EnvironmentFile", Type: Path, Syntax: "FILE", List: true, Resettable: true},
+		{Section: "Service", Name: "PassEnvironment", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Service", Name: "UnsetEnvironment", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Service", Name: "DynamicUser", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Service", Name: "RemoveIPC", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "StandardInput", Type: String, Syntax: "INPUT"},
+		{Section: "Service", Name: "StandardOutput", Type: String, Syntax: "OUTPUT"},
+		{Section: "Service", Name: "StandardError", Type: String, Syntax: "OUTPUT"},
+		{Section: "Service", Name: "StandardInputText", Type: String, Syntax: "OTHER", Since: 236},
+		{Section: "Service", Name: "StandardInputData", Type: String, Syntax: "OTHER", Since: 236},
+		{Section: "Service", Name: "TTYPath", Type: Path, Syntax: "PATH"},
+		{Section: "Service", Name: "TTYReset", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "TTYVHangup", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "TTYVTDisallocate", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "TTYRows", Type: Int, Syntax: "OTHER"},
+		{Section: "Service", Name: "TTYColumns", Type: Int, Syntax: "OTHER"},
+		{Section: "Service", Name: "SyslogIdentifier", Type: String, Syntax: "STRING"},
+		{Section: "Service", Name: "SyslogFacility", Type: String, Syntax: "FACILITY"},
+		{Section: "Service", Name: "SyslogLevel", Type: String, Syntax: "LEVEL"},
+		{Section: "Service", Name: "SyslogLevelPrefix", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "LogLevelMax", Type: String, Syntax: "LEVEL"},
+		{Section: "Service", Name: "LogRateLimitIntervalSec", Type: Duration, Syntax: "SECONDS", Since: 240},
+		{Section: "Service", Name: "LogRateLimitBurst", Type: Int, Syntax: "UNSIGNED", Since: 240},
+		{Section: "Service", Name: "LogExtraFields", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Service", Name: "SecureBits", Type: String, Syntax: "SECUREBITS"},
+		{Section: "Service", Name: "CapabilityBoundingSet", Type: String, Syntax: "BOUNDINGSET", List: true, Resettable: true},
+		{Section: "Service", Name: "AmbientCapabilities", Type: String, Syntax: "BOUNDINGSET", List: true, Resettable: true},
+		{Section: "Service", Name: "TimerSlackNSec", Type: Duration, Syntax: "NANOSECONDS"},
+		{Section: "Service", Name: "NoNewPrivileges", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "KeyringMode", Type: String, Syntax: "OTHER", Since: 235},
+		{Section: "Service", Name: "ProtectProc", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Service", Name: "ProcSubset", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Service", Name: "SystemCallFilter", Type: String, Syntax: "SYSCALLS", List: true, Resettable: true},
+		{Section: "Service", Name: "SystemCallArchitectures", Type: String, Syntax: "ARCHS", List: true, Resettable: true},
+		{Section: "Service", Name: "SystemCallErrorNumber", Type: String, Syntax: "ERRNO"},
+		{Section: "Service", Name: "SystemCallLog", Type: String, Syntax: "SYSCALLS", List: true, Resettable: true, Since: 247},
+		{Section: "Service", Name: "MemoryDenyWriteExecute", Type: Bool, Syntax: "BOOLEAN", Since: 231},
+		{Section: "Service", Name: "RestrictNamespaces", Type: String, Syntax: "NAMESPACES", Since: 233},
+		{Section: "Service", Name: "RestrictRealtime", Type: Bool, Syntax: "BOOLEAN", Since: 231},
+		{Section: "Service", Name: "RestrictSUIDSGID", Type: Bool, Syntax: "BOOLEAN", Since: 242},
+		{Section: "Service", Name: "RestrictAddressFamilies", Type: String, Syntax: "FAMILIES", List: true, Resettable: true},
+		{Section: "Service", Name: "LockPersonality", Type: Bool, Syntax: "BOOLEAN", Since: 235},
+		{Section: "Service", Name: "RestrictFileSystems", Type: String, Syntax: "FILESYSTEMS", List: true, Resettable: true, Since: 250},
+		{Section: "Service", Name: "LimitCPU", Type: String, Syntax: "LIMIT"},
+		{Section: "Service", Name: "LimitFSIZE", Type: String, Syntax: "LIMIT"},
+		{Section: "Service", Name: "LimitDATA", Type: String, Syntax: "LIMIT"},
+		{Section: "Service", Name: "LimitSTACK", Type: String, Syntax: "LIMIT"},
+		{Section: "Service", Name: "LimitCORE", Type: String, Syntax: "LIMIT"},
+		{Section: "Service", Name: "LimitRSS", Type: String, Syntax: "LIMIT"},
+		{Section: "Service", Name: "LimitNOFILE", Type: String, Syntax: "LIMIT"},
+		{Section: "Service", Name: "LimitAS", Type: String, Syntax: "LIMIT"},
+		{Section: "Service", Name: "LimitNPROC", Type: String, Syntax: "LIMIT"},
+		{Section: "Service", Name: "LimitMEMLOCK", Type: String, Syntax: "LIMIT"},
+		{Section: "Service", Name: "LimitLOCKS", Type: String, Syntax: "LIMIT"},
+		{Section: "Service", Name: "LimitSIGPENDING", Type: String, Syntax: "LIMIT"},
+		{Section: "Service", Name: "LimitMSGQUEUE", Type: String, Syntax: "LIMIT"},
+		{Section: "Service", Name: "LimitNICE", Type: String, Syntax: "LIMIT"},
+		{Section: "Service", Name: "LimitRTPRIO", Type: String, Syntax: "LIMIT"},
+		{Section: "Service", Name: "LimitRTTIME", Type: String, Syntax: "LIMIT"},
+		{Section: "Service", Name: "ReadWriteDirectories", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Service.ReadWritePaths"},
+		{Section: "Service", Name: "ReadOnlyDirectories", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Service.ReadOnlyPaths"},
+		{Section: "Service", Name: "InaccessibleDirectories", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Service.InaccessiblePaths"},
+		{Section: "Service", Name: "ReadWritePaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 231},
+		{Section: "Service", Name: "ReadOnlyPaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 231},
+		{Section: "Service", Name: "InaccessiblePaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 231},
+		{Section: "Service", Name: "ExecPaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 247},
+		{Section: "Service", Name: "NoExecPaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 247},
+		{Section: "Service", Name: "ExecSearchPath", Type: Path, Syntax: "PATH", Since: 250},
+		{Section: "Service", Name: "BindPaths", Type: String, Syntax: "PATH[:PATH[:OPTIONS]] [...]", List: true, Resettable: true, Since: 233},
+		{Section: "Service", Name: "BindReadOnlyPaths", Type: String, Syntax: "PATH[:PATH[:OPTIONS]] [...]", List: true, Resettable: true, Since: 233},
+		{Section: "Service", Name: "TemporaryFileSystem", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 238},
+		{Section: "Service", Name: "PrivateTmp", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "PrivateDevices", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "ProtectKernelTunables", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Service", Name: "ProtectKernelModules", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Service", Name: "ProtectKernelLogs", Type: Bool, Syntax: "BOOLEAN", Since: 244},
+		{Section: "Service", Name: "ProtectClock", Type: Bool, Syntax: "BOOLEAN", Since: 245},
+		{Section: "Service", Name: "ProtectControlGroups", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Service", Name: "NetworkNamespacePath", Type: Path, Syntax: "PATH"},
+		{Section: "Service", Name: "IPCNamespacePath", Type: Path, Syntax: "PATH"},
+		{Section: "Service", Name: "LogNamespace", Type: String, Syntax: "OTHER", Since: 245},
+		{Section: "Service", Name: "PrivateNetwork", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "PrivateUsers", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Service", Name: "PrivateMounts", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "PrivateIPC", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "ProtectSystem", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "ProtectHome", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "MountFlags", Type: String, Syntax: "MOUNTFLAG [...]", List: true, Resettable: true},
+		{Section: "Service", Name: "MountAPIVFS", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "Personality", Type: String, Syntax: "PERSONALITY"},
+		{Section: "Service", Name: "RuntimeDirectoryPreserve", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "RuntimeDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Service", Name: "RuntimeDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Service", Name: "StateDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Service", Name: "StateDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Service", Name: "CacheDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Service", Name: "CacheDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Service", Name: "LogsDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Service", Name: "LogsDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Service", Name: "ConfigurationDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Service", Name: "ConfigurationDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Service", Name: "SetCredential", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 247},
+		{Section: "Service", Name: "SetCredentialEncrypted", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 250},
+		{Section: "Service", Name: "LoadCredential", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 247},
+		{Section: "Service", Name: "LoadCredentialEncrypted", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 250},
+		{Section: "Service", Name: "TimeoutCleanSec", Type: Duration, Syntax: "SECONDS"},
+		{Section: "Service", Name: "PAMName", Type: String, Syntax: "STRING"},
+		{Section: "Service", Name: "IgnoreSIGPIPE", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "UtmpIdentifier", Type: String, Syntax: "STRING"},
+		{Section: "Service", Name: "UtmpMode", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "SELinuxContext", Type: String, Syntax: "LABEL"},
+		{Section: "Service", Name: "AppArmorProfile", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "SmackProcessLabel", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "ProtectHostname", Type: Bool, Syntax: "BOOLEAN", Since: 242},
+		{Section: "Service", Name: "Slice", Type: Unit, Syntax: "SLICE"},
+		{Section: "Service", Name: "AllowedCPUs", Type: String, Syntax: "OTHER", Since: 244},
+		{Section: "Service", Name: "StartupAllowedCPUs", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "AllowedMemoryNodes", Type: String, Syntax: "OTHER", Since: 244},
+		{Section: "Service", Name: "StartupAllowedMemoryNodes", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "CPUAccounting", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "CPUWeight", Type: String, Syntax: "CPUWEIGHT", Since: 232},
+		{Section: "Service", Name: "StartupCPUWeight", Type: String, Syntax: "CPUWEIGHT", Since: 232},
+		{Section: "Service", Name: "CPUShares", Type: Int, Syntax: "SHARES", Deprecated: true, ReplacedBy: "Service.CPUWeight"},
+		{Section: "Service", Name: "StartupCPUShares", Type: Int, Syntax: "SHARES", Deprecated: true, ReplacedBy: "Service.StartupCPUWeight"},
+		{Section: "Service", Name: "CPUQuota", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "CPUQuotaPeriodSec", Type: Duration, Syntax: "OTHER", Since: 242},
+		{Section: "Service", Name: "MemoryAccounting", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "MemoryMin", Type: String, Syntax: "LIMIT", Since: 240},
+		{Section: "Service", Name: "DefaultMemoryMin", Type: String, Syntax: "LIMIT"},
+		{Section: "Service", Name: "DefaultMemoryLow", Type: String, Syntax: "LIMIT", Since: 240},
+		{Section: "Service", Name: "MemoryLow", Type: String, Syntax: "LIMIT", Since: 231},
+		{Section: "Service", Name: "MemoryHigh", Type: String, Syntax: "LIMIT", Since: 231},
+		{Section: "Service", Name: "MemoryMax", Type: String, Syntax: "LIMIT", Since: 231},
+		{Section: "Service", Name: "MemorySwapMax", Type: String, Syntax: "LIMIT", Since: 232},
+		{Section: "Service", Name: "MemoryLimit", Type: String, Syntax: "LIMIT", Deprecated: true, ReplacedBy: "Service.MemoryMax"},
+		{Section: "Service", Name: "DeviceAllow", Type: String, Syntax: "DEVICE", List: true, Resettable: true},
+		{Section: "Service", Name: "DevicePolicy", Type: String, Syntax: "POLICY"},
+		{Section: "Service", Name: "IOAccounting", Type: Bool, Syntax: "BOOLEAN", Since: 230},
+		{Section: "Service", Name: "IOWeight", Type: Int, Syntax: "WEIGHT", Since: 230},
+		{Section: "Service", Name: "StartupIOWeight", Type: Int, Syntax: "WEIGHT", Since: 230},
+		{Section: "Service", Name: "IODeviceWeight", Type: String, Syntax: "DEVICEWEIGHT", List: true, Resettable: true, Since: 230},
+		{Section: "Service", Name: "IOReadBandwidthMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Service", Name: "IOWriteBandwidthMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Service", Name: "IOReadIOPSMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Service", Name: "IOWriteIOPSMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Service", Name: "IODeviceLatencyTargetSec", Type: String, Syntax: "DEVICELATENCY", List: true, Resettable: true},
+		{Section: "Service", Name: "BlockIOAccounting", Type: Bool, Syntax: "BOOLEAN", Deprecated: true, ReplacedBy: "Service.IOAccounting"},
+		{Section: "Service", Name: "BlockIOWeight", Type: Int, Syntax: "WEIGHT", Deprecated: true, ReplacedBy: "Service.IOWeight"},
+		{Section: "Service", Name: "StartupBlockIOWeight", Type: Int, Syntax: "WEIGHT", Deprecated: true, ReplacedBy: "Service.StartupIOWeight"},
+		{Section: "Service", Name: "BlockIODeviceWeight", Type: String, Syntax: "DEVICEWEIGHT", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Service.IODeviceWeight"},
+		{Section: "Service", Name: "BlockIOReadBandwidth", Type: String, Syntax: "BANDWIDTH", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Service.IOReadBandwidthMax"},
+		{Section: "Service", Name: "BlockIOWriteBandwidth", Type: String, Syntax: "BANDWIDTH", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Service.IOWriteBandwidthMax"},
+		{Section: "Service", Name: "TasksAccounting", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "TasksMax", Type: String, Syntax: "OTHER", Since: 227},
+		{Section: "Service", Name: "Delegate", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "DisableControllers", Type: String, Syntax: "OTHER"},
+		{Section: "Service", Name: "IPAccounting", Type: Bool, Syntax: "BOOLEAN", Since: 235},
+		{Section: "Service", Name: "IPAddressAllow", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Service", Name: "IPAddressDeny", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Service", Name: "IPIngressFilterPath", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 243},
+		{Section: "Service", Name: "IPEgressFilterPath", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 243},
+		{Section: "Service", Name: "ManagedOOMSwap", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Service", Name: "ManagedOOMMemoryPressure", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Service", Name: "ManagedOOMMemoryPressureLimit", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Service", Name: "ManagedOOMPreference", Type: String, Syntax: "OTHER", Since: 248},
+		{Section: "Service", Name: "BPFProgram", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 249},
+		{Section: "Service", Name: "SocketBindAllow", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 249},
+		{Section: "Service", Name: "SocketBindDeny", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 249},
+		{Section: "Service", Name: "RestrictNetworkInterfaces", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 250},
+		{Section: "Service", Name: "SendSIGKILL", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "SendSIGHUP", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Service", Name: "KillMode", Type: String, Syntax: "KILLMODE"},
+		{Section: "Service", Name: "KillSignal", Type: String, Syntax: "SIGNAL"},
+		{Section: "Service", Name: "RestartKillSignal", Type: String, Syntax: "SIGNAL", Since: 244},
+		{Section: "Service", Name: "FinalKillSignal", Type: String, Syntax: "SIGNAL"},
+		{Section: "Service", Name: "WatchdogSignal", Type: String, Syntax: "SIGNAL"},
+	},
+	"Socket": {
+		{Section: "Socket", Name: "ListenStream", Type: String, Syntax: "SOCKET [...]", List: true, Resettable: true},
+		{Section: "Socket", Name: "ListenDatagram", Type: String, Syntax: "SOCKET [...]", List: true, Resettable: true},
+		{Section: "Socket", Name: "ListenSequentialPacket", Type: String, Syntax: "SOCKET [...]", List: true, Resettable: true},
+		{Section: "Socket", Name: "ListenFIFO", Type: String, Syntax: "SOCKET [...]", List: true, Resettable: true},
+		{Section: "Socket", Name: "ListenNetlink", Type: String, Syntax: "SOCKET [...]", List: true, Resettable: true},
+		{Section: "Socket", Name: "ListenSpecial", Type: String, Syntax: "SOCKET [...]", List: true, Resettable: true},
+		{Section: "Socket", Name: "ListenMessageQueue", Type: String, Syntax: "SOCKET [...]", List: true, Resettable: true},
+		{Section: "Socket", Name: "ListenUSBFunction", Type: String, Syntax: "SOCKET [...]", List: true, Resettable: true},
+		{Section: "Socket", Name: "SocketProtocol", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "BindIPv6Only", Type: String, Syntax: "SOCKETBIND"},
+		{Section: "Socket", Name: "Backlog", Type: Int, Syntax: "UNSIGNED"},
+		{Section: "Socket", Name: "BindToDevice", Type: String, Syntax: "NETWORKINTERFACE"},
+		{Section: "Socket", Name: "ExecStartPre", Type: Command, Syntax: "PATH [ARGUMENT [...]]", List: true, Resettable: true},
+		{Section: "Socket", Name: "ExecStartPost", Type: Command, Syntax: "PATH [ARGUMENT [...]]", List: true, Resettable: true},
+		{Section: "Socket", Name: "ExecStopPre", Type: Command, Syntax: "PATH [ARGUMENT [...]]", List: true, Resettable: true},
+		{Section: "Socket", Name: "ExecStopPost", Type: Command, Syntax: "PATH [ARGUMENT [...]]", List: true, Resettable: true},
+		{Section: "Socket", Name: "TimeoutSec", Type: Duration, Syntax: "OTHER"},
+		{Section: "Socket", Name: "SocketUser", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "SocketGroup", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "SocketMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Socket", Name: "DirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Socket", Name: "Accept", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "FlushPending", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "Writable", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "MaxConnections", Type: Int, Syntax: "UNSIGNED"},
+		{Section: "Socket", Name: "MaxConnectionsPerSource", Type: Int, Syntax: "UNSIGNED"},
+		{Section: "Socket", Name: "KeepAlive", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "KeepAliveTimeSec", Type: Duration, Syntax: "SECONDS"},
+		{Section: "Socket", Name: "KeepAliveIntervalSec", Type: Duration, Syntax: "SECONDS"},
+		{Section: "Socket", Name: "KeepAliveProbes", Type: Int, Syntax: "UNSIGNED"},
+		{Section: "Socket", Name: "DeferAcceptSec", Type: Duration, Syntax: "SECONDS"},
+		{Section: "Socket", Name: "NoDelay", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "Priority", Type: Int, Syntax: "INTEGER"},
+		{Section: "Socket", Name: "ReceiveBuffer", Type: Bytes, Syntax: "SIZE"},
+		{Section: "Socket", Name: "SendBuffer", Type: Bytes, Syntax: "SIZE"},
+		{Section: "Socket", Name: "IPTOS", Type: String, Syntax: "TOS"},
+		{Section: "Socket", Name: "IPTTL", Type: Int, Syntax: "INTEGER"},
+		{Section: "Socket", Name: "Mark", Type: Int, Syntax: "INTEGER"},
+		{Section: "Socket", Name: "PipeSize", Type: Bytes, Syntax: "SIZE"},
+		{Section: "Socket", Name: "FreeBind", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "Transparent", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "Broadcast", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "PassCredentials", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "PassSecurity", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "PassPacketInfo", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "Timestamping", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Socket", Name: "TCPCongestion", Type: String, Syntax: "STRING"},
+		{Section: "Socket", Name: "ReusePort", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "MessageQueueMaxMessages", Type: Int, Syntax: "LONG"},
+		{Section: "Socket", Name: "MessageQueueMessageSize", Type: Int, Syntax: "LONG"},
+		{Section: "Socket", Name: "RemoveOnStop", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "Symlinks", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Socket", Name: "FileDescriptorName", Type: String, Syntax: "OTHER", Since: 227},
+		{Section: "Socket", Name: "Service", Type: Unit, Syntax: "SERVICE"},
+		{Section: "Socket", Name: "TriggerLimitIntervalSec", Type: Duration, Syntax: "SECONDS", Since: 230},
+		{Section: "Socket", Name: "TriggerLimitBurst", Type: Int, Syntax: "UNSIGNED", Since: 230},
+		{Section: "Socket", Name: "SmackLabel", Type: String, Syntax: "STRING"},
+		{Section: "Socket", Name: "SmackLabelIPIn", Type: String, Syntax: "STRING"},
+		{Section: "Socket", Name: "SmackLabelIPOut", Type: String, Syntax: "STRING"},
+		{Section: "Socket", Name: "SELinuxContextFromNet", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "WorkingDirectory", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "RootDirectory", Type: Path, Syntax: "PATH"},
+		{Section: "Socket", Name: "RootImage", Type: Path, Syntax: "PATH"},
+		{Section: "Socket", Name: "RootImageOptions", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "RootHash", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "RootHashSignature", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "RootVerity", Type: Path, Syntax: "PATH"},
+		{Section: "Socket", Name: "ExtensionDirectories", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 251},
+		{Section: "Socket", Name: "ExtensionImages", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 248},
+		{Section: "Socket", Name: "MountImages", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 247},
+		{Section: "Socket", Name: "User", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "Group", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "SupplementaryGroups", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Socket", Name: "Nice", Type: Int, Syntax: "NICE"},
+		{Section: "Socket", Name: "OOMScoreAdjust", Type: Int, Syntax: "OOMSCOREADJUST"},
+		{Section: "Socket", Name: "CoredumpFilter", Type: String, Syntax: "OTHER", Since: 246},
+		{Section: "Socket", Name: "IOSchedulingClass", Type: String, Syntax: "IOCLASS"},
+		{Section: "Socket", Name: "IOSchedulingPriority", Type: String, Syntax: "IOPRIORITY"},
+		{Section: "Socket", Name: "CPUSchedulingPolicy", Type: String, Syntax: "CPUSCHEDPOLICY"},
+		{Section: "Socket", Name: "CPUSchedulingPriority", Type: Int, Syntax: "CPUSCHEDPRIO"},
+		{Section: "Socket", Name: "CPUSchedulingResetOnFork", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "CPUAffinity", Type: String, Syntax: "CPUAFFINITY"},
+		{Section: "Socket", Name: "NUMAPolicy", Type: String, Syntax: "OTHER", Since: 243},
+		{Section: "Socket", Name: "NUMAMask", Type: String, Syntax: "OTHER", Since: 243},
+		{Section: "Socket", Name: "UMask", Type: Mode, Syntax: "MODE"},
+		{Section: "Socket", Name: "Environment", Type: String, Syntax: "ENVIRON", List: true, Resettable: true},
+		{Section: "Socket", Name: "EnvironmentFile", Type: Path, Syntax: "FILE", List: true, Resettable: true},
+		{Section: "Socket", Name: "PassEnvironment", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Socket", Name: "UnsetEnvironment", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Socket", Name: "DynamicUser", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Socket", Name: "RemoveIPC", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "StandardInput", Type: String, Syntax: "INPUT"},
+		{Section: "Socket", Name: "StandardOutput", Type: String, Syntax: "OUTPUT"},
+		{Section: "Socket", Name: "StandardError", Type: String, Syntax: "OUTPUT"},
+		{Section: "Socket", Name: "StandardInputText", Type: String, Syntax: "OTHER", Since: 236},
+		{Section: "Socket", Name: "StandardInputData", Type: String, Syntax: "OTHER", Since: 236},
+		{Section: "Socket", Name: "TTYPath", Type: Path, Syntax: "PATH"},
+		{Section: "Socket", Name: "TTYReset", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "TTYVHangup", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "TTYVTDisallocate", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "TTYRows", Type: Int, Syntax: "OTHER"},
+		{Section: "Socket", Name: "TTYColumns", Type: Int, Syntax: "OTHER"},
+		{Section: "Socket", Name: "SyslogIdentifier", Type: String, Syntax: "STRING"},
+		{Section: "Socket", Name: "SyslogFacility", Type: String, Syntax: "FACILITY"},
+		{Section: "Socket", Name: "SyslogLevel", Type: String, Syntax: "LEVEL"},
+		{Section: "Socket", Name: "SyslogLevelPrefix", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "LogLevelMax", Type: String, Syntax: "LEVEL"},
+		{Section: "Socket", Name: "LogRateLimitIntervalSec", Type: Duration, Syntax: "SECONDS", Since: 240},
+		{Section: "Socket", Name: "LogRateLimitBurst", Type: Int, Syntax: "UNSIGNED", Since: 240},
+		{Section: "Socket", Name: "LogExtraFields", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Socket", Name: "SecureBits", Type: String, Syntax: "SECUREBITS"},
+		{Section: "Socket", Name: "CapabilityBoundingSet", Type: String, Syntax: "BOUNDINGSET", List: true, Resettable: true},
+		{Section: "Socket", Name: "AmbientCapabilities", Type: String, Syntax: "BOUNDINGSET", List: true, Resettable: true},
+		{Section: "Socket", Name: "TimerSlackNSec", Type: Duration, Syntax: "NANOSECONDS"},
+		{Section: "Socket", Name: "NoNewPrivileges", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "KeyringMode", Type: String, Syntax: "OTHER", Since: 235},
+		{Section: "Socket", Name: "ProtectProc", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Socket", Name: "ProcSubset", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Socket", Name: "SystemCallFilter", Type: String, Syntax: "SYSCALLS", List: true, Resettable: true},
+		{Section: "Socket", Name: "SystemCallArchitectures", Type: String, Syntax: "ARCHS", List: true, Resettable: true},
+		{Section: "Socket", Name: "SystemCallErrorNumber", Type: String, Syntax: "ERRNO"},
+		{Section: "Socket", Name: "SystemCallLog", Type: String, Syntax: "SYSCALLS", List: true, Resettable: true, Since: 247},
+		{Section: "Socket", Name: "MemoryDenyWriteExecute", Type: Bool, Syntax: "BOOLEAN", Since: 231},
+		{Section: "Socket", Name: "RestrictNamespaces", Type: String, Syntax: "NAMESPACES", Since: 233},
+		{Section: "Socket", Name: "RestrictRealtime", Type: Bool, Syntax: "BOOLEAN", Since: 231},
+		{Section: "Socket", Name: "RestrictSUIDSGID", Type: Bool, Syntax: "BOOLEAN", Since: 242},
+		{Section: "Socket", Name: "RestrictAddressFamilies", Type: String, Syntax: "FAMILIES", List: true, Resettable: true},
+		{Section: "Socket", Name: "LockPersonality", Type: Bool, Syntax: "BOOLEAN", Since: 235},
+		{Section: "Socket", Name: "RestrictFileSystems", Type: String, Syntax: "FILESYSTEMS", List: true, Resettable: true, Since: 250},
+		{Section: "Socket", Name: "LimitCPU", Type: String, Syntax: "LIMIT"},
+		{Section: "Socket", Name: "LimitFSIZE", Type: String, Syntax: "LIMIT"},
+		{Section: "Socket", Name: "LimitDATA", Type: String, Syntax: "LIMIT"},
+		{Section: "Socket", Name: "LimitSTACK", Type: String, Syntax: "LIMIT"},
+		{Section: "Socket", Name: "LimitCORE", Type: String, Syntax: "LIMIT"},
+		{Section: "Socket", Name: "LimitRSS", Type: String, Syntax: "LIMIT"},
+		{Section: "Socket", Name: "LimitNOFILE", Type: String, Syntax: "LIMIT"},
+		{Section: "Socket", Name: "LimitAS", Type: String, Syntax: "LIMIT"},
+		{Section: "Socket", Name: "LimitNPROC", Type: String, Syntax: "LIMIT"},
+		{Section: "Socket", Name: "LimitMEMLOCK", Type: String, Syntax: "LIMIT"},
+		{Section: "Socket", Name: "LimitLOCKS", Type: String, Syntax: "LIMIT"},
+		{Section: "Socket", Name: "LimitSIGPENDING", Type: String, Syntax: "LIMIT"},
+		{Section: "Socket", Name: "LimitMSGQUEUE", Type: String, Syntax: "LIMIT"},
+		{Section: "Socket", Name: "LimitNICE", Type: String, Syntax: "LIMIT"},
+		{Section: "Socket", Name: "LimitRTPRIO", Type: String, Syntax: "LIMIT"},
+		{Section: "Socket", Name: "LimitRTTIME", Type: String, Syntax: "LIMIT"},
+		{Section: "Socket", Name: "ReadWriteDirectories", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Socket.ReadWritePaths"},
+		{Section: "Socket", Name: "ReadOnlyDirectories", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Socket.ReadOnlyPaths"},
+		{Section: "Socket", Name: "InaccessibleDirectories", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Socket.InaccessiblePaths"},
+		{Section: "Socket", Name: "ReadWritePaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 231},
+		{Section: "Socket", Name: "ReadOnlyPaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 231},
+		{Section: "Socket", Name: "InaccessiblePaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 231},
+		{Section: "Socket", Name: "ExecPaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 247},
+		{Section: "Socket", Name: "NoExecPaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 247},
+		{Section: "Socket", Name: "ExecSearchPath", Type: Path, Syntax: "PATH", Since: 250},
+		{Section: "Socket", Name: "BindPaths", Type: String, Syntax: "PATH[:PATH[:OPTIONS]] [...]", List: true, Resettable: true, Since: 233},
+		{Section: "Socket", Name: "BindReadOnlyPaths", Type: String, Syntax: "PATH[:PATH[:OPTIONS]] [...]", List: true, Resettable: true, Since: 233},
+		{Section: "Socket", Name: "TemporaryFileSystem", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 238},
+		{Section: "Socket", Name: "PrivateTmp", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "PrivateDevices", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "ProtectKernelTunables", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Socket", Name: "ProtectKernelModules", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Socket", Name: "ProtectKernelLogs", Type: Bool, Syntax: "BOOLEAN", Since: 244},
+		{Section: "Socket", Name: "ProtectClock", Type: Bool, Syntax: "BOOLEAN", Since: 245},
+		{Section: "Socket", Name: "ProtectControlGroups", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Socket", Name: "NetworkNamespacePath", Type: Path, Syntax: "PATH"},
+		{Section: "Socket", Name: "IPCNamespacePath", Type: Path, Syntax: "PATH"},
+		{Section: "Socket", Name: "LogNamespace", Type: String, Syntax: "OTHER", Since: 245},
+		{Section: "Socket", Name: "PrivateNetwork", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "PrivateUsers", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Socket", Name: "PrivateMounts", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "PrivateIPC", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "ProtectSystem", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "ProtectHome", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "MountFlags", Type: String, Syntax: "MOUNTFLAG [...]", List: true, Resettable: true},
+		{Section: "Socket", Name: "MountAPIVFS", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "Personality", Type: String, Syntax: "PERSONALITY"},
+		{Section: "Socket", Name: "RuntimeDirectoryPreserve", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "RuntimeDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Socket", Name: "RuntimeDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Socket", Name: "StateDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Socket", Name: "StateDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Socket", Name: "CacheDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Socket", Name: "CacheDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Socket", Name: "LogsDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Socket", Name: "LogsDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Socket", Name: "ConfigurationDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Socket", Name: "ConfigurationDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Socket", Name: "SetCredential", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 247},
+		{Section: "Socket", Name: "SetCredentialEncrypted", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 250},
+		{Section: "Socket", Name: "LoadCredential", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 247},
+		{Section: "Socket", Name: "LoadCredentialEncrypted", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 250},
+		{Section: "Socket", Name: "TimeoutCleanSec", Type: Duration, Syntax: "SECONDS"},
+		{Section: "Socket", Name: "PAMName", Type: String, Syntax: "STRING"},
+		{Section: "Socket", Name: "IgnoreSIGPIPE", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "UtmpIdentifier", Type: String, Syntax: "STRING"},
+		{Section: "Socket", Name: "UtmpMode", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "SELinuxContext", Type: String, Syntax: "LABEL"},
+		{Section: "Socket", Name: "AppArmorProfile", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "SmackProcessLabel", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "ProtectHostname", Type: Bool, Syntax: "BOOLEAN", Since: 242},
+		{Section: "Socket", Name: "Slice", Type: Unit, Syntax: "SLICE"},
+		{Section: "Socket", Name: "AllowedCPUs", Type: String, Syntax: "OTHER", Since: 244},
+		{Section: "Socket", Name: "StartupAllowedCPUs", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "AllowedMemoryNodes", Type: String, Syntax: "OTHER", Since: 244},
+		{Section: "Socket", Name: "StartupAllowedMemoryNodes", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "CPUAccounting", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "CPUWeight", Type: String, Syntax: "CPUWEIGHT", Since: 232},
+		{Section: "Socket", Name: "StartupCPUWeight", Type: String, Syntax: "CPUWEIGHT", Since: 232},
+		{Section: "Socket", Name: "CPUShares", Type: Int, Syntax: "SHARES", Deprecated: true, ReplacedBy: "Socket.CPUWeight"},
+		{Section: "Socket", Name: "StartupCPUShares", Type: Int, Syntax: "SHARES", Deprecated: true, ReplacedBy: "Socket.StartupCPUWeight"},
+		{Section: "Socket", Name: "CPUQuota", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "CPUQuotaPeriodSec", Type: Duration, Syntax: "OTHER", Since: 242},
+		{Section: "Socket", Name: "MemoryAccounting", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "MemoryMin", Type: String, Syntax: "LIMIT", Since: 240},
+		{Section: "Socket", Name: "DefaultMemoryMin", Type: String, Syntax: "LIMIT"},
+		{Section: "Socket", Name: "DefaultMemoryLow", Type: String, Syntax: "LIMIT", Since: 240},
+		{Section: "Socket", Name: "MemoryLow", Type: String, Syntax: "LIMIT", Since: 231},
+		{Section: "Socket", Name: "MemoryHigh", Type: String, Syntax: "LIMIT", Since: 231},
+		{Section: "Socket", Name: "MemoryMax", Type: String, Syntax: "LIMIT", Since: 231},
+		{Section: "Socket", Name: "MemorySwapMax", Type: String, Syntax: "LIMIT", Since: 232},
+		{Section: "Socket", Name: "MemoryLimit", Type: String, Syntax: "LIMIT", Deprecated: true, ReplacedBy: "Socket.MemoryMax"},
+		{Section: "Socket", Name: "DeviceAllow", Type: String, Syntax: "DEVICE", List: true, Resettable: true},
+		{Section: "Socket", Name: "DevicePolicy", Type: String, Syntax: "POLICY"},
+		{Section: "Socket", Name: "IOAccounting", Type: Bool, Syntax: "BOOLEAN", Since: 230},
+		{Section: "Socket", Name: "IOWeight", Type: Int, Syntax: "WEIGHT", Since: 230},
+		{Section: "Socket", Name: "StartupIOWeight", Type: Int, Syntax: "WEIGHT", Since: 230},
+		{Section: "Socket", Name: "IODeviceWeight", Type: String, Syntax: "DEVICEWEIGHT", List: true, Resettable: true, Since: 230},
+		{Section: "Socket", Name: "IOReadBandwidthMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Socket", Name: "IOWriteBandwidthMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Socket", Name: "IOReadIOPSMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Socket", Name: "IOWriteIOPSMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Socket", Name: "IODeviceLatencyTargetSec", Type: String, Syntax: "DEVICELATENCY", List: true, Resettable: true},
+		{Section: "Socket", Name: "BlockIOAccounting", Type: Bool, Syntax: "BOOLEAN", Deprecated: true, ReplacedBy: "Socket.IOAccounting"},
+		{Section: "Socket", Name: "BlockIOWeight", Type: Int, Syntax: "WEIGHT", Deprecated: true, ReplacedBy: "Socket.IOWeight"},
+		{Section: "Socket", Name: "StartupBlockIOWeight", Type: Int, Syntax: "WEIGHT", Deprecated: true, ReplacedBy: "Socket.StartupIOWeight"},
+		{Section: "Socket", Name: "BlockIODeviceWeight", Type: String, Syntax: "DEVICEWEIGHT", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Socket.IODeviceWeight"},
+		{Section: "Socket", Name: "BlockIOReadBandwidth", Type: String, Syntax: "BANDWIDTH", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Socket.IOReadBandwidthMax"},
+		{Section: "Socket", Name: "BlockIOWriteBandwidth", Type: String, Syntax: "BANDWIDTH", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Socket.IOWriteBandwidthMax"},
+		{Section: "Socket", Name: "TasksAccounting", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "TasksMax", Type: String, Syntax: "OTHER", Since: 227},
+		{Section: "Socket", Name: "Delegate", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "DisableControllers", Type: String, Syntax: "OTHER"},
+		{Section: "Socket", Name: "IPAccounting", Type: Bool, Syntax: "BOOLEAN", Since: 235},
+		{Section: "Socket", Name: "IPAddressAllow", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Socket", Name: "IPAddressDeny", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Socket", Name: "IPIngressFilterPath", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 243},
+		{Section: "Socket", Name: "IPEgressFilterPath", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 243},
+		{Section: "Socket", Name: "ManagedOOMSwap", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Socket", Name: "ManagedOOMMemoryPressure", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Socket", Name: "ManagedOOMMemoryPressureLimit", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Socket", Name: "ManagedOOMPreference", Type: String, Syntax: "OTHER", Since: 248},
+		{Section: "Socket", Name: "BPFProgram", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 249},
+		{Section: "Socket", Name: "SocketBindAllow", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 249},
+		{Section: "Socket", Name: "SocketBindDeny", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 249},
+		{Section: "Socket", Name: "RestrictNetworkInterfaces", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 250},
+		{Section: "Socket", Name: "SendSIGKILL", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "SendSIGHUP", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Socket", Name: "KillMode", Type: String, Syntax: "KILLMODE"},
+		{Section: "Socket", Name: "KillSignal", Type: String, Syntax: "SIGNAL"},
+		{Section: "Socket", Name: "RestartKillSignal", Type: String, Syntax: "SIGNAL", Since: 244},
+		{Section: "Socket", Name: "FinalKillSignal", Type: String, Syntax: "SIGNAL"},
+		{Section: "Socket", Name: "WatchdogSignal", Type: String, Syntax: "SIGNAL"},
+	},
+	"Mount": {
+		{Section: "Mount", Name: "What", Type: String, Syntax: "STRING"},
+		{Section: "Mount", Name: "Where", Type: Path, Syntax: "PATH"},
+		{Section: "Mount", Name: "Options", Type: String, Syntax: "STRING"},
+		{Section: "Mount", Name: "Type", Type: String, Syntax: "STRING"},
+		{Section: "Mount", Name: "TimeoutSec", Type: Duration, Syntax: "OTHER"},
+		{Section: "Mount", Name: "DirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Mount", Name: "SloppyOptions", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "LazyUnmount", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "ForceUnmount", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "ReadWriteOnly", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "WorkingDirectory", Type: String, Syntax: "OTHER"},
+		{Section: "Mount", Name: "RootDirectory", Type: Path, Syntax: "PATH"},
+		{Section: "Mount", Name: "RootImage", Type: Path, Syntax: "PATH"},
+		{Section: "Mount", Name: "RootImageOptions", Type: String, Syntax: "OTHER"},
+		{Section: "Mount", Name: "RootHash", Type: String, Syntax: "OTHER"},
+		{Section: "Mount", Name: "RootHashSignature", Type: String, Syntax: "OTHER"},
+		{Section: "Mount", Name: "RootVerity", Type: Path, Syntax: "PATH"},
+		{Section: "Mount", Name: "ExtensionDirectories", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 251},
+		{Section: "Mount", Name: "ExtensionImages", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 248},
+		{Section: "Mount", Name: "MountImages", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 247},
+		{Section: "Mount", Name: "User", Type: String, Syntax: "OTHER"},
+		{Section: "Mount", Name: "Group", Type: String, Syntax: "OTHER"},
+		{Section: "Mount", Name: "SupplementaryGroups", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Mount", Name: "Nice", Type: Int, Syntax: "NICE"},
+		{Section: "Mount", Name: "OOMScoreAdjust", Type: Int, Syntax: "OOMSCOREADJUST"},
+		{Section: "Mount", Name: "CoredumpFilter", Type: String, Syntax: "OTHER", Since: 246},
+		{Section: "Mount", Name: "IOSchedulingClass", Type: String, Syntax: "IOCLASS"},
+		{Section: "Mount", Name: "IOSchedulingPriority", Type: String, Syntax: "IOPRIORITY"},
+		{Section: "Mount", Name: "CPUSchedulingPolicy", Type: String, Syntax: "CPUSCHEDPOLICY"},
+		{Section: "Mount", Name: "CPUSchedulingPriority", Type: Int, Syntax: "CPUSCHEDPRIO"},
+		{Section: "Mount", Name: "CPUSchedulingResetOnFork", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "CPUAffinity", Type: String, Syntax: "CPUAFFINITY"},
+		{Section: "Mount", Name: "NUMAPolicy", Type: String, Syntax: "OTHER", Since: 243},
+		{Section: "Mount", Name: "NUMAMask", Type: String, Syntax: "OTHER", Since: 243},
+		{Section: "Mount", Name: "UMask", Type: Mode, Syntax: "MODE"},
+		{Section: "Mount", Name: "Environment", Type: String, Syntax: "ENVIRON", List: true, Resettable: true},
+		{Section: "Mount", Name: "EnvironmentFile", Type: Path, Syntax: "FILE", List: true, Resettable: true},
+		{Section: "Mount", Name: "PassEnvironment", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Mount", Name: "UnsetEnvironment", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Mount", Name: "DynamicUser", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Mount", Name: "RemoveIPC", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "StandardInput", Type: String, Syntax: "INPUT"},
+		{Section: "Mount", Name: "StandardOutput", Type: String, Syntax: "OUTPUT"},
+		{Section: "Mount", Name: "StandardError", Type: String, Syntax: "OUTPUT"},
+		{Section: "Mount", Name: "StandardInputText", Type: String, Syntax: "OTHER", Since: 236},
+		{Section: "Mount", Name: "StandardInputData", Type: String, Syntax: "OTHER", Since: 236},
+		{Section: "Mount", Name: "TTYPath", Type: Path, Syntax: "PATH"},
+		{Section: "Mount", Name: "TTYReset", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "TTYVHangup", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "TTYVTDisallocate", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "TTYRows", Type: Int, Syntax: "OTHER"},
+		{Section: "Mount", Name: "TTYColumns", Type: Int, Syntax: "OTHER"},
+		{Section: "Mount", Name: "SyslogIdentifier", Type: String, Syntax: "STRING"},
+		{Section: "Mount", Name: "SyslogFacility", Type: String, Syntax: "FACILITY"},
+		{Section: "Mount", Name: "SyslogLevel", Type: String, Syntax: "LEVEL"},
+		{Section: "Mount", Name: "SyslogLevelPrefix", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "LogLevelMax", Type: String, Syntax: "LEVEL"},
+		{Section: "Mount", Name: "LogRateLimitIntervalSec", Type: Duration, Syntax: "SECONDS", Since: 240},
+		{Section: "Mount", Name: "LogRateLimitBurst", Type: Int, Syntax: "UNSIGNED", Since: 240},
+		{Section: "Mount", Name: "LogExtraFields", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Mount", Name: "SecureBits", Type: String, Syntax: "SECUREBITS"},
+		{Section: "Mount", Name: "CapabilityBoundingSet", Type: String, Syntax: "BOUNDINGSET", List: true, Resettable: true},
+		{Section: "Mount", Name: "AmbientCapabilities", Type: String, Syntax: "BOUNDINGSET", List: true, Resettable: true},
+		{Section: "Mount", Name: "TimerSlackNSec", Type: Duration, Syntax: "NANOSECONDS"},
+		{Section: "Mount", Name: "NoNewPrivileges", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "KeyringMode", Type: String, Syntax: "OTHER", Since: 235},
+		{Section: "Mount", Name: "ProtectProc", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Mount", Name: "ProcSubset", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Mount", Name: "SystemCallFilter", Type: String, Syntax: "SYSCALLS", List: true, Resettable: true},
+		{Section: "Mount", Name: "SystemCallArchitectures", Type: String, Syntax: "ARCHS", List: true, Resettable: true},
+		{Section: "Mount", Name: "SystemCallErrorNumber", Type: String, Syntax: "ERRNO"},
+		{Section: "Mount", Name: "SystemCallLog", Type: String, Syntax: "SYSCALLS", List: true, Resettable: true, Since: 247},
+		{Section: "Mount", Name: "MemoryDenyWriteExecute", Type: Bool, Syntax: "BOOLEAN", Since: 231},
+		{Section: "Mount", Name: "RestrictNamespaces", Type: String, Syntax: "NAMESPACES", Since: 233},
+		{Section: "Mount", Name: "RestrictRealtime", Type: Bool, Syntax: "BOOLEAN", Since: 231},
+		{Section: "Mount", Name: "RestrictSUIDSGID", Type: Bool, Syntax: "BOOLEAN", Since: 242},
+		{Section: "Mount", Name: "RestrictAddressFamilies", Type: String, Syntax: "FAMILIES", List: true, Resettable: true},
+		{Section: "Mount", Name: "LockPersonality", Type: Bool, Syntax: "BOOLEAN", Since: 235},
+		{Section: "Mount", Name: "RestrictFileSystems", Type: String, Syntax: "FILESYSTEMS", List: true, Resettable: true, Since: 250},
+		{Section: "Mount", Name: "LimitCPU", Type: String, Syntax: "LIMIT"},
+		{Section: "Mount", Name: "LimitFSIZE", Type: String, Syntax: "LIMIT"},
+		{Section: "Mount", Name: "LimitDATA", Type: String, Syntax: "LIMIT"},
+		{Section: "Mount", Name: "LimitSTACK", Type: String, Syntax: "LIMIT"},
+		{Section: "Mount", Name: "LimitCORE", Type: String, Syntax: "LIMIT"},
+		{Section: "Mount", Name: "LimitRSS", Type: String, Syntax: "LIMIT"},
+		{Section: "Mount", Name: "LimitNOFILE", Type: String, Syntax: "LIMIT"},
+		{Section: "Mount", Name: "LimitAS", Type: String, Syntax: "LIMIT"},
+		{Section: "Mount", Name: "LimitNPROC", Type: String, Syntax: "LIMIT"},
+		{Section: "Mount", Name: "LimitMEMLOCK", Type: String, Syntax: "LIMIT"},
+		{Section: "Mount", Name: "LimitLOCKS", Type: String, Syntax: "LIMIT"},
+		{Section: "Mount", Name: "LimitSIGPENDING", Type: String, Syntax: "LIMIT"},
+		{Section: "Mount", Name: "LimitMSGQUEUE", Type: String, Syntax: "LIMIT"},
+		{Section: "Mount", Name: "LimitNICE", Type: String, Syntax: "LIMIT"},
+		{Section: "Mount", Name: "LimitRTPRIO", Type: String, Syntax: "LIMIT"},
+		{Section: "Mount", Name: "LimitRTTIME", Type: String, Syntax: "LIMIT"},
+		{Section: "Mount", Name: "ReadWriteDirectories", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Mount.ReadWritePaths"},
+		{Section: "Mount", Name: "ReadOnlyDirectories", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Mount.ReadOnlyPaths"},
+		{Section: "Mount", Name: "InaccessibleDirectories", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Mount.InaccessiblePaths"},
+		{Section: "Mount", Name: "ReadWritePaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 231},
+		{Section: "Mount", Name: "ReadOnlyPaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 231},
+		{Section: "Mount", Name: "InaccessiblePaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 231},
+		{Section: "Mount", Name: "ExecPaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 247},
+		{Section: "Mount", Name: "NoExecPaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 247},
+		{Section: "Mount", Name: "ExecSearchPath", Type: Path, Syntax: "PATH", Since: 250},
+		{Section: "Mount", Name: "BindPaths", Type: String, Syntax: "PATH[:PATH[:OPTIONS]] [...]", List: true, Resettable: true, Since: 233},
+		{Section: "Mount", Name: "BindReadOnlyPaths", Type: String, Syntax: "PATH[:PATH[:OPTIONS]] [...]", List: true, Resettable: true, Since: 233},
+		{Section: "Mount", Name: "TemporaryFileSystem", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 238},
+		{Section: "Mount", Name: "PrivateTmp", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "PrivateDevices", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "ProtectKernelTunables", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Mount", Name: "ProtectKernelModules", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Mount", Name: "ProtectKernelLogs", Type: Bool, Syntax: "BOOLEAN", Since: 244},
+		{Section: "Mount", Name: "ProtectClock", Type: Bool, Syntax: "BOOLEAN", Since: 245},
+		{Section: "Mount", Name: "ProtectControlGroups", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Mount", Name: "NetworkNamespacePath", Type: Path, Syntax: "PATH"},
+		{Section: "Mount", Name: "IPCNamespacePath", Type: Path, Syntax: "PATH"},
+		{Section: "Mount", Name: "LogNamespace", Type: String, Syntax: "OTHER", Since: 245},
+		{Section: "Mount", Name: "PrivateNetwork", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "PrivateUsers", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Mount", Name: "PrivateMounts", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "PrivateIPC", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "ProtectSystem", Type: String, Syntax: "OTHER"},
+		{Section: "Mount", Name: "ProtectHome", Type: String, Syntax: "OTHER"},
+		{Section: "Mount", Name: "MountFlags", Type: String, Syntax: "MOUNTFLAG [...]", List: true, Resettable: true},
+		{Section: "Mount", Name: "MountAPIVFS", Type: String, Syntax: "OTHER"},
+		{Section: "Mount", Name: "Personality", Type: String, Syntax: "PERSONALITY"},
+		{Section: "Mount", Name: "RuntimeDirectoryPreserve", Type: String, Syntax: "OTHER"},
+		{Section: "Mount", Name: "RuntimeDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Mount", Name: "RuntimeDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Mount", Name: "StateDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Mount", Name: "StateDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Mount", Name: "CacheDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Mount", Name: "CacheDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Mount", Name: "LogsDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Mount", Name: "LogsDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Mount", Name: "ConfigurationDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Mount", Name: "ConfigurationDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Mount", Name: "SetCredential", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 247},
+		{Section: "Mount", Name: "SetCredentialEncrypted", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 250},
+		{Section: "Mount", Name: "LoadCredential", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 247},
+		{Section: "Mount", Name: "LoadCredentialEncrypted", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 250},
+		{Section: "Mount", Name: "TimeoutCleanSec", Type: Duration, Syntax: "SECONDS"},
+		{Section: "Mount", Name: "PAMName", Type: String, Syntax: "STRING"},
+		{Section: "Mount", Name: "IgnoreSIGPIPE", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "UtmpIdentifier", Type: String, Syntax: "STRING"},
+		{Section: "Mount", Name: "UtmpMode", Type: String, Syntax: "OTHER"},
+		{Section: "Mount", Name: "SELinuxContext", Type: String, Syntax: "LABEL"},
+		{Section: "Mount", Name: "AppArmorProfile", Type: String, Syntax: "OTHER"},
+		{Section: "Mount", Name: "SmackProcessLabel", Type: String, Syntax: "OTHER"},
+		{Section: "Mount", Name: "ProtectHostname", Type: Bool, Syntax: "BOOLEAN", Since: 242},
+		{Section: "Mount", Name: "Slice", Type: Unit, Syntax: "SLICE"},
+		{Section: "Mount", Name: "AllowedCPUs", Type: String, Syntax: "OTHER", Since: 244},
+		{Section: "Mount", Name: "StartupAllowedCPUs", Type: String, Syntax: "OTHER"},
+		{Section: "Mount", Name: "AllowedMemoryNodes", Type: String, Syntax: "OTHER", Since: 244},
+		{Section: "Mount", Name: "StartupAllowedMemoryNodes", Type: String, Syntax: "OTHER"},
+		{Section: "Mount", Name: "CPUAccounting", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "CPUWeight", Type: String, Syntax: "CPUWEIGHT", Since: 232},
+		{Section: "Mount", Name: "StartupCPUWeight", Type: String, Syntax: "CPUWEIGHT", Since: 232},
+		{Section: "Mount", Name: "CPUShares", Type: Int, Syntax: "SHARES", Deprecated: true, ReplacedBy: "Mount.CPUWeight"},
+		{Section: "Mount", Name: "StartupCPUShares", Type: Int, Syntax: "SHARES", Deprecated: true, ReplacedBy: "Mount.StartupCPUWeight"},
+		{Section: "Mount", Name: "CPUQuota", Type: String, Syntax: "OTHER"},
+		{Section: "Mount", Name: "CPUQuotaPeriodSec", Type: Duration, Syntax: "OTHER", Since: 242},
+		{Section: "Mount", Name: "MemoryAccounting", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "MemoryMin", Type: String, Syntax: "LIMIT", Since: 240},
+		{Section: "Mount", Name: "DefaultMemoryMin", Type: String, Syntax: "LIMIT"},
+		{Section: "Mount", Name: "DefaultMemoryLow", Type: String, Syntax: "LIMIT", Since: 240},
+		{Section: "Mount", Name: "MemoryLow", Type: String, Syntax: "LIMIT", Since: 231},
+		{Section: "Mount", Name: "MemoryHigh", Type: String, Syntax: "LIMIT", Since: 231},
+		{Section: "Mount", Name: "MemoryMax", Type: String, Syntax: "LIMIT", Since: 231},
+		{Section: "Mount", Name: "MemorySwapMax", Type: String, Syntax: "LIMIT", Since: 232},
+		{Section: "Mount", Name: "MemoryLimit", Type: String, Syntax: "LIMIT", Deprecated: true, ReplacedBy: "Mount.MemoryMax"},
+		{Section: "Mount", Name: "DeviceAllow", Type: String, Syntax: "DEVICE", List: true, Resettable: true},
+		{Section: "Mount", Name: "DevicePolicy", Type: String, Syntax: "POLICY"},
+		{Section: "Mount", Name: "IOAccounting", Type: Bool, Syntax: "BOOLEAN", Since: 230},
+		{Section: "Mount", Name: "IOWeight", Type: Int, Syntax: "WEIGHT", Since: 230},
+		{Section: "Mount", Name: "StartupIOWeight", Type: Int, Syntax: "WEIGHT", Since: 230},
+		{Section: "Mount", Name: "IODeviceWeight", Type: String, Syntax: "DEVICEWEIGHT", List: true, Resettable: true, Since: 230},
+		{Section: "Mount", Name: "IOReadBandwidthMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Mount", Name: "IOWriteBandwidthMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Mount", Name: "IOReadIOPSMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Mount", Name: "IOWriteIOPSMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Mount", Name: "IODeviceLatencyTargetSec", Type: String, Syntax: "DEVICELATENCY", List: true, Resettable: true},
+		{Section: "Mount", Name: "BlockIOAccounting", Type: Bool, Syntax: "BOOLEAN", Deprecated: true, ReplacedBy: "Mount.IOAccounting"},
+		{Section: "Mount", Name: "BlockIOWeight", Type: Int, Syntax: "WEIGHT", Deprecated: true, ReplacedBy: "Mount.IOWeight"},
+		{Section: "Mount", Name: "StartupBlockIOWeight", Type: Int, Syntax: "WEIGHT", Deprecated: true, ReplacedBy: "Mount.StartupIOWeight"},
+		{Section: "Mount", Name: "BlockIODeviceWeight", Type: String, Syntax: "DEVICEWEIGHT", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Mount.IODeviceWeight"},
+		{Section: "Mount", Name: "BlockIOReadBandwidth", Type: String, Syntax: "BANDWIDTH", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Mount.IOReadBandwidthMax"},
+		{Section: "Mount", Name: "BlockIOWriteBandwidth", Type: String, Syntax: "BANDWIDTH", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Mount.IOWriteBandwidthMax"},
+		{Section: "Mount", Name: "TasksAccounting", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "TasksMax", Type: String, Syntax: "OTHER", Since: 227},
+		{Section: "Mount", Name: "Delegate", Type: String, Syntax: "OTHER"},
+		{Section: "Mount", Name: "DisableControllers", Type: String, Syntax: "OTHER"},
+		{Section: "Mount", Name: "IPAccounting", Type: Bool, Syntax: "BOOLEAN", Since: 235},
+		{Section: "Mount", Name: "IPAddressAllow", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Mount", Name: "IPAddressDeny", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Mount", Name: "IPIngressFilterPath", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 243},
+		{Section: "Mount", Name: "IPEgressFilterPath", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 243},
+		{Section: "Mount", Name: "ManagedOOMSwap", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Mount", Name: "ManagedOOMMemoryPressure", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Mount", Name: "ManagedOOMMemoryPressureLimit", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Mount", Name: "ManagedOOMPreference", Type: String, Syntax: "OTHER", Since: 248},
+		{Section: "Mount", Name: "BPFProgram", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 249},
+		{Section: "Mount", Name: "SocketBindAllow", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 249},
+		{Section: "Mount", Name: "SocketBindDeny", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 249},
+		{Section: "Mount", Name: "RestrictNetworkInterfaces", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 250},
+		{Section: "Mount", Name: "SendSIGKILL", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "SendSIGHUP", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Mount", Name: "KillMode", Type: String, Syntax: "KILLMODE"},
+		{Section: "Mount", Name: "KillSignal", Type: String, Syntax: "SIGNAL"},
+		{Section: "Mount", Name: "RestartKillSignal", Type: String, Syntax: "SIGNAL", Since: 244},
+		{Section: "Mount", Name: "FinalKillSignal", Type: String, Syntax: "SIGNAL"},
+		{Section: "Mount", Name: "WatchdogSignal", Type: String, Syntax: "SIGNAL"},
+	},
+	"Automount": {
+		{Section: "Automount", Name: "Where", Type: Path, Syntax: "PATH"},
+		{Section: "Automount", Name: "ExtraOptions", Type: String, Syntax: "STRING"},
+		{Section: "Automount", Name: "DirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Automount", Name: "TimeoutIdleSec", Type: Duration, Syntax: "OTHER"},
+	},
+	"Swap": {
+		{Section: "Swap", Name: "What", Type: Path, Syntax: "PATH"},
+		{Section: "Swap", Name: "Priority", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "Options", Type: String, Syntax: "STRING"},
+		{Section: "Swap", Name: "TimeoutSec", Type: Duration, Syntax: "OTHER"},
+		{Section: "Swap", Name: "WorkingDirectory", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "RootDirectory", Type: Path, Syntax: "PATH"},
+		{Section: "Swap", Name: "RootImage", Type: Path, Syntax: "PATH"},
+		{Section: "Swap", Name: "RootImageOptions", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "RootHash", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "RootHashSignature", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "RootVerity", Type: Path, Syntax: "PATH"},
+		{Section: "Swap", Name: "ExtensionDirectories", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 251},
+		{Section: "Swap", Name: "ExtensionImages", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 248},
+		{Section: "Swap", Name: "MountImages", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 247},
+		{Section: "Swap", Name: "User", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "Group", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "SupplementaryGroups", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Swap", Name: "Nice", Type: Int, Syntax: "NICE"},
+		{Section: "Swap", Name: "OOMScoreAdjust", Type: Int, Syntax: "OOMSCOREADJUST"},
+		{Section: "Swap", Name: "CoredumpFilter", Type: String, Syntax: "OTHER", Since: 246},
+		{Section: "Swap", Name: "IOSchedulingClass", Type: String, Syntax: "IOCLASS"},
+		{Section: "Swap", Name: "IOSchedulingPriority", Type: String, Syntax: "IOPRIORITY"},
+		{Section: "Swap", Name: "CPUSchedulingPolicy", Type: String, Syntax: "CPUSCHEDPOLICY"},
+		{Section: "Swap", Name: "CPUSchedulingPriority", Type: Int, Syntax: "CPUSCHEDPRIO"},
+		{Section: "Swap", Name: "CPUSchedulingResetOnFork", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Swap", Name: "CPUAffinity", Type: String, Syntax: "CPUAFFINITY"},
+		{Section: "Swap", Name: "NUMAPolicy", Type: String, Syntax: "OTHER", Since: 243},
+		{Section: "Swap", Name: "NUMAMask", Type: String, Syntax: "OTHER", Since: 243},
+		{Section: "Swap", Name: "UMask", Type: Mode, Syntax: "MODE"},
+		{Section: "Swap", Name: "Environment", Type: String, Syntax: "ENVIRON", List: true, Resettable: true},
+		{Section: "Swap", Name: "EnvironmentFile", Type: Path, Syntax: "FILE", List: true, Resettable: true},
+		{Section: "Swap", Name: "PassEnvironment", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Swap", Name: "UnsetEnvironment", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Swap", Name: "DynamicUser", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Swap", Name: "RemoveIPC", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Swap", Name: "StandardInput", Type: String, Syntax: "INPUT"},
+		{Section: "Swap", Name: "StandardOutput", Type: String, Syntax: "OUTPUT"},
+		{Section: "Swap", Name: "StandardError", Type: String, Syntax: "OUTPUT"},
+		{Section: "Swap", Name: "StandardInputText", Type: String, Syntax: "OTHER", Since: 236},
+		{Section: "Swap", Name: "StandardInputData", Type: String, Syntax: "OTHER", Since: 236},
+		{Section: "Swap", Name: "TTYPath", Type: Path, Syntax: "PATH"},
+		{Section: "Swap", Name: "TTYReset", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Swap", Name: "TTYVHangup", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Swap", Name: "TTYVTDisallocate", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Swap", Name: "TTYRows", Type: Int, Syntax: "OTHER"},
+		{Section: "Swap", Name: "TTYColumns", Type: Int, Syntax: "OTHER"},
+		{Section: "Swap", Name: "SyslogIdentifier", Type: String, Syntax: "STRING"},
+		{Section: "Swap", Name: "SyslogFacility", Type: String, Syntax: "FACILITY"},
+		{Section: "Swap", Name: "SyslogLevel", Type: String, Syntax: "LEVEL"},
+		{Section: "Swap", Name: "SyslogLevelPrefix", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Swap", Name: "LogLevelMax", Type: String, Syntax: "LEVEL"},
+		{Section: "Swap", Name: "LogRateLimitIntervalSec", Type: Duration, Syntax: "SECONDS", Since: 240},
+		{Section: "Swap", Name: "LogRateLimitBurst", Type: Int, Syntax: "UNSIGNED", Since: 240},
+		{Section: "Swap", Name: "LogExtraFields", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Swap", Name: "SecureBits", Type: String, Syntax: "SECUREBITS"},
+		{Section: "Swap", Name: "CapabilityBoundingSet", Type: String, Syntax: "BOUNDINGSET", List: true, Resettable: true},
+		{Section: "Swap", Name: "AmbientCapabilities", Type: String, Syntax: "BOUNDINGSET", List: true, Resettable: true},
+		{Section: "Swap", Name: "TimerSlackNSec", Type: Duration, Syntax: "NANOSECONDS"},
+		{Section: "Swap", Name: "NoNewPrivileges", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Swap", Name: "KeyringMode", Type: String, Syntax: "OTHER", Since: 235},
+		{Section: "Swap", Name: "ProtectProc", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Swap", Name: "ProcSubset", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Swap", Name: "SystemCallFilter", Type: String, Syntax: "SYSCALLS", List: true, Resettable: true},
+		{Section: "Swap", Name: "SystemCallArchitectures", Type: String, Syntax: "ARCHS", List: true, Resettable: true},
+		{Section: "Swap", Name: "SystemCallErrorNumber", Type: String, Syntax: "ERRNO"},
+		{Section: "Swap", Name: "SystemCallLog", Type: String, Syntax: "SYSCALLS", List: true, Resettable: true, Since: 247},
+		{Section: "Swap", Name: "MemoryDenyWriteExecute", Type: Bool, Syntax: "BOOLEAN", Since: 231},
+		{Section: "Swap", Name: "RestrictNamespaces", Type: String, Syntax: "NAMESPACES", Since: 233},
+		{Section: "Swap", Name: "RestrictRealtime", Type: Bool, Syntax: "BOOLEAN", Since: 231},
+		{Section: "Swap", Name: "RestrictSUIDSGID", Type: Bool, Syntax: "BOOLEAN", Since: 242},
+		{Section: "Swap", Name: "RestrictAddressFamilies", Type: String, Syntax: "FAMILIES", List: true, Resettable: true},
+		{Section: "Swap", Name: "LockPersonality", Type: Bool, Syntax: "BOOLEAN", Since: 235},
+		{Section: "Swap", Name: "RestrictFileSystems", Type: String, Syntax: "FILESYSTEMS", List: true, Resettable: true, Since: 250},
+		{Section: "Swap", Name: "LimitCPU", Type: String, Syntax: "LIMIT"},
+		{Section: "Swap", Name: "LimitFSIZE", Type: String, Syntax: "LIMIT"},
+		{Section: "Swap", Name: "LimitDATA", Type: String, Syntax: "LIMIT"},
+		{Section: "Swap", Name: "LimitSTACK", Type: String, Syntax: "LIMIT"},
+		{Section: "Swap", Name: "LimitCORE", Type: String, Syntax: "LIMIT"},
+		{Section: "Swap", Name: "LimitRSS", Type: String, Syntax: "LIMIT"},
+		{Section: "Swap", Name: "LimitNOFILE", Type: String, Syntax: "LIMIT"},
+		{Section: "Swap", Name: "LimitAS", Type: String, Syntax: "LIMIT"},
+		{Section: "Swap", Name: "LimitNPROC", Type: String, Syntax: "LIMIT"},
+		{Section: "Swap", Name: "LimitMEMLOCK", Type: String, Syntax: "LIMIT"},
+		{Section: "Swap", Name: "LimitLOCKS", Type: String, Syntax: "LIMIT"},
+		{Section: "Swap", Name: "LimitSIGPENDING", Type: String, Syntax: "LIMIT"},
+		{Section: "Swap", Name: "LimitMSGQUEUE", Type: String, Syntax: "LIMIT"},
+		{Section: "Swap", Name: "LimitNICE", Type: String, Syntax: "LIMIT"},
+		{Section: "Swap", Name: "LimitRTPRIO", Type: String, Syntax: "LIMIT"},
+		{Section: "Swap", Name: "LimitRTTIME", Type: String, Syntax: "LIMIT"},
+		{Section: "Swap", Name: "ReadWriteDirectories", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Swap.ReadWritePaths"},
+		{Section: "Swap", Name: "ReadOnlyDirectories", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Swap.ReadOnlyPaths"},
+		{Section: "Swap", Name: "InaccessibleDirectories", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Swap.InaccessiblePaths"},
+		{Section: "Swap", Name: "ReadWritePaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 231},
+		{Section: "Swap", Name: "ReadOnlyPaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 231},
+		{Section: "Swap", Name: "InaccessiblePaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 231},
+		{Section: "Swap", Name: "ExecPaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 247},
+		{Section: "Swap", Name: "NoExecPaths", Type: Path, Syntax: "PATH [...]", List: true, Resettable: true, Since: 247},
+		{Section: "Swap", Name: "ExecSearchPath", Type: Path, Syntax: "PATH", Since: 250},
+		{Section: "Swap", Name: "BindPaths", Type: String, Syntax: "PATH[:PATH[:OPTIONS]] [...]", List: true, Resettable: true, Since: 233},
+		{Section: "Swap", Name: "BindReadOnlyPaths", Type: String, Syntax: "PATH[:PATH[:OPTIONS]] [...]", List: true, Resettable: true, Since: 233},
+		{Section: "Swap", Name: "TemporaryFileSystem", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 238},
+		{Section: "Swap", Name: "PrivateTmp", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Swap", Name: "PrivateDevices", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Swap", Name: "ProtectKernelTunables", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Swap", Name: "ProtectKernelModules", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Swap", Name: "ProtectKernelLogs", Type: Bool, Syntax: "BOOLEAN", Since: 244},
+		{Section: "Swap", Name: "ProtectClock", Type: Bool, Syntax: "BOOLEAN", Since: 245},
+		{Section: "Swap", Name: "ProtectControlGroups", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Swap", Name: "NetworkNamespacePath", Type: Path, Syntax: "PATH"},
+		{Section: "Swap", Name: "IPCNamespacePath", Type: Path, Syntax: "PATH"},
+		{Section: "Swap", Name: "LogNamespace", Type: String, Syntax: "OTHER", Since: 245},
+		{Section: "Swap", Name: "PrivateNetwork", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Swap", Name: "PrivateUsers", Type: Bool, Syntax: "BOOLEAN", Since: 232},
+		{Section: "Swap", Name: "PrivateMounts", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Swap", Name: "PrivateIPC", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Swap", Name: "ProtectSystem", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "ProtectHome", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "MountFlags", Type: String, Syntax: "MOUNTFLAG [...]", List: true, Resettable: true},
+		{Section: "Swap", Name: "MountAPIVFS", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "Personality", Type: String, Syntax: "PERSONALITY"},
+		{Section: "Swap", Name: "RuntimeDirectoryPreserve", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "RuntimeDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Swap", Name: "RuntimeDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Swap", Name: "StateDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Swap", Name: "StateDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Swap", Name: "CacheDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Swap", Name: "CacheDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Swap", Name: "LogsDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Swap", Name: "LogsDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Swap", Name: "ConfigurationDirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Swap", Name: "ConfigurationDirectory", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Swap", Name: "SetCredential", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 247},
+		{Section: "Swap", Name: "SetCredentialEncrypted", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 250},
+		{Section: "Swap", Name: "LoadCredential", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 247},
+		{Section: "Swap", Name: "LoadCredentialEncrypted", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 250},
+		{Section: "Swap", Name: "TimeoutCleanSec", Type: Duration, Syntax: "SECONDS"},
+		{Section: "Swap", Name: "PAMName", Type: String, Syntax: "STRING"},
+		{Section: "Swap", Name: "IgnoreSIGPIPE", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Swap", Name: "UtmpIdentifier", Type: String, Syntax: "STRING"},
+		{Section: "Swap", Name: "UtmpMode", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "SELinuxContext", Type: String, Syntax: "LABEL"},
+		{Section: "Swap", Name: "AppArmorProfile", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "SmackProcessLabel", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "ProtectHostname", Type: Bool, Syntax: "BOOLEAN", Since: 242},
+		{Section: "Swap", Name: "Slice", Type: Unit, Syntax: "SLICE"},
+		{Section: "Swap", Name: "AllowedCPUs", Type: String, Syntax: "OTHER", Since: 244},
+		{Section: "Swap", Name: "StartupAllowedCPUs", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "AllowedMemoryNodes", Type: String, Syntax: "OTHER", Since: 244},
+		{Section: "Swap", Name: "StartupAllowedMemoryNodes", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "CPUAccounting", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Swap", Name: "CPUWeight", Type: String, Syntax: "CPUWEIGHT", Since: 232},
+		{Section: "Swap", Name: "StartupCPUWeight", Type: String, Syntax: "CPUWEIGHT", Since: 232},
+		{Section: "Swap", Name: "CPUShares", Type: Int, Syntax: "SHARES", Deprecated: true, ReplacedBy: "Swap.CPUWeight"},
+		{Section: "Swap", Name: "StartupCPUShares", Type: Int, Syntax: "SHARES", Deprecated: true, ReplacedBy: "Swap.StartupCPUWeight"},
+		{Section: "Swap", Name: "CPUQuota", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "CPUQuotaPeriodSec", Type: Duration, Syntax: "OTHER", Since: 242},
+		{Section: "Swap", Name: "MemoryAccounting", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Swap", Name: "MemoryMin", Type: String, Syntax: "LIMIT", Since: 240},
+		{Section: "Swap", Name: "DefaultMemoryMin", Type: String, Syntax: "LIMIT"},
+		{Section: "Swap", Name: "DefaultMemoryLow", Type: String, Syntax: "LIMIT", Since: 240},
+		{Section: "Swap", Name: "MemoryLow", Type: String, Syntax: "LIMIT", Since: 231},
+		{Section: "Swap", Name: "MemoryHigh", Type: String, Syntax: "LIMIT", Since: 231},
+		{Section: "Swap", Name: "MemoryMax", Type: String, Syntax: "LIMIT", Since: 231},
+		{Section: "Swap", Name: "MemorySwapMax", Type: String, Syntax: "LIMIT", Since: 232},
+		{Section: "Swap", Name: "MemoryLimit", Type: String, Syntax: "LIMIT", Deprecated: true, ReplacedBy: "Swap.MemoryMax"},
+		{Section: "Swap", Name: "DeviceAllow", Type: String, Syntax: "DEVICE", List: true, Resettable: true},
+		{Section: "Swap", Name: "DevicePolicy", Type: String, Syntax: "POLICY"},
+		{Section: "Swap", Name: "IOAccounting", Type: Bool, Syntax: "BOOLEAN", Since: 230},
+		{Section: "Swap", Name: "IOWeight", Type: Int, Syntax: "WEIGHT", Since: 230},
+		{Section: "Swap", Name: "StartupIOWeight", Type: Int, Syntax: "WEIGHT", Since: 230},
+		{Section: "Swap", Name: "IODeviceWeight", Type: String, Syntax: "DEVICEWEIGHT", List: true, Resettable: true, Since: 230},
+		{Section: "Swap", Name: "IOReadBandwidthMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Swap", Name: "IOWriteBandwidthMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Swap", Name: "IOReadIOPSMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Swap", Name: "IOWriteIOPSMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Swap", Name: "IODeviceLatencyTargetSec", Type: String, Syntax: "DEVICELATENCY", List: true, Resettable: true},
+		{Section: "Swap", Name: "BlockIOAccounting", Type: Bool, Syntax: "BOOLEAN", Deprecated: true, ReplacedBy: "Swap.IOAccounting"},
+		{Section: "Swap", Name: "BlockIOWeight", Type: Int, Syntax: "WEIGHT", Deprecated: true, ReplacedBy: "Swap.IOWeight"},
+		{Section: "Swap", Name: "StartupBlockIOWeight", Type: Int, Syntax: "WEIGHT", Deprecated: true, ReplacedBy: "Swap.StartupIOWeight"},
+		{Section: "Swap", Name: "BlockIODeviceWeight", Type: String, Syntax: "DEVICEWEIGHT", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Swap.IODeviceWeight"},
+		{Section: "Swap", Name: "BlockIOReadBandwidth", Type: String, Syntax: "BANDWIDTH", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Swap.IOReadBandwidthMax"},
+		{Section: "Swap", Name: "BlockIOWriteBandwidth", Type: String, Syntax: "BANDWIDTH", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Swap.IOWriteBandwidthMax"},
+		{Section: "Swap", Name: "TasksAccounting", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Swap", Name: "TasksMax", Type: String, Syntax: "OTHER", Since: 227},
+		{Section: "Swap", Name: "Delegate", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "DisableControllers", Type: String, Syntax: "OTHER"},
+		{Section: "Swap", Name: "IPAccounting", Type: Bool, Syntax: "BOOLEAN", Since: 235},
+		{Section: "Swap", Name: "IPAddressAllow", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Swap", Name: "IPAddressDeny", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Swap", Name: "IPIngressFilterPath", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 243},
+		{Section: "Swap", Name: "IPEgressFilterPath", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 243},
+		{Section: "Swap", Name: "ManagedOOMSwap", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Swap", Name: "ManagedOOMMemoryPressure", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Swap", Name: "ManagedOOMMemoryPressureLimit", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Swap", Name: "ManagedOOMPreference", Type: String, Syntax: "OTHER", Since: 248},
+		{Section: "Swap", Name: "BPFProgram", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 249},
+		{Section: "Swap", Name: "SocketBindAllow", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 249},
+		{Section: "Swap", Name: "SocketBindDeny", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 249},
+		{Section: "Swap", Name: "RestrictNetworkInterfaces", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 250},
+		{Section: "Swap", Name: "SendSIGKILL", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Swap", Name: "SendSIGHUP", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Swap", Name: "KillMode", Type: String, Syntax: "KILLMODE"},
+		{Section: "Swap", Name: "KillSignal", Type: String, Syntax: "SIGNAL"},
+		{Section: "Swap", Name: "RestartKillSignal", Type: String, Syntax: "SIGNAL", Since: 244},
+		{Section: "Swap", Name: "FinalKillSignal", Type: String, Syntax: "SIGNAL"},
+		{Section: "Swap", Name: "WatchdogSignal", Type: String, Syntax: "SIGNAL"},
+	},
+	"Timer": {
+		{Section: "Timer", Name: "OnCalendar", Type: Calendar, Syntax: "TIMER", List: true, Resettable: true},
+		{Section: "Timer", Name: "OnActiveSec", Type: Duration, Syntax: "TIMER", List: true, Resettable: true},
+		{Section: "Timer", Name: "OnBootSec", Type: Duration, Syntax: "TIMER", List: true, Resettable: true},
+		{Section: "Timer", Name: "OnStartupSec", Type: Duration, Syntax: "TIMER", List: true, Resettable: true},
+		{Section: "Timer", Name: "OnUnitActiveSec", Type: Duration, Syntax: "TIMER", List: true, Resettable: true},
+		{Section: "Timer", Name: "OnUnitInactiveSec", Type: Duration, Syntax: "TIMER", List: true, Resettable: true},
+		{Section: "Timer", Name: "OnClockChange", Type: Bool, Syntax: "BOOLEAN", Since: 242},
+		{Section: "Timer", Name: "OnTimezoneChange", Type: Bool, Syntax: "BOOLEAN", Since: 242},
+		{Section: "Timer", Name: "Persistent", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Timer", Name: "WakeSystem", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Timer", Name: "RemainAfterElapse", Type: Bool, Syntax: "BOOLEAN", Since: 229},
+		{Section: "Timer", Name: "FixedRandomDelay", Type: Bool, Syntax: "BOOLEAN", Since: 247},
+		{Section: "Timer", Name: "AccuracySec", Type: Duration, Syntax: "SECONDS"},
+		{Section: "Timer", Name: "RandomizedDelaySec", Type: Duration, Syntax: "SECONDS", Since: 229},
+		{Section: "Timer", Name: "Unit", Type: Unit, Syntax: "UNIT"},
+	},
+	"Path": {
+		{Section: "Path", Name: "PathExists", Type: Path, Syntax: "PATH", List: true, Resettable: true},
+		{Section: "Path", Name: "PathExistsGlob", Type: Path, Syntax: "PATH", List: true, Resettable: true},
+		{Section: "Path", Name: "PathChanged", Type: Path, Syntax: "PATH", List: true, Resettable: true},
+		{Section: "Path", Name: "PathModified", Type: Path, Syntax: "PATH", List: true, Resettable: true},
+		{Section: "Path", Name: "DirectoryNotEmpty", Type: Path, Syntax: "PATH", List: true, Resettable: true},
+		{Section: "Path", Name: "Unit", Type: Unit, Syntax: "UNIT"},
+		{Section: "Path", Name: "MakeDirectory", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Path", Name: "DirectoryMode", Type: Mode, Syntax: "MODE"},
+		{Section: "Path", Name: "TriggerLimitIntervalSec", Type: Duration, Syntax: "SECONDS"},
+		{Section: "Path", Name: "TriggerLimitBurst", Type: Int, Syntax: "UNSIGNED"},
+	},
+	"Slice": {
+		{Section: "Slice", Name: "Slice", Type: Unit, Syntax: "SLICE"},
+		{Section: "Slice", Name: "AllowedCPUs", Type: String, Syntax: "OTHER", Since: 244},
+		{Section: "Slice", Name: "StartupAllowedCPUs", Type: String, Syntax: "OTHER"},
+		{Section: "Slice", Name: "AllowedMemoryNodes", Type: String, Syntax: "OTHER", Since: 244},
+		{Section: "Slice", Name: "StartupAllowedMemoryNodes", Type: String, Syntax: "OTHER"},
+		{Section: "Slice", Name: "CPUAccounting", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Slice", Name: "CPUWeight", Type: String, Syntax: "CPUWEIGHT", Since: 232},
+		{Section: "Slice", Name: "StartupCPUWeight", Type: String, Syntax: "CPUWEIGHT", Since: 232},
+		{Section: "Slice", Name: "CPUShares", Type: Int, Syntax: "SHARES", Deprecated: true, ReplacedBy: "Slice.CPUWeight"},
+		{Section: "Slice", Name: "StartupCPUShares", Type: Int, Syntax: "SHARES", Deprecated: true, ReplacedBy: "Slice.StartupCPUWeight"},
+		{Section: "Slice", Name: "CPUQuota", Type: String, Syntax: "OTHER"},
+		{Section: "Slice", Name: "CPUQuotaPeriodSec", Type: Duration, Syntax: "OTHER", Since: 242},
+		{Section: "Slice", Name: "MemoryAccounting", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Slice", Name: "MemoryMin", Type: String, Syntax: "LIMIT", Since: 240},
+		{Section: "Slice", Name: "DefaultMemoryMin", Type: String, Syntax: "LIMIT"},
+		{Section: "Slice", Name: "DefaultMemoryLow", Type: String, Syntax: "LIMIT", Since: 240},
+		{Section: "Slice", Name: "MemoryLow", Type: String, Syntax: "LIMIT", Since: 231},
+		{Section: "Slice", Name: "MemoryHigh", Type: String, Syntax: "LIMIT", Since: 231},
+		{Section: "Slice", Name: "MemoryMax", Type: String, Syntax: "LIMIT", Since: 231},
+		{Section: "Slice", Name: "MemorySwapMax", Type: String, Syntax: "LIMIT", Since: 232},
+		{Section: "Slice", Name: "MemoryLimit", Type: String, Syntax: "LIMIT", Deprecated: true, ReplacedBy: "Slice.MemoryMax"},
+		{Section: "Slice", Name: "DeviceAllow", Type: String, Syntax: "DEVICE", List: true, Resettable: true},
+		{Section: "Slice", Name: "DevicePolicy", Type: String, Syntax: "POLICY"},
+		{Section: "Slice", Name: "IOAccounting", Type: Bool, Syntax: "BOOLEAN", Since: 230},
+		{Section: "Slice", Name: "IOWeight", Type: Int, Syntax: "WEIGHT", Since: 230},
+		{Section: "Slice", Name: "StartupIOWeight", Type: Int, Syntax: "WEIGHT", Since: 230},
+		{Section: "Slice", Name: "IODeviceWeight", Type: String, Syntax: "DEVICEWEIGHT", List: true, Resettable: true, Since: 230},
+		{Section: "Slice", Name: "IOReadBandwidthMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Slice", Name: "IOWriteBandwidthMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Slice", Name: "IOReadIOPSMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Slice", Name: "IOWriteIOPSMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Slice", Name: "IODeviceLatencyTargetSec", Type: String, Syntax: "DEVICELATENCY", List: true, Resettable: true},
+		{Section: "Slice", Name: "BlockIOAccounting", Type: Bool, Syntax: "BOOLEAN", Deprecated: true, ReplacedBy: "Slice.IOAccounting"},
+		{Section: "Slice", Name: "BlockIOWeight", Type: Int, Syntax: "WEIGHT", Deprecated: true, ReplacedBy: "Slice.IOWeight"},
+		{Section: "Slice", Name: "StartupBlockIOWeight", Type: Int, Syntax: "WEIGHT", Deprecated: true, ReplacedBy: "Slice.StartupIOWeight"},
+		{Section: "Slice", Name: "BlockIODeviceWeight", Type: String, Syntax: "DEVICEWEIGHT", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Slice.IODeviceWeight"},
+		{Section: "Slice", Name: "BlockIOReadBandwidth", Type: String, Syntax: "BANDWIDTH", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Slice.IOReadBandwidthMax"},
+		{Section: "Slice", Name: "BlockIOWriteBandwidth", Type: String, Syntax: "BANDWIDTH", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Slice.IOWriteBandwidthMax"},
+		{Section: "Slice", Name: "TasksAccounting", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Slice", Name: "TasksMax", Type: String, Syntax: "OTHER", Since: 227},
+		{Section: "Slice", Name: "Delegate", Type: String, Syntax: "OTHER"},
+		{Section: "Slice", Name: "DisableControllers", Type: String, Syntax: "OTHER"},
+		{Section: "Slice", Name: "IPAccounting", Type: Bool, Syntax: "BOOLEAN", Since: 235},
+		{Section: "Slice", Name: "IPAddressAllow", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Slice", Name: "IPAddressDeny", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Slice", Name: "IPIngressFilterPath", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 243},
+		{Section: "Slice", Name: "IPEgressFilterPath", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 243},
+		{Section: "Slice", Name: "ManagedOOMSwap", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Slice", Name: "ManagedOOMMemoryPressure", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Slice", Name: "ManagedOOMMemoryPressureLimit", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Slice", Name: "ManagedOOMPreference", Type: String, Syntax: "OTHER", Since: 248},
+		{Section: "Slice", Name: "BPFProgram", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 249},
+		{Section: "Slice", Name: "SocketBindAllow", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 249},
+		{Section: "Slice", Name: "SocketBindDeny", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 249},
+		{Section: "Slice", Name: "RestrictNetworkInterfaces", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 250},
+	},
+	"Scope": {
+		{Section: "Scope", Name: "Slice", Type: Unit, Syntax: "SLICE"},
+		{Section: "Scope", Name: "AllowedCPUs", Type: String, Syntax: "OTHER", Since: 244},
+		{Section: "Scope", Name: "StartupAllowedCPUs", Type: String, Syntax: "OTHER"},
+		{Section: "Scope", Name: "AllowedMemoryNodes", Type: String, Syntax: "OTHER", Since: 244},
+		{Section: "Scope", Name: "StartupAllowedMemoryNodes", Type: String, Syntax: "OTHER"},
+		{Section: "Scope", Name: "CPUAccounting", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Scope", Name: "CPUWeight", Type: String, Syntax: "CPUWEIGHT", Since: 232},
+		{Section: "Scope", Name: "StartupCPUWeight", Type: String, Syntax: "CPUWEIGHT", Since: 232},
+		{Section: "Scope", Name: "CPUShares", Type: Int, Syntax: "SHARES", Deprecated: true, ReplacedBy: "Scope.CPUWeight"},
+		{Section: "Scope", Name: "StartupCPUShares", Type: Int, Syntax: "SHARES", Deprecated: true, ReplacedBy: "Scope.StartupCPUWeight"},
+		{Section: "Scope", Name: "CPUQuota", Type: String, Syntax: "OTHER"},
+		{Section: "Scope", Name: "CPUQuotaPeriodSec", Type: Duration, Syntax: "OTHER", Since: 242},
+		{Section: "Scope", Name: "MemoryAccounting", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Scope", Name: "MemoryMin", Type: String, Syntax: "LIMIT", Since: 240},
+		{Section: "Scope", Name: "DefaultMemoryMin", Type: String, Syntax: "LIMIT"},
+		{Section: "Scope", Name: "DefaultMemoryLow", Type: String, Syntax: "LIMIT", Since: 240},
+		{Section: "Scope", Name: "MemoryLow", Type: String, Syntax: "LIMIT", Since: 231},
+		{Section: "Scope", Name: "MemoryHigh", Type: String, Syntax: "LIMIT", Since: 231},
+		{Section: "Scope", Name: "MemoryMax", Type: String, Syntax: "LIMIT", Since: 231},
+		{Section: "Scope", Name: "MemorySwapMax", Type: String, Syntax: "LIMIT", Since: 232},
+		{Section: "Scope", Name: "MemoryLimit", Type: String, Syntax: "LIMIT", Deprecated: true, ReplacedBy: "Scope.MemoryMax"},
+		{Section: "Scope", Name: "DeviceAllow", Type: String, Syntax: "DEVICE", List: true, Resettable: true},
+		{Section: "Scope", Name: "DevicePolicy", Type: String, Syntax: "POLICY"},
+		{Section: "Scope", Name: "IOAccounting", Type: Bool, Syntax: "BOOLEAN", Since: 230},
+		{Section: "Scope", Name: "IOWeight", Type: Int, Syntax: "WEIGHT", Since: 230},
+		{Section: "Scope", Name: "StartupIOWeight", Type: Int, Syntax: "WEIGHT", Since: 230},
+		{Section: "Scope", Name: "IODeviceWeight", Type: String, Syntax: "DEVICEWEIGHT", List: true, Resettable: true, Since: 230},
+		{Section: "Scope", Name: "IOReadBandwidthMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Scope", Name: "IOWriteBandwidthMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Scope", Name: "IOReadIOPSMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Scope", Name: "IOWriteIOPSMax", Type: String, Syntax: "LIMIT", List: true, Resettable: true, Since: 230},
+		{Section: "Scope", Name: "IODeviceLatencyTargetSec", Type: String, Syntax: "DEVICELATENCY", List: true, Resettable: true},
+		{Section: "Scope", Name: "BlockIOAccounting", Type: Bool, Syntax: "BOOLEAN", Deprecated: true, ReplacedBy: "Scope.IOAccounting"},
+		{Section: "Scope", Name: "BlockIOWeight", Type: Int, Syntax: "WEIGHT", Deprecated: true, ReplacedBy: "Scope.IOWeight"},
+		{Section: "Scope", Name: "StartupBlockIOWeight", Type: Int, Syntax: "WEIGHT", Deprecated: true, ReplacedBy: "Scope.StartupIOWeight"},
+		{Section: "Scope", Name: "BlockIODeviceWeight", Type: String, Syntax: "DEVICEWEIGHT", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Scope.IODeviceWeight"},
+		{Section: "Scope", Name: "BlockIOReadBandwidth", Type: String, Syntax: "BANDWIDTH", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Scope.IOReadBandwidthMax"},
+		{Section: "Scope", Name: "BlockIOWriteBandwidth", Type: String, Syntax: "BANDWIDTH", List: true, Resettable: true, Deprecated: true, ReplacedBy: "Scope.IOWriteBandwidthMax"},
+		{Section: "Scope", Name: "TasksAccounting", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Scope", Name: "TasksMax", Type: String, Syntax: "OTHER", Since: 227},
+		{Section: "Scope", Name: "Delegate", Type: String, Syntax: "OTHER"},
+		{Section: "Scope", Name: "DisableControllers", Type: String, Syntax: "OTHER"},
+		{Section: "Scope", Name: "IPAccounting", Type: Bool, Syntax: "BOOLEAN", Since: 235},
+		{Section: "Scope", Name: "IPAddressAllow", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Scope", Name: "IPAddressDeny", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 235},
+		{Section: "Scope", Name: "IPIngressFilterPath", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 243},
+		{Section: "Scope", Name: "IPEgressFilterPath", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 243},
+		{Section: "Scope", Name: "ManagedOOMSwap", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Scope", Name: "ManagedOOMMemoryPressure", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Scope", Name: "ManagedOOMMemoryPressureLimit", Type: String, Syntax: "OTHER", Since: 247},
+		{Section: "Scope", Name: "ManagedOOMPreference", Type: String, Syntax: "OTHER", Since: 248},
+		{Section: "Scope", Name: "BPFProgram", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 249},
+		{Section: "Scope", Name: "SocketBindAllow", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 249},
+		{Section: "Scope", Name: "SocketBindDeny", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 249},
+		{Section: "Scope", Name: "RestrictNetworkInterfaces", Type: String, Syntax: "OTHER", List: true, Resettable: true, Since: 250},
+		{Section: "Scope", Name: "SendSIGKILL", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Scope", Name: "SendSIGHUP", Type: Bool, Syntax: "BOOLEAN"},
+		{Section: "Scope", Name: "KillMode", Type: String, Syntax: "KILLMODE"},
+		{Section: "Scope", Name: "KillSignal", Type: String, Syntax: "SIGNAL"},
+		{Section: "Scope", Name: "RestartKillSignal", Type: String, Syntax: "SIGNAL", Since: 244},
+		{Section: "Scope", Name: "FinalKillSignal", Type: String, Syntax: "SIGNAL"},
+		{Section: "Scope", Name: "WatchdogSignal", Type: String, Syntax: "SIGNAL"},
+		{Section: "Scope", Name: "RuntimeMaxSec", Type: Duration, Syntax: "SECONDS"},
+		{Section: "Scope", Name: "RuntimeRandomizedExtraSec", Type: Duration, Syntax: "SECONDS"},
+		{Section: "Scope", Name: "TimeoutStopSec", Type: Duration, Syntax: "SECONDS"},
+		{Section: "Scope", Name: "OOMPolicy", Type: String, Syntax: "OTHER"},
+	},
+	"Install": {
+		{Section: "Install", Name: "Alias", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Install", Name: "WantedBy", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Install", Name: "RequiredBy", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Install", Name: "Also", Type: String, Syntax: "OTHER", List: true, Resettable: true},
+		{Section: "Install", Name: "DefaultInstance", Type: String, Syntax: "OTHER"},
+	},
+}
@@ -0,0 +1,18 @@
+package catalog_test
+
+import (
+	"fmt"
+
+	"github.com/javadh75/systemd-config/catalog"
+)
+
+func ExampleLookup() {
+	o, ok := catalog.Lookup("service", "Service", "MemoryLimit")
+	fmt.Println(ok, o.Type, o.Deprecated, o.ReplacedBy)
+
+	o, _ = catalog.Lookup("service", "Unit", "After")
+	fmt.Println(o.List, o.Resettable)
+	// Output:
+	// true string true Service.MemoryMax
+	// true false
+}
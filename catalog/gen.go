@@ -0,0 +1,269 @@
+//go:build ignore
+
+// gen.go generates directives_gen.go from directives.txt and
+// annotations.txt, see the package documentation.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// option is an option of directives.txt with its annotations.
+type option struct {
+	section, name, syntax string
+	typ                   string
+	list, resettable      bool
+	deprecated            bool
+	replacedBy            string
+	since                 int
+}
+
+// syntaxTypes are the value types of the syntaxes systemd prints; the
+// others are String.
+var syntaxTypes = map[string]string{
+	"BOOLEAN":               "Bool",
+	"UNSIGNED":              "Int",
+	"INTEGER":               "Int",
+	"LONG":                  "Int",
+	"NICE":                  "Int",
+	"OOMSCOREADJUST":        "Int",
+	"CPUSCHEDPRIO":          "Int",
+	"SHARES":                "Int",
+	"WEIGHT":                "Int",
+	"SECONDS":               "Duration",
+	"NANOSECONDS":           "Duration",
+	"TIMER":                 "Duration",
+	"SIZE":                  "Bytes",
+	"MODE":                  "Mode",
+	"PATH":                  "Path",
+	"PATH [...]":            "Path",
+	"FILE":                  "Path",
+	"UNIT":                  "Unit",
+	"UNIT [...]":            "Unit",
+	"SLICE":                 "Unit",
+	"SERVICE":               "Unit",
+	"PATH [ARGUMENT [...]]": "Command",
+}
+
+// listSyntaxes are the syntaxes of options whose repeated assignments
+// accumulate, besides those with "[...]".
+var listSyntaxes = []string{
+	"ARCHS", "BANDWIDTH", "BOUNDINGSET", "CONDITION", "DEVICE", "DEVICELATENCY", "DEVICEWEIGHT",
+	"ENVIRON", "FAMILIES", "FILE", "FILESYSTEMS", "SOCKETS", "SYSCALLS", "TIMER",
+}
+
+// typeNames are the value types annotations.txt may name.
+var typeNames = map[string]string{
+	"string": "String", "bool": "Bool", "int": "Int", "duration": "Duration", "bytes": "Bytes",
+	"mode": "Mode", "path": "Path", "unit": "Unit", "command": "Command", "calendar": "Calendar",
+}
+
+var versionRE = regexp.MustCompile(`^# Generated by systemd (\d+)`)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gen: ")
+
+	version, order, sections := readDirectives("directives.txt")
+	annotate("annotations.txt", sections)
+	checkReplacements(sections)
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen.go from directives.txt and annotations.txt; DO NOT EDIT.\n\n")
+	b.WriteString("package catalog\n\n")
+	fmt.Fprintf(&b, "const systemdVersion = %d\n\n", version)
+	b.WriteString("// sections are the options of each section, in the order of systemd's table.\n")
+	b.WriteString("var sections = map[string][]Option{\n")
+	for _, name := range order {
+		fmt.Fprintf(&b, "%q: {\n", name)
+		for _, o := range sections[name] {
+			fmt.Fprintf(&b, "{Section: %q, Name: %q, Type: %s, Syntax: %q", o.section, o.name, o.typ, o.syntax)
+			if o.list {
+				b.WriteString(", List: true")
+			}
+			if o.resettable {
+				b.WriteString(", Resettable: true")
+			}
+			if o.deprecated {
+				b.WriteString(", Deprecated: true")
+			}
+			if o.replacedBy != "" {
+				fmt.Fprintf(&b, ", ReplacedBy: %q", o.replacedBy)
+			}
+			if o.since != 0 {
+				fmt.Fprintf(&b, ", Since: %d", o.since)
+			}
+			b.WriteString("},\n")
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("directives_gen.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readDirectives reads the output of systemd --dump-configuration-items
+// with a "# Generated by systemd N" header. It returns N, the section
+// names in order and the options of each section.
+func readDirectives(name string) (int, []string, map[string][]*option) {
+	f, err := os.Open(name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	var (
+		version  int
+		order    []string
+		sections = map[string][]*option{}
+		section  string
+	)
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		switch {
+		case version == 0 && versionRE.MatchString(line):
+			version, _ = strconv.Atoi(versionRE.FindStringSubmatch(line)[1])
+		case line == "" || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			section = line[1 : len(line)-1]
+			if _, ok := sections[section]; ok {
+				log.Fatalf("%s:%d: duplicate section [%s]", name, n, section)
+			}
+			order = append(order, section)
+			sections[section] = nil
+		default:
+			key, syntax, ok := strings.Cut(line, "=")
+			if !ok || section == "" {
+				log.Fatalf("%s:%d: malformed line %q", name, n, line)
+			}
+			typ, ok := syntaxTypes[syntax]
+			if !ok {
+				typ = "String"
+			}
+			list := strings.Contains(syntax, "[...]") || slices.Contains(listSyntaxes, syntax)
+			sections[section] = append(sections[section], &option{
+				section:    section,
+				name:       key,
+				syntax:     syntax,
+				typ:        typ,
+				list:       list,
+				resettable: list && typ != "Unit",
+			})
+		}
+	}
+	if err := sc.Err(); err != nil {
+		log.Fatal(err)
+	}
+	if version == 0 {
+		log.Fatalf("%s: no systemd version", name)
+	}
+	return version, order, sections
+}
+
+// annotate applies the annotations of the named file to sections. An
+// annotation of an option that does not exist is an error.
+func annotate(name string, sections map[string][]*option) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for n, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		pos := fmt.Sprintf("%s:%d", name, n+1)
+		section, key, ok := strings.Cut(fields[0], ".")
+		if !ok || len(fields) < 2 {
+			log.Fatalf("%s: malformed line %q", pos, line)
+		}
+
+		var matched []*option
+		for s, opts := range sections {
+			if section != "*" && s != section {
+				continue
+			}
+			for _, o := range opts {
+				if o.name == key {
+					matched = append(matched, o)
+				}
+			}
+		}
+		if len(matched) == 0 {
+			log.Fatalf("%s: no option %s", pos, fields[0])
+		}
+		for _, attr := range fields[1:] {
+			for _, o := range matched {
+				if err := o.apply(attr); err != nil {
+					log.Fatalf("%s: %v", pos, err)
+				}
+			}
+		}
+	}
+}
+
+// apply applies an attribute of annotations.txt to o.
+func (o *option) apply(attr string) error {
+	key, value, _ := strings.Cut(attr, "=")
+	switch key {
+	case "type":
+		typ, ok := typeNames[value]
+		if !ok {
+			return fmt.Errorf("unknown type %q", value)
+		}
+		o.typ = typ
+	case "list":
+		o.list, o.resettable = true, true
+	case "scalar":
+		o.list, o.resettable = false, false
+	case "noreset":
+		o.resettable = false
+	case "deprecated":
+		o.deprecated = true
+		if value != "" && !strings.Contains(value, ".") {
+			value = o.section + "." + value
+		}
+		o.replacedBy = value
+	case "since":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("malformed version %q", value)
+		}
+		o.since = n
+	default:
+		return fmt.Errorf("unknown attribute %q", attr)
+	}
+	return nil
+}
+
+// checkReplacements fails unless the replacements of deprecated options
+// exist.
+func checkReplacements(sections map[string][]*option) {
+	for _, opts := range sections {
+		for _, o := range opts {
+			if o.replacedBy == "" {
+				continue
+			}
+			section, key, _ := strings.Cut(o.replacedBy, ".")
+			if !slices.ContainsFunc(sections[section], func(r *option) bool { return r.name == key }) {
+				log.Fatalf("%s.%s: no replacement option %s", o.section, o.name, o.replacedBy)
+			}
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package systemdconfig
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+)
+
+// lineKind classifies the lines between nodes.
+type lineKind int
+
+const (
+	blankLine lineKind = iota
+	commentLine
+	// skippedLine is a line with a syntax error, kept verbatim by a
+	// lossless decoder that recovers from errors
+	skippedLine
+)
+
+// classifyLines splits raw, the text between two nodes, into lines
+// (keeping their line endings) and classifies each. A comment ending in a
+// backslash continues on the next line, as the lexer sees it.
+func classifyLines(raw []byte) ([][]byte, []lineKind) {
+	lines := bytes.SplitAfter(raw, []byte{'\n'})
+	if len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	kinds := make([]lineKind, len(lines))
+	continued := false
+	for i, line := range lines {
+		content := bytes.TrimLeftFunc(line, unicode.IsSpace)
+		switch {
+		case continued || len(content) > 0 && IsComment(rune(content[0])):
+			kinds[i] = commentLine
+		case len(content) == 0:
+			kinds[i] = blankLine
+		default:
+			kinds[i] = skippedLine
+		}
+		content = bytes.TrimSuffix(bytes.TrimRight(line, "\r\n"), []byte{' '})
+		continued = kinds[i] == commentLine && bytes.HasSuffix(content, []byte{'\\'})
+	}
+	return lines, kinds
+}
+
+// splitComments splits the blank and comment lines before a node into
+// the lines detached from it and the block of comment lines directly
+// above it, with no blank line in between.
+func splitComments(leading []byte) (detached, block []byte) {
+	lines, kinds := classifyLines(leading)
+	start := len(leading)
+	for i := len(lines) - 1; i >= 0 && kinds[i] == commentLine; i-- {
+		start -= len(lines[i])
+	}
+	return leading[:start], leading[start:]
+}
+
+// parseComments returns the text of every comment line in raw, without
+// the comment marker and the single space following it. It returns nil
+// when raw holds no comment.
+func parseComments(raw []byte) []string {
+	var comments []string
+	lines, kinds := classifyLines(raw)
+	for i, line := range lines {
+		if kinds[i] != commentLine {
+			continue
+		}
+		text := strings.TrimRight(strings.TrimLeftFunc(string(line), unicode.IsSpace), "\r\n")
+		if text != "" && IsComment(rune(text[0])) {
+			text = strings.TrimPrefix(text[1:], " ")
+		}
+		comments = append(comments, text)
+	}
+	return comments
+}
+
+// splitLines splits every comment into its lines, so that a comment with
+// an embedded newline cannot break out of its comment line when written.
+func splitLines(comments []string) []string {
+	if len(comments) == 0 {
+		return nil
+	}
+	var lines []string
+	for _, c := range comments {
+		lines = append(lines, strings.Split(c, "\n")...)
+	}
+	return lines
+}
+
+// writeComments writes every comment as a '#' comment line to given
+// buffer.
+func writeComments(buf *bytes.Buffer, comments []string, cfg encoderConfig) {
+	for _, c := range comments {
+		buf.WriteRune('#')
+		if c != "" {
+			buf.WriteRune(' ')
+			buf.WriteString(c)
+		}
+		writeNewLine(buf, cfg)
+	}
+}
@@ -0,0 +1,126 @@
+package systemdconfig
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitComments(t *testing.T) {
+	tests := []struct {
+		name     string
+		leading  string
+		detached string
+		block    string
+	}{
+		{"Empty", "", "", ""},
+		{"OnlyBlankLines", "\n  \n", "\n  \n", ""},
+		{"AttachedBlock", "# one\n; two\n", "", "# one\n; two\n"},
+		{"BlankLineDetaches", "# header\n\n# attached\n", "# header\n\n", "# attached\n"},
+		{"CRLF", "# header\r\n\r\n  # attached\r\n", "# header\r\n\r\n", "  # attached\r\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detached, block := splitComments([]byte(tt.leading))
+			if string(detached) != tt.detached || string(block) != tt.block {
+				t.Errorf("splitComments() = %q, %q, want %q, %q", detached, block, tt.detached, tt.block)
+			}
+		})
+	}
+}
+
+func TestParseComments(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []string
+	}{
+		{"Empty", "", nil},
+		{"BlankLines", "\n\n", nil},
+		{"Markers", "# hash\n;semicolon\n#\n", []string{"hash", "semicolon", ""}},
+		{"OnlyOneSpaceStripped", "#   indented\n", []string{"  indented"}},
+		{"IndentedCRLF", "  # text\r\n", []string{"text"}},
+		{"ContinuedComment", "# first \\\nsecond\n", []string{`first \`, "second"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseComments([]byte(tt.raw)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseComments() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommentsWritten(t *testing.T) {
+	unit := NewUnit()
+	unit.SetTrailingComments("end of file")
+	s := unit.AddSection("Unit")
+	s.SetComments("Managed by X, do not edit", "")
+	s.AddOption("Description", "Test").SetComments("what this is\nshown in systemctl status")
+	unit.AddSection("Install").AddOption("WantedBy", "multi-user.target")
+
+	want := `# Managed by X, do not edit
+#
+[Unit]
+# what this is
+# shown in systemctl status
+Description=Test
+
+[Install]
+WantedBy=multi-user.target
+
+# end of file
+`
+	if got := unit.String(); got != want {
+		t.Errorf("Unit.WriteTo() wrote\n%s\nwant\n%s", got, want)
+	}
+
+	// the canonical form, read back losslessly, attaches the same comments
+	reparsed, err := DeserializeLossless(strings.NewReader(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reparsed.Sections[0].Comments(); !reflect.DeepEqual(got, []string{"Managed by X, do not edit", ""}) {
+		t.Errorf("Section.Comments() = %q", got)
+	}
+	if got := reparsed.Sections[0].Options[0].Comments(); !reflect.DeepEqual(got, []string{"what this is", "shown in systemctl status"}) {
+		t.Errorf("OptionValue.Comments() = %q", got)
+	}
+	if got := reparsed.TrailingComments(); !reflect.DeepEqual(got, []string{"end of file"}) {
+		t.Errorf("Unit.TrailingComments() = %q", got)
+	}
+}
+
+func TestCommentsLossless(t *testing.T) {
+	const in = "# vendor header\n\n;  Unit section\n[Unit]\n\n#detached\n\n# attached\nDescription=Test\n\n# trailing one\n\n# trailing two\n"
+
+	unit, err := DeserializeLossless(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	section := unit.Sections[0]
+	option := section.Options[0]
+	if got := section.Comments(); !reflect.DeepEqual(got, []string{" Unit section"}) {
+		t.Errorf("Section.Comments() = %q", got)
+	}
+	if got := option.Comments(); !reflect.DeepEqual(got, []string{"attached"}) {
+		t.Errorf("OptionValue.Comments() = %q", got)
+	}
+	if got := unit.TrailingComments(); !reflect.DeepEqual(got, []string{"trailing one", "trailing two"}) {
+		t.Errorf("Unit.TrailingComments() = %q", got)
+	}
+
+	section.SetComments("Managed by X")
+	option.SetComments()
+	unit.SetTrailingComments("the end")
+	want := "# vendor header\n\n# Managed by X\n[Unit]\n\n#detached\n\nDescription=Test\n\n# the end\n"
+	if got := unit.String(); got != want {
+		t.Errorf("Unit.WriteTo() wrote\n%q\nwant\n%q", got, want)
+	}
+
+	unit.SetTrailingComments()
+	want = "# vendor header\n\n# Managed by X\n[Unit]\n\n#detached\n\nDescription=Test\n"
+	if got := unit.String(); got != want {
+		t.Errorf("Unit.WriteTo() wrote\n%q\nwant\n%q", got, want)
+	}
+}
@@ -0,0 +1,176 @@
+package systemdconfig
+
+import (
+	"errors"
+	"io"
+)
+
+// LegacyLineMax mimics LINE_MAX, the maximum line length older systemd
+// versions accept in a unit file. Use it with WithLineMax to reject
+// files those versions would reject.
+const LegacyLineMax = 2048
+
+// decoderConfig holds the parse options of a Decoder.
+type decoderConfig struct {
+	// filename is reported in errors; it may be empty.
+	filename string
+	// lossless makes the lexer record the concrete syntax of every node.
+	lossless bool
+	// recover makes the lexer record syntax errors and skip the offending
+	// line instead of stopping, as systemd does.
+	recover bool
+
+	lineMax             int
+	stripBOM            bool
+	strictUTF8          bool
+	rejectControl       bool
+	strictContinuations bool
+
+	maxFileSize int64
+	maxSections int
+	maxOptions  int
+}
+
+// A Decoder reads and parses a systemd config from an input stream. Its
+// options make the parse stricter than systemd's, e.g. to safely parse
+// untrusted uploads, or change what it records.
+type Decoder struct {
+	r io.Reader
+	decoderConfig
+}
+
+// DecoderOption configures a Decoder.
+type DecoderOption func(*decoderConfig)
+
+// NewDecoder returns a new decoder that reads from r. Without options it
+// parses like Deserialize.
+func NewDecoder(r io.Reader, opts ...DecoderOption) *Decoder {
+	return &Decoder{r: r, decoderConfig: newDecoderConfig(r, opts)}
+}
+
+// newDecoderConfig returns the configuration for reading from r with the
+// given options applied.
+func newDecoderConfig(r io.Reader, opts []DecoderOption) decoderConfig {
+	cfg := decoderConfig{filename: nameOf(r), lineMax: LineMax}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// Decode reads the whole input and parses it into a Unit. On error it
+// returns the sections parsed so far alongside the error. Syntax errors
+// are reported as a *ParseError, or as an ErrorList when recovering with
+// WithRecovery.
+func (d *Decoder) Decode() (*Unit, error) {
+	l := newLexer(d.r, d.decoderConfig)
+	if err := l.lex(); err != nil {
+		// errors that cannot be recovered from end the list
+		var perr *ParseError
+		if l.recover && errors.As(err, &perr) {
+			l.errs = append(l.errs, perr)
+			return l.unit, l.errs
+		}
+		return l.unit, err
+	}
+	l.finish()
+	if len(l.errs) > 0 {
+		return l.unit, l.errs
+	}
+	return l.unit, nil
+}
+
+// WithFilename sets the file name reported in errors. By default it is
+// the name of the reader when it has one, as an *os.File does.
+func WithFilename(name string) DecoderOption {
+	return func(c *decoderConfig) {
+		c.filename = name
+	}
+}
+
+// WithLossless makes the decoder record the concrete syntax of the input,
+// as DeserializeLossless does.
+func WithLossless() DecoderOption {
+	return func(c *decoderConfig) {
+		c.lossless = true
+	}
+}
+
+// WithRecovery makes the decoder skip the lines with syntax errors and
+// report all of them in an ErrorList, as DeserializeAll does. Violations
+// of WithMaxFileSize, WithMaxSections and WithMaxOptions still stop it.
+// Combined with WithLossless, skipped lines are kept verbatim.
+func WithRecovery() DecoderOption {
+	return func(c *decoderConfig) {
+		c.recover = true
+	}
+}
+
+// WithLineMax sets the maximum line length in bytes, LineMax by default.
+// Use LegacyLineMax to accept only what older systemd versions accept.
+// Longer lines yield an error wrapping ErrLineTooLong.
+func WithLineMax(n int) DecoderOption {
+	return func(c *decoderConfig) {
+		c.lineMax = n
+	}
+}
+
+// WithBOMStripping makes the decoder skip a UTF-8 byte order mark at the
+// start of the input, which otherwise yields ErrAssignmentOutsideSection.
+// A lossless unit writes it back.
+func WithBOMStripping() DecoderOption {
+	return func(c *decoderConfig) {
+		c.stripBOM = true
+	}
+}
+
+// WithStrictUTF8 rejects input that is not valid UTF-8 with an
+// InvalidUTF8 error.
+func WithStrictUTF8() DecoderOption {
+	return func(c *decoderConfig) {
+		c.strictUTF8 = true
+	}
+}
+
+// WithRejectControlChars rejects control characters other than tab,
+// carriage return and line feed with a ControlCharacter error.
+func WithRejectControlChars() DecoderOption {
+	return func(c *decoderConfig) {
+		c.rejectControl = true
+	}
+}
+
+// WithStrictContinuations rejects values ending in a backslash that has
+// nothing to continue with, at the end of the file or before trailing
+// whitespace, with a DanglingBackslash error. By default the dangling
+// backslash is dropped.
+func WithStrictContinuations() DecoderOption {
+	return func(c *decoderConfig) {
+		c.strictContinuations = true
+	}
+}
+
+// WithMaxFileSize limits the input to n bytes; the decoder never reads
+// more than one byte beyond it. Larger input yields an error wrapping
+// ErrFileTooLarge.
+func WithMaxFileSize(n int64) DecoderOption {
+	return func(c *decoderConfig) {
+		c.maxFileSize = n
+	}
+}
+
+// WithMaxSections limits the input to n sections. More sections yield an
+// error wrapping ErrTooManySections.
+func WithMaxSections(n int) DecoderOption {
+	return func(c *decoderConfig) {
+		c.maxSections = n
+	}
+}
+
+// WithMaxOptions limits the input to n assignments in total. More
+// assignments yield an error wrapping ErrTooManyOptions.
+func WithMaxOptions(n int) DecoderOption {
+	return func(c *decoderConfig) {
+		c.maxOptions = n
+	}
+}
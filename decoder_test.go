@@ -0,0 +1,222 @@
+package systemdconfig
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecoder_Decode(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		opts    []DecoderOption
+		kind    ErrorKind
+		pos     string
+		wantErr error
+	}{
+		{
+			name: "DefaultsAcceptWhatDeserializeAccepts",
+			in:   "[Unit]\nDescription=" + strings.Repeat("x", LegacyLineMax) + "\x01\xff \\",
+		},
+		{
+			name:    "LegacyLineMax",
+			in:      "[Unit]\nDescription=" + strings.Repeat("x", LegacyLineMax) + "\n",
+			opts:    []DecoderOption{WithLineMax(LegacyLineMax)},
+			kind:    LineTooLong,
+			pos:     "2:1",
+			wantErr: ErrLineTooLong,
+		},
+		{
+			name: "LegacyLineMaxFits",
+			in:   "[Unit]\nDescription=" + strings.Repeat("x", LegacyLineMax-len("Description=")) + "\n",
+			opts: []DecoderOption{WithLineMax(LegacyLineMax)},
+		},
+		{
+			name: "StrictUTF8InValue",
+			in:   "[Unit]\nDescription=caf\xe9\n",
+			opts: []DecoderOption{WithStrictUTF8()},
+			kind: InvalidUTF8,
+			pos:  "2:16",
+		},
+		{
+			name: "StrictUTF8InOptionName",
+			in:   "[Unit]\nDescr\xffiption=Test\n",
+			opts: []DecoderOption{WithStrictUTF8()},
+			kind: InvalidUTF8,
+			pos:  "2:6",
+		},
+		{
+			name: "StrictUTF8AcceptsUTF8",
+			in:   "[Unit]\nDescription=café ☕\n",
+			opts: []DecoderOption{WithStrictUTF8()},
+		},
+		{
+			name: "ControlCharacter",
+			in:   "[Unit]\nDescription=a\x1b[31mred\n",
+			opts: []DecoderOption{WithRejectControlChars()},
+			kind: ControlCharacter,
+			pos:  "2:14",
+		},
+		{
+			name: "ControlCharacterAllowsTabAndCRLF",
+			in:   "[Unit]\r\nDescription=\ta\tb\r\n",
+			opts: []DecoderOption{WithRejectControlChars()},
+		},
+		{
+			name: "DanglingBackslashAtEOF",
+			in:   "[Service]\nExecStart=/bin/foo \\",
+			opts: []DecoderOption{WithStrictContinuations()},
+			kind: DanglingBackslash,
+			pos:  "2:20",
+		},
+		{
+			name: "DanglingBackslashBeforeWhitespace",
+			in:   "[Service]\nExecStart=/bin/foo \\ \n",
+			opts: []DecoderOption{WithStrictContinuations()},
+			kind: DanglingBackslash,
+			pos:  "2:20",
+		},
+		{
+			name: "ContinuationIsNotDangling",
+			in:   "[Service]\nExecStart=/bin/foo \\\n  --bar\n",
+			opts: []DecoderOption{WithStrictContinuations()},
+		},
+		{
+			name:    "MaxFileSize",
+			in:      "[Unit]\nDescription=Test\n",
+			opts:    []DecoderOption{WithMaxFileSize(20)},
+			kind:    FileTooLarge,
+			pos:     "2:14",
+			wantErr: ErrFileTooLarge,
+		},
+		{
+			name: "MaxFileSizeFits",
+			in:   "[Unit]\nDescription=Test\n",
+			opts: []DecoderOption{WithMaxFileSize(24)},
+		},
+		{
+			name:    "MaxSections",
+			in:      "[Address]\n[Address]\n[Address]\n",
+			opts:    []DecoderOption{WithMaxSections(2)},
+			kind:    TooManySections,
+			pos:     "3:1",
+			wantErr: ErrTooManySections,
+		},
+		{
+			name:    "MaxOptions",
+			in:      "[Network]\nDNS=1.1.1.1\n[Network]\nDNS=8.8.8.8\n",
+			opts:    []DecoderOption{WithMaxOptions(1)},
+			kind:    TooManyOptions,
+			pos:     "4:1",
+			wantErr: ErrTooManyOptions,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDecoder(strings.NewReader(tt.in), tt.opts...).Decode()
+			if tt.kind == 0 {
+				if err != nil {
+					t.Fatalf("Decode() error = %v", err)
+				}
+				return
+			}
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("Decode() error = %v, want a *ParseError", err)
+			}
+			if perr.Kind != tt.kind || perr.Pos.String() != tt.pos {
+				t.Errorf("Decode() error = %v (%v), want %v at %v", err, perr.Kind, tt.kind, tt.pos)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecoder_LineMaxMessage(t *testing.T) {
+	in := "[Unit]\nDescription=" + strings.Repeat("x", LegacyLineMax) + "\n"
+	_, err := NewDecoder(strings.NewReader(in), WithLineMax(LegacyLineMax), WithFilename("big.service")).Decode()
+	if want := "big.service:2:1: line too long (max 2048 bytes)"; err == nil || err.Error() != want {
+		t.Errorf("Decode() error = %v, want %v", err, want)
+	}
+}
+
+func TestDecoder_BOM(t *testing.T) {
+	const in = "\ufeff[Unit]\nDescription=Test\n"
+
+	if _, err := NewDecoder(strings.NewReader(in)).Decode(); !errors.Is(err, ErrAssignmentOutsideSection) {
+		t.Errorf("Decode() without WithBOMStripping error = %v, want ErrAssignmentOutsideSection", err)
+	}
+
+	unit, err := NewDecoder(strings.NewReader(in), WithBOMStripping()).Decode()
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got, want := unit.Sections[0].Pos(), (Position{Offset: 3, Line: 1, Column: 1}); got != want {
+		t.Errorf("Section.Pos() = %+v, want %+v", got, want)
+	}
+	if got, want := unit.String(), "[Unit]\nDescription=Test\n"; got != want {
+		t.Errorf("canonical output = %q, want %q", got, want)
+	}
+
+	lossless, err := NewDecoder(strings.NewReader(in), WithBOMStripping(), WithLossless()).Decode()
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if got := lossless.String(); got != in {
+		t.Errorf("lossless output = %q, want %q", got, in)
+	}
+}
+
+func TestDecoder_Recovery(t *testing.T) {
+	const in = "[Unit]\nDescr\x01iption=Test\nAfter=network.target \\ \n[Install]\nWantedBy=multi-user.target\n"
+	unit, err := NewDecoder(strings.NewReader(in), WithRecovery(), WithRejectControlChars(), WithStrictContinuations()).Decode()
+
+	var errs ErrorList
+	if !errors.As(err, &errs) || len(errs) != 2 || errs[0].Kind != ControlCharacter || errs[1].Kind != DanglingBackslash {
+		t.Fatalf("Decode() error = %v, want a ControlCharacter and a DanglingBackslash error", err)
+	}
+	want := &Unit{Sections: []*Section{
+		{Name: "Unit", Options: []*OptionValue{{Option: "After", Value: "network.target"}}},
+		{Name: "Install", Options: []*OptionValue{{Option: "WantedBy", Value: "multi-user.target"}}},
+	}}
+	if !reflect.DeepEqual(withoutPositions(unit), want) {
+		t.Errorf("Decode() = %v, want %v", unit, want)
+	}
+}
+
+func TestDecoder_RecoveryStopsAtLimits(t *testing.T) {
+	const in = "Orphan=yes\n[Address]\n[Address]\n"
+	_, err := NewDecoder(strings.NewReader(in), WithRecovery(), WithMaxSections(1)).Decode()
+
+	var errs ErrorList
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("Decode() error = %v, want an ErrorList of two errors", err)
+	}
+	if !errors.Is(err, ErrAssignmentOutsideSection) || !errors.Is(err, ErrTooManySections) {
+		t.Errorf("Decode() error = %v, want ErrAssignmentOutsideSection and ErrTooManySections", err)
+	}
+}
+
+func TestDecoder_LosslessRecovery(t *testing.T) {
+	const in = "# header\n[Unit]\nDescription=Test\nbroken line\n# about After\nAfter=network.target\n[Service\nType=simple\n[Install] junk\nWantedBy=multi-user.target\n"
+	unit, err := NewDecoder(strings.NewReader(in), WithLossless(), WithRecovery()).Decode()
+	if err == nil {
+		t.Fatal("Decode() error = nil, want syntax errors")
+	}
+	if got := unit.String(); got != in {
+		t.Errorf("lossless output =\n%q\nwant\n%q", got, in)
+	}
+	if got := unit.Sections[0].Options[1].Comments(); !reflect.DeepEqual(got, []string{"about After"}) {
+		t.Errorf("OptionValue.Comments() = %q, want [about After]", got)
+	}
+
+	unit.Sections[0].Options[1].Value = "network-online.target"
+	want := strings.Replace(in, "After=network.target", "After=network-online.target", 1)
+	if got := unit.String(); got != want {
+		t.Errorf("lossless output after edit =\n%q\nwant\n%q", got, want)
+	}
+}
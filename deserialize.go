@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 const (
@@ -25,23 +27,268 @@ var (
 	// other non-comment text) appears before the first section header.
 	// systemd rejects such lines too.
 	ErrAssignmentOutsideSection = errors.New("assignment outside of section")
+
+	// ErrFileTooLarge gets returned when the input exceeds the size set
+	// with WithMaxFileSize.
+	ErrFileTooLarge = errors.New("file too large")
+
+	// ErrTooManySections gets returned when the input has more sections
+	// than allowed with WithMaxSections.
+	ErrTooManySections = errors.New("too many sections")
+
+	// ErrTooManyOptions gets returned when the input has more assignments
+	// than allowed with WithMaxOptions.
+	ErrTooManyOptions = errors.New("too many options")
 )
 
+// lineTooLongError is the ErrLineTooLong of a Decoder with a line limit
+// other than LineMax.
+type lineTooLongError struct {
+	max int
+}
+
+func (e *lineTooLongError) Error() string {
+	return fmt.Sprintf("line too long (max %d bytes)", e.max)
+}
+
+// Is reports whether target is ErrLineTooLong.
+func (e *lineTooLongError) Is(target error) bool {
+	return target == ErrLineTooLong
+}
+
 type lexer struct {
+	decoderConfig
+
 	buf  *bufio.Reader
 	unit *Unit
+
+	// offset is the byte offset of the next unread byte, line the 1-based
+	// line it sits on and lineStart the offset at which that line begins.
+	offset, line, lineStart int
+	// lastSize is the size of the last rune read, for unreadRune.
+	lastSize int
+	// eol is the position just past the content of the line most recently
+	// returned by toEOL, excluding the line ending.
+	eol Position
+
+	// in lossless mode, raw holds all input read so far and consumed is
+	// the offset up to which it has been attributed to a node
+	raw      bytes.Buffer
+	consumed int
+
+	// errs holds the syntax errors recovered from
+	errs ErrorList
+	// sections and options count the sections and assignments parsed so
+	// far
+	sections, options int
+
+	// stream makes the lexer queue tokens and recovered syntax errors for
+	// a Tokenizer instead of building a unit
+	stream bool
+	queue  []queued
+	// orphan is the section collecting the assignments after an unusable
+	// section header
+	orphan *Section
+	// blank is where the whitespace read from the start of the current
+	// line ends, and cr the position of the last carriage return read as
+	// whitespace
+	blank int
+	cr    Position
+}
+
+// source is the concrete syntax of a section header or assignment parsed
+// in lossless mode, which WriteTo reproduces verbatim as long as the node
+// is not modified.
+type source struct {
+	// leading holds the blank and comment lines before the node that are
+	// not attached to it.
+	leading []byte
+	// comments holds the comment lines directly above the node, and
+	// commentLines their text as returned by Comments.
+	comments     []byte
+	commentLines []string
+	// text holds the node's own lines, from the indentation of its first
+	// line through the line ending of its last.
+	text []byte
+	// name and value are the node's name and value as parsed, to detect
+	// modifications; value is unused for sections.
+	name, value string
 }
 
 type lexStep func() (lexStep, error)
 
-// newLexer returns a lexer that parses f into a fresh unit.
-func newLexer(f io.Reader) *lexer {
-	return &lexer{buf: bufio.NewReader(f), unit: &Unit{}}
+// newLexer returns a lexer that parses f into a fresh unit according to
+// cfg.
+func newLexer(f io.Reader, cfg decoderConfig) *lexer {
+	if cfg.lineMax <= 0 {
+		cfg.lineMax = LineMax
+	}
+	l := &lexer{decoderConfig: cfg, unit: &Unit{}, line: 1}
+	if cfg.maxFileSize > 0 {
+		// reading one byte more than allowed is enough to notice
+		f = io.LimitReader(f, cfg.maxFileSize+1)
+	}
+	if cfg.lossless {
+		f = io.TeeReader(f, &l.raw)
+	}
+	l.buf = bufio.NewReader(f)
+	return l
+}
+
+// source returns the concrete syntax of the node whose first line begins
+// at offset lineStart and whose last line ends at offset end, and
+// attributes the input up to end to it. It returns nil unless the lexer
+// is lossless.
+func (l *lexer) source(lineStart, end int, name, value string) *source {
+	if !l.lossless {
+		return nil
+	}
+	raw := l.raw.Bytes()
+	leading, comments := splitComments(raw[l.consumed:lineStart])
+	src := &source{
+		leading:      bytes.Clone(leading),
+		comments:     bytes.Clone(comments),
+		commentLines: parseComments(comments),
+		text:         bytes.Clone(raw[lineStart:end]),
+		name:         name,
+		value:        value,
+	}
+	l.consumed = end
+	return src
+}
+
+// attachedComments returns a copy of the text of the comments attached
+// to the node, or nil for a nil source.
+func (src *source) attachedComments() []string {
+	if src == nil {
+		return nil
+	}
+	return slices.Clone(src.commentLines)
+}
+
+// finish records the input left after the last node as the unit's
+// trailing text, and every comment in it as its trailing comments, in
+// lossless mode.
+func (l *lexer) finish() {
+	if !l.lossless {
+		return
+	}
+	trailing := l.raw.Bytes()[l.consumed:]
+	l.unit.tail = &source{leading: bytes.Clone(trailing), commentLines: parseComments(trailing)}
+	l.unit.comments = slices.Clone(l.unit.tail.commentLines)
+}
+
+// pos returns the position of the next unread byte.
+func (l *lexer) pos() Position {
+	return Position{Offset: l.offset, Line: l.line, Column: l.offset - l.lineStart + 1}
+}
+
+// readRune reads a single rune, keeping track of the current position.
+// It returns io.EOF at the end of the input and a *ParseError for a rune
+// the lexer does not accept.
+func (l *lexer) readRune() (rune, int, error) {
+	start := l.pos()
+	r, size, err := l.buf.ReadRune()
+	if errors.Is(err, io.EOF) {
+		return 0, 0, io.EOF
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("reading input: %w", err)
+	}
+	l.advance(size, r == '\n')
+	l.lastSize = size
+	if err := l.checkSize(start, size); err != nil {
+		return 0, 0, err
+	}
+	if err := l.checkRune(r, size, start); err != nil {
+		return 0, 0, err
+	}
+	return r, size, nil
+}
+
+// unreadRune unreads the last rune. It must not be used to unread a
+// newline.
+func (l *lexer) unreadRune() error {
+	if err := l.buf.UnreadRune(); err != nil {
+		return err
+	}
+	l.offset -= l.lastSize
+	l.lastSize = 0
+	return nil
+}
+
+// readBytes reads until the first occurrence of delim, keeping track of
+// the current position. Like bufio.Reader.ReadBytes it returns io.EOF
+// when the input ends before delim. A *ParseError for input the lexer
+// does not accept takes precedence; the bytes are consumed anyway.
+func (l *lexer) readBytes(delim byte) ([]byte, error) {
+	pos := l.pos()
+	b, err := l.buf.ReadBytes(delim)
+	start := 0
+	for i, c := range b {
+		if c == '\n' {
+			l.advance(i+1-start, true)
+			start = i + 1
+		}
+	}
+	l.advance(len(b)-start, false)
+
+	if err := l.checkSize(pos, len(b)); err != nil {
+		return b, err
+	}
+	for i := 0; (l.strictUTF8 || l.rejectControl) && i < len(b); {
+		r, size := utf8.DecodeRune(b[i:])
+		if err := l.checkRune(r, size, pos.advance(i)); err != nil {
+			return b, err
+		}
+		i += size
+	}
+	return b, err
+}
+
+// checkSize returns a FileTooLarge error if the n bytes just read from
+// pos exceed the maximum file size.
+func (l *lexer) checkSize(pos Position, n int) error {
+	if l.maxFileSize <= 0 || int64(pos.Offset+n) <= l.maxFileSize {
+		return nil
+	}
+	// the bytes read never span a line ending but at their very end
+	return l.errorAt(pos.advance(int(l.maxFileSize)-pos.Offset), FileTooLarge, "")
+}
+
+// checkRune returns an error if the rune r of the given size, read at
+// pos, violates the encoding options of the lexer.
+func (l *lexer) checkRune(r rune, size int, pos Position) error {
+	switch {
+	case l.strictUTF8 && r == utf8.RuneError && size == 1:
+		return l.errorAt(pos, InvalidUTF8, "")
+	case l.rejectControl && unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r':
+		return l.errorAt(pos, ControlCharacter, string(r))
+	}
+	return nil
+}
+
+// advance moves the position n bytes forward; newline reports whether the
+// last of those bytes ends a line.
+func (l *lexer) advance(n int, newline bool) {
+	l.offset += n
+	if newline {
+		l.line++
+		l.lineStart = l.offset
+	}
+}
+
+// start returns the first step of the state machine.
+func (l *lexer) start() lexStep {
+	if l.stripBOM {
+		l.skipBOM()
+	}
+	return l.LexNextSection
 }
 
 // lex drives the state machine until the input is exhausted or a step fails.
 func (l *lexer) lex() error {
-	next := l.LexNextSection
+	next := l.start()
 	for next != nil {
 		var err error
 		next, err = next()
@@ -52,74 +299,123 @@ func (l *lexer) lex() error {
 	return nil
 }
 
+// skipBOM skips a UTF-8 byte order mark at the start of the input. The
+// positions of the input still count it as a byte, but not as a column.
+func (l *lexer) skipBOM() {
+	const bom = "\ufeff"
+	if b, _ := l.buf.Peek(len(bom)); string(b) != bom {
+		return
+	}
+	_, _ = l.buf.Discard(len(bom))
+	l.offset, l.lineStart = len(bom), len(bom)
+	if l.lossless {
+		l.consumed = len(bom)
+		l.unit.bom = true
+	}
+}
+
 func (l *lexer) LexNextSection() (lexStep, error) {
-	r, _, err := l.buf.ReadRune()
+	start := l.pos()
+	r, _, err := l.readRune()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
 	if err != nil {
-		if errors.Is(err, io.EOF) {
-			err = nil
-		}
-		return nil, err
+		return l.recoverLine(err, l.LexNextSection)
 	}
 
 	switch {
 	case unicode.IsSpace(r):
+		l.space(r, start)
 		return l.LexNextSection, nil
 	case r == '[':
-		return l.LexSectionName, nil
+		return l.LexSectionNameFunc(start), nil
 	case IsComment(r):
-		return l.IgnoreLineFunc(l.LexNextSection), nil
+		return l.IgnoreLineFunc(start, l.LexNextSection), nil
 	}
-	return nil, ErrAssignmentOutsideSection
-}
 
-func (l *lexer) LexSectionName() (lexStep, error) {
-	sec, err := l.buf.ReadBytes(']')
+	if err := l.unreadRune(); err != nil {
+		return nil, fmt.Errorf("unreading rune: %w", err)
+	}
+	line, _, err := l.toEOL()
 	if err != nil {
-		return nil, errors.New("unable to find end of section")
+		return l.recoverFrom(err, l.LexNextSection)
 	}
-
-	return l.LexSectionSuffixFunc(string(sec[:len(sec)-1])), nil
+	return l.recoverFrom(l.errorAt(start, AssignmentOutsideSection, string(line)), l.LexNextSection)
 }
 
-func (l *lexer) LexSectionSuffixFunc(name string) lexStep {
+func (l *lexer) LexSectionNameFunc(start Position) lexStep {
 	return func() (lexStep, error) {
-		garbage, _, err := l.toEOL()
+		line, _, err := l.toEOL()
 		if err != nil {
-			return nil, err
+			return l.recoverFrom(err, l.LexOrphanOptions())
 		}
 
-		garbage = bytes.TrimSpace(garbage)
-		if len(garbage) > 0 {
-			return nil, fmt.Errorf("found garbage after section name %s: %q", name, garbage)
+		closing := bytes.IndexByte(line, ']')
+		if closing < 0 {
+			return l.recoverFrom(l.errorAt(start, UnterminatedSectionHeader, "["+string(line)), l.LexOrphanOptions())
 		}
 
-		section := &Section{Name: name, Options: []*OptionValue{}}
+		// the header is bracket, name, bracket
+		section := &Section{Name: string(line[:closing]), Options: []*OptionValue{}, pos: start, end: start.advance(closing + 2)}
+
+		suffix := line[closing+1:]
+		if garbage := bytes.TrimSpace(suffix); len(garbage) > 0 {
+			leading := len(suffix) - len(bytes.TrimLeftFunc(suffix, unicode.IsSpace))
+			// the section name is unambiguous, so when recovering the
+			// section is kept
+			if err := l.report(l.errorAt(section.end.advance(leading), GarbageAfterSectionHeader, string(garbage))); err != nil {
+				return nil, err
+			}
+		}
+
+		l.sections++
+		if l.maxSections > 0 && l.sections > l.maxSections {
+			return nil, l.errorAt(start, TooManySections, "")
+		}
+
+		if l.stream {
+			l.emit(Token{Kind: SectionStart, Pos: section.pos, End: section.end, Section: section.Name})
+			return l.LexNextSectionOrOptionFunc(section), nil
+		}
+		section.src = l.source(lineStartOf(start), l.offset, section.Name, "")
+		section.comments = section.src.attachedComments()
 		l.unit.Sections = append(l.unit.Sections, section)
 
 		return l.LexNextSectionOrOptionFunc(section), nil
 	}
 }
 
+// LexOrphanOptions parses the assignments after an unusable section
+// header, up to the next header, into a section that is not part of the
+// unit.
+func (l *lexer) LexOrphanOptions() lexStep {
+	l.orphan = &Section{}
+	return l.LexNextSectionOrOptionFunc(l.orphan)
+}
+
 func (l *lexer) LexNextSectionOrOptionFunc(section *Section) lexStep {
 	return func() (lexStep, error) {
-		r, _, err := l.buf.ReadRune()
+		start := l.pos()
+		r, _, err := l.readRune()
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
 		if err != nil {
-			if errors.Is(err, io.EOF) {
-				err = nil
-			}
-			return nil, err
+			return l.recoverLine(err, l.LexNextSectionOrOptionFunc(section))
 		}
 
 		switch {
 		case unicode.IsSpace(r):
+			l.space(r, start)
 			return l.LexNextSectionOrOptionFunc(section), nil
 		case r == '[':
-			return l.LexSectionName, nil
+			return l.LexSectionNameFunc(start), nil
 		case IsComment(r):
-			return l.IgnoreLineFunc(l.LexNextSectionOrOptionFunc(section)), nil
+			return l.IgnoreLineFunc(start, l.LexNextSectionOrOptionFunc(section)), nil
 		}
 
-		if err := l.buf.UnreadRune(); err != nil {
+		if err := l.unreadRune(); err != nil {
 			return nil, fmt.Errorf("unreading rune: %w", err)
 		}
 		return l.LexOptionNameFunc(section), nil
@@ -128,15 +424,15 @@ func (l *lexer) LexNextSectionOrOptionFunc(section *Section) lexStep {
 
 func (l *lexer) LexOptionNameFunc(section *Section) lexStep {
 	return func() (lexStep, error) {
+		start := l.pos()
 		var partial bytes.Buffer
 		for {
-			r, _, err := l.buf.ReadRune()
-			if err != nil {
-				return nil, fmt.Errorf("reading option name: %w", err)
+			r, _, err := l.readRune()
+			if errors.Is(err, io.EOF) || r == '\n' || r == '\r' {
+				return l.recoverFrom(l.errorAt(start, MissingEquals, partial.String()), l.LexNextSectionOrOptionFunc(section))
 			}
-
-			if r == '\n' || r == '\r' {
-				return nil, errors.New("unexpected newline encountered while parsing option name")
+			if err != nil {
+				return l.recoverLine(err, l.LexNextSectionOrOptionFunc(section))
 			}
 
 			if r == '=' {
@@ -146,18 +442,35 @@ func (l *lexer) LexOptionNameFunc(section *Section) lexStep {
 			partial.WriteRune(r)
 		}
 
-		name := strings.TrimSpace(partial.String())
-		return l.LexOptionValueFunc(section, name), nil
+		l.options++
+		if l.maxOptions > 0 && l.options > l.maxOptions {
+			return nil, l.errorAt(start, TooManyOptions, "")
+		}
+
+		option := &OptionValue{Option: strings.TrimSpace(partial.String()), pos: start, end: l.pos()}
+		return l.LexOptionValueFunc(section, option), nil
 	}
 }
 
-func (l *lexer) LexOptionValueFunc(section *Section, name string) lexStep {
+func (l *lexer) LexOptionValueFunc(section *Section, option *OptionValue) lexStep {
 	return func() (lexStep, error) {
 		var partial bytes.Buffer
-		for {
+		// rawEnd is where the last line contributing to the value ends;
+		// skipped comment lines and a terminating blank line after it do
+		// not belong to the assignment
+		rawEnd := l.offset
+		// last is the last line contributing to the value, and lastEOL
+		// the position of its end
+		var last []byte
+		var lastEOL Position
+		dangling := false
+		// blank is the blank line ending a continuation, if any
+		var blank *Token
+		for first := true; ; first = false {
+			lineStart := l.pos()
 			line, eof, err := l.toEOL()
 			if err != nil {
-				return nil, err
+				return l.recoverFrom(err, l.LexNextSectionOrOptionFunc(section))
 			}
 
 			// comment lines inside a continuation are skipped entirely
@@ -169,8 +482,15 @@ func (l *lexer) LexOptionValueFunc(section *Section, name string) lexStep {
 			}
 
 			if len(bytes.TrimSpace(line)) == 0 {
+				if first {
+					option.end, rawEnd = l.eol, l.offset
+				} else if !eof {
+					blank = &Token{Kind: Blank, Pos: lineStart, End: l.eol}
+				}
 				break
 			}
+			option.end, rawEnd = l.eol, l.offset
+			last, lastEOL = line, l.eol
 
 			// a line ending in a backslash is concatenated with the next
 			// non-comment line and the backslash is replaced by a space,
@@ -180,6 +500,7 @@ func (l *lexer) LexOptionValueFunc(section *Section, name string) lexStep {
 				partial.Write(line[:len(line)-1])
 				partial.WriteRune(' ')
 				if eof {
+					dangling = true
 					break
 				}
 				continue
@@ -194,21 +515,55 @@ func (l *lexer) LexOptionValueFunc(section *Section, name string) lexStep {
 		// it would re-trigger line continuation on the next parse, so the
 		// dangling marker is dropped
 		for strings.HasSuffix(val, `\`) {
+			dangling = true
 			val = strings.TrimSpace(val[:len(val)-1])
 		}
+		if dangling && l.strictContinuations {
+			backslash := lastEOL.advance(bytes.LastIndexByte(last, '\\') - len(last))
+			if err := l.report(l.errorAt(backslash, DanglingBackslash, string(last))); err != nil {
+				return nil, err
+			}
+		}
 
-		section.Options = append(section.Options, &OptionValue{Option: name, Value: val})
+		option.Value = val
+		if l.stream {
+			if section != l.orphan {
+				l.emit(Token{Kind: Assignment, Pos: option.pos, End: option.end, Section: section.Name, Option: option.Option, Value: option.Value})
+			}
+			if blank != nil {
+				l.emit(*blank)
+			}
+			return l.LexNextSectionOrOptionFunc(section), nil
+		}
+		// the assignments of a section left out of the unit after an
+		// error leave their text to the next node
+		if section.src != nil {
+			option.src = l.source(lineStartOf(option.pos), rawEnd, option.Option, option.Value)
+			option.comments = option.src.attachedComments()
+		}
+		section.Options = append(section.Options, option)
 
 		return l.LexNextSectionOrOptionFunc(section), nil
 	}
 }
 
-func (l *lexer) IgnoreLineFunc(next lexStep) lexStep {
+// IgnoreLineFunc skips the comment starting at start, including the lines
+// it continues on.
+func (l *lexer) IgnoreLineFunc(start Position, next lexStep) lexStep {
 	return func() (lexStep, error) {
+		// raw collects the comment lines for a Comment token
+		var raw bytes.Buffer
+		if l.stream {
+			raw.WriteByte('#')
+		}
 		for {
 			line, _, err := l.toEOL()
 			if err != nil {
-				return nil, err
+				return l.recoverFrom(err, next)
+			}
+			if l.stream {
+				raw.Write(line)
+				raw.WriteByte('\n')
 			}
 
 			line = bytes.TrimSuffix(line, []byte{' '})
@@ -218,38 +573,180 @@ func (l *lexer) IgnoreLineFunc(next lexStep) lexStep {
 			}
 		}
 
+		if l.stream {
+			text := strings.Join(parseComments(raw.Bytes()), "\n")
+			l.emit(Token{Kind: Comment, Pos: start, End: l.eol, Text: text})
+		}
 		return next, nil
 	}
 }
 
+// space records the whitespace rune r read at start, and emits a Blank
+// token when it ends a line holding nothing else.
+func (l *lexer) space(r rune, start Position) {
+	// only whitespace from the start of the line makes a blank line
+	if start.Column != 1 && start.Offset != l.blank {
+		return
+	}
+	l.blank = l.offset
+	switch r {
+	case '\r':
+		l.cr = start
+	case '\n':
+		end := start
+		if l.cr.IsValid() && l.cr.Offset == start.Offset-1 {
+			end = l.cr
+		}
+		l.emit(Token{Kind: Blank, Pos: start.advance(1 - start.Column), End: end})
+	}
+}
+
+// emit queues tok for a Tokenizer.
+func (l *lexer) emit(tok Token) {
+	if l.stream {
+		l.queue = append(l.queue, queued{tok: tok})
+	}
+}
+
 func (l *lexer) toEOL() ([]byte, bool, error) {
-	line, err := l.buf.ReadBytes('\n')
+	lineStart := Position{Offset: l.lineStart, Line: l.line, Column: 1}
+	start := l.pos()
+	line, err := l.readBytes('\n')
 	// ignore EOF here since it's roughly equivalent to EOL
 	if err != nil && !errors.Is(err, io.EOF) {
+		var perr *ParseError
+		if errors.As(err, &perr) {
+			return nil, false, err
+		}
 		return nil, false, fmt.Errorf("reading line: %w", err)
 	}
 
 	line = bytes.TrimSuffix(line, []byte{'\n'})
 	line = bytes.TrimSuffix(line, []byte{'\r'})
+	l.eol = start.advance(len(line))
 
-	if len(line) > LineMax {
-		return nil, false, ErrLineTooLong
+	// the limit applies to the whole line, not just the part read here
+	if l.eol.Offset-lineStart.Offset > l.lineMax {
+		return nil, false, l.errorAt(lineStart, LineTooLong, "")
 	}
 
 	return line, errors.Is(err, io.EOF), nil
 }
 
+// report records the syntax error err and returns nil when the lexer
+// recovers from errors. Otherwise, and for errors that cannot be
+// recovered from, it returns err.
+func (l *lexer) report(err error) error {
+	var perr *ParseError
+	if !l.recover || !errors.As(err, &perr) || perr.Kind.fatal() {
+		return err
+	}
+	if l.stream {
+		l.queue = append(l.queue, queued{err: perr})
+		return nil
+	}
+	l.errs = append(l.errs, perr)
+	return nil
+}
+
+// recoverFrom continues with next after the syntax error err, which
+// ended with the offending line, when the lexer recovers from errors.
+// Otherwise it stops the lexer with err.
+func (l *lexer) recoverFrom(err error, next lexStep) (lexStep, error) {
+	if err := l.report(err); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
+
+// recoverLine is like recoverFrom for a syntax error in the middle of a
+// line: the rest of the offending line is skipped.
+func (l *lexer) recoverLine(err error, next lexStep) (lexStep, error) {
+	if err := l.report(err); err != nil {
+		return nil, err
+	}
+	if _, _, err := l.toEOL(); err != nil {
+		var perr *ParseError
+		// one error per line is enough
+		if !errors.As(err, &perr) || perr.Kind.fatal() {
+			return nil, err
+		}
+	}
+	return next, nil
+}
+
+// nameOf returns the name of f when it has one, as an *os.File does.
+func nameOf(f io.Reader) string {
+	if named, ok := f.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return ""
+}
+
+// lineStartOf returns the offset of the beginning of the line pos is on.
+func lineStartOf(pos Position) int {
+	return pos.Offset - pos.Column + 1
+}
+
+// errorAt returns a *ParseError of the given kind at pos.
+func (l *lexer) errorAt(pos Position, kind ErrorKind, text string) *ParseError {
+	e := &ParseError{Filename: l.filename, Pos: pos, Text: text, Kind: kind}
+	switch kind {
+	case LineTooLong:
+		e.Err = ErrLineTooLong
+		if l.lineMax != LineMax {
+			e.Err = &lineTooLongError{max: l.lineMax}
+		}
+	case AssignmentOutsideSection:
+		e.Err = ErrAssignmentOutsideSection
+	case FileTooLarge:
+		e.Err = ErrFileTooLarge
+	case TooManySections:
+		e.Err = ErrTooManySections
+	case TooManyOptions:
+		e.Err = ErrTooManyOptions
+	}
+	return e
+}
+
 // IsComment reports whether r marks the start of a comment line ('#' or ';').
 func IsComment(r rune) bool {
 	return r == '#' || r == ';'
 }
 
 // Deserialize parses the given systemd config into a Unit. On error it
-// returns the sections parsed so far alongside the error.
+// returns the sections parsed so far alongside the error. Syntax errors
+// are reported as a *ParseError; when f is an *os.File (or anything else
+// with a Name method) its name is recorded in the error. Use a Decoder
+// for control over the parse.
 func Deserialize(f io.Reader) (*Unit, error) {
-	l := newLexer(f)
-	if err := l.lex(); err != nil {
-		return l.unit, err
-	}
-	return l.unit, nil
+	return NewDecoder(f).Decode()
+}
+
+// DeserializeLossless parses the given systemd config like Deserialize,
+// but the returned Unit also remembers the concrete syntax of the input:
+// comments, blank lines, indentation, spacing around '=', line endings
+// and backslash continuations. WriteTo reproduces every section header
+// and assignment that has not been modified byte for byte, along with the
+// comments and blank lines before it; modified and new ones are written
+// in canonical form. Writing an unmodified unit reproduces the input.
+// It is a shorthand for a Decoder with WithLossless.
+func DeserializeLossless(f io.Reader) (*Unit, error) {
+	return NewDecoder(f, WithLossless()).Decode()
+}
+
+// DeserializeAll parses the given systemd config like Deserialize, but
+// does not stop at the first syntax error. Like systemd, it reports and
+// skips the offending line and carries on: an assignment outside of a
+// section, a line without '=' or a line that is too long is ignored,
+// and a section header with garbage after it still opens the section.
+// The assignments following an unterminated section header, up to the
+// next header, are ignored too.
+//
+// It returns the unit parsed from all valid lines and, when there were
+// syntax errors, an ErrorList holding every one of them in order of
+// appearance. Any other error, e.g. from reading f, stops parsing and is
+// returned as is. It is a shorthand for a Decoder with WithRecovery.
+func DeserializeAll(f io.Reader) (*Unit, error) {
+	return NewDecoder(f, WithRecovery()).Decode()
 }
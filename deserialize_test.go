@@ -1,9 +1,10 @@
 package systemdconfig
 
 import (
-	"bufio"
 	"errors"
 	"io"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -78,7 +79,7 @@ func TestNewLexer(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := newLexer(strings.NewReader(tt.args.s))
+			got := newLexer(strings.NewReader(tt.args.s), decoderConfig{})
 			buf := new(strings.Builder)
 
 			_, err := io.Copy(buf, got.buf)
@@ -191,13 +192,165 @@ func TestDeserialize(t *testing.T) {
 			if tt.wantErr {
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
+			if !reflect.DeepEqual(withoutPositions(got), tt.want) {
 				t.Errorf("Deserialize() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+// withoutPositions clears the source positions the parser records on u so
+// that it can be compared against a unit built in code.
+func withoutPositions(u *Unit) *Unit {
+	for _, s := range u.Sections {
+		s.pos, s.end = Position{}, Position{}
+		for _, o := range s.Options {
+			o.pos, o.end = Position{}, Position{}
+		}
+	}
+	return u
+}
+
+func TestDeserializePositions(t *testing.T) {
+	const in = "# comment\n[Unit]\nDescription=Test\n\n[Service]\n  ExecStart=/bin/foo \\\n# interleaved\n  --bar\nEmpty=\n[Install]"
+	unit, err := Deserialize(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type node interface {
+		Pos() Position
+		End() Position
+	}
+	tests := []struct {
+		name     string
+		node     node
+		pos, end string
+		text     string
+	}{
+		{"SectionHeader", unit.Sections[0], "2:1", "2:7", "[Unit]"},
+		{"Assignment", unit.Sections[0].Options[0], "3:1", "3:17", "Description=Test"},
+		{"IndentedContinuedAssignment", unit.Sections[1].Options[0], "6:3", "8:8", "ExecStart=/bin/foo \\\n# interleaved\n  --bar"},
+		{"EmptyAssignment", unit.Sections[1].Options[1], "9:1", "9:7", "Empty="},
+		{"HeaderAtEOF", unit.Sections[2], "10:1", "10:10", "[Install]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pos, end := tt.node.Pos(), tt.node.End()
+			if pos.String() != tt.pos || end.String() != tt.end {
+				t.Errorf("Pos(), End() = %v, %v, want %v, %v", pos, end, tt.pos, tt.end)
+			}
+			if got := in[pos.Offset:end.Offset]; got != tt.text {
+				t.Errorf("text between Pos() and End() = %q, want %q", got, tt.text)
+			}
+		})
+	}
+}
+
+func TestDeserializeAll(t *testing.T) {
+	const in = `Orphan=before any section
+[Unit]
+Description=Test
+Desc ription
+[Service] junk
+ExecStart=/bin/foo
+[Broken
+Ignored=yes
+[Install]
+WantedBy=multi-user.target
+NoEquals`
+	unit, err := DeserializeAll(strings.NewReader(in))
+
+	want := &Unit{Sections: []*Section{
+		{Name: "Unit", Options: []*OptionValue{{Option: "Description", Value: "Test"}}},
+		{Name: "Service", Options: []*OptionValue{{Option: "ExecStart", Value: "/bin/foo"}}},
+		{Name: "Install", Options: []*OptionValue{{Option: "WantedBy", Value: "multi-user.target"}}},
+	}}
+	if !reflect.DeepEqual(withoutPositions(unit), want) {
+		t.Errorf("DeserializeAll() = %v, want %v", unit, want)
+	}
+
+	var errs ErrorList
+	if !errors.As(err, &errs) {
+		t.Fatalf("DeserializeAll() error = %v, want an ErrorList", err)
+	}
+	wantErrs := []struct {
+		kind ErrorKind
+		pos  string
+	}{
+		{AssignmentOutsideSection, "1:1"},
+		{MissingEquals, "4:1"},
+		{GarbageAfterSectionHeader, "5:11"},
+		{UnterminatedSectionHeader, "7:1"},
+		{MissingEquals, "11:1"},
+	}
+	if len(errs) != len(wantErrs) {
+		t.Fatalf("DeserializeAll() returned %d errors, want %d: %v", len(errs), len(wantErrs), errs.Unwrap())
+	}
+	for i, want := range wantErrs {
+		if errs[i].Kind != want.kind || errs[i].Pos.String() != want.pos {
+			t.Errorf("error %d = %v, want %v at %v", i, errs[i], want.kind, want.pos)
+		}
+	}
+	if !errors.Is(err, ErrAssignmentOutsideSection) {
+		t.Errorf("errors.Is(%v, ErrAssignmentOutsideSection) = false, want true", err)
+	}
+}
+
+func TestDeserializeAllLineTooLong(t *testing.T) {
+	in := "[Unit]\nDescription=" + strings.Repeat("x", LineMax+1) + "\nAfter=network.target\n# " + strings.Repeat("x", LineMax) + "\n"
+	unit, err := DeserializeAll(strings.NewReader(in))
+	if got, want := len(unit.Sections[0].Options), 1; got != want {
+		t.Errorf("DeserializeAll() parsed %d options, want %d", got, want)
+	}
+	var errs ErrorList
+	if !errors.As(err, &errs) || len(errs) != 2 || !errors.Is(err, ErrLineTooLong) {
+		t.Errorf("DeserializeAll() error = %v, want two ErrLineTooLong", err)
+	}
+}
+
+func TestDeserializeAllValid(t *testing.T) {
+	unit, err := DeserializeAll(strings.NewReader("[Unit]\nDescription=Test\n"))
+	if err != nil {
+		t.Fatalf("DeserializeAll() error = %v", err)
+	}
+	if v, _ := unit.Value("Unit", "Description"); v != "Test" {
+		t.Errorf("Value(Description) = %q, want %q", v, "Test")
+	}
+}
+
+func TestDeserializeLossless(t *testing.T) {
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "*.service"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fixture := range fixtures {
+		t.Run(filepath.Base(fixture), func(t *testing.T) {
+			src, err := os.ReadFile(fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := Deserialize(strings.NewReader(string(src)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := DeserializeLossless(strings.NewReader(string(src)))
+			if err != nil {
+				t.Fatalf("DeserializeLossless() error = %v", err)
+			}
+			if !got.Match(want) {
+				t.Errorf("DeserializeLossless() = %v, want %v", got, want)
+			}
+		})
+	}
+
+	_, err = DeserializeLossless(strings.NewReader("[Unit] junk\n"))
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Kind != GarbageAfterSectionHeader {
+		t.Errorf("DeserializeLossless() error = %v, want GarbageAfterSectionHeader", err)
+	}
+}
+
 func TestDeserializeAssignmentOutsideSection(t *testing.T) {
 	_, err := Deserialize(strings.NewReader("Option=value\n"))
 	if !errors.Is(err, ErrAssignmentOutsideSection) {
@@ -213,6 +366,52 @@ func TestDeserializeLineTooLong(t *testing.T) {
 	}
 }
 
+func TestDeserializeParseError(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		kind ErrorKind
+		pos  string
+		text string
+	}{
+		{"UnterminatedSectionHeader", "[Unit]\nA=B\n[Service\nC=D]\n", UnterminatedSectionHeader, "3:1", "[Service"},
+		{"GarbageAfterSectionHeader", "[Unit]  junk \nA=B\n", GarbageAfterSectionHeader, "1:9", "junk"},
+		{"MissingEquals", "[Unit]\n  Desc\nription=Test\n", MissingEquals, "2:3", "Desc"},
+		{"MissingEqualsAtEOF", "[Unit]\nDescription", MissingEquals, "2:1", "Description"},
+		{"LineTooLong", "[Unit]\nDescription=" + strings.Repeat("x", LineMax+1) + "\n", LineTooLong, "2:1", ""},
+		{"AssignmentOutsideSection", "# comment\n Option=value\n[Unit]\n", AssignmentOutsideSection, "2:2", "Option=value"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Deserialize(strings.NewReader(tt.in))
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("Deserialize() error = %v, want a *ParseError", err)
+			}
+			if perr.Kind != tt.kind || perr.Pos.String() != tt.pos || perr.Text != tt.text {
+				t.Errorf("Deserialize() error = {Kind: %v, Pos: %v, Text: %q}, want {Kind: %v, Pos: %v, Text: %q}",
+					perr.Kind, perr.Pos, perr.Text, tt.kind, tt.pos, tt.text)
+			}
+		})
+	}
+}
+
+func TestDeserializeParseErrorFilename(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "broken.service")
+	if err := os.WriteFile(path, []byte("[Unit]\n[Service] junk\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	_, err = Deserialize(f)
+	if want := path + `:2:11: garbage after section header: "junk"`; err == nil || err.Error() != want {
+		t.Errorf("Deserialize() error = %v, want %v", err, want)
+	}
+}
+
 func Test_lexer_toEOL(t *testing.T) {
 	type fields struct {
 		s string
@@ -264,9 +463,7 @@ func Test_lexer_toEOL(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			l := &lexer{
-				buf: bufio.NewReader(strings.NewReader(tt.fields.s)),
-			}
+			l := newLexer(strings.NewReader(tt.fields.s), decoderConfig{})
 			got, got1, err := l.toEOL()
 			if (err != nil) != tt.wantErr {
 				t.Errorf("lexer.toEOL() error = %v, wantErr %v", err, tt.wantErr)
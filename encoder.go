@@ -0,0 +1,261 @@
+package systemdconfig
+
+import (
+	"io"
+	"strings"
+)
+
+// encoderConfig holds the output options of an Encoder.
+type encoderConfig struct {
+	// width is the line length values are wrapped at; 0 wraps only
+	// values that would exceed LineMax.
+	width int
+	// escape makes values that cannot be written verbatim be written with
+	// C escapes instead of being rejected.
+	escape bool
+
+	// the output style
+	crlf           bool
+	sectionSpacing int
+	spaced         bool
+	align          bool
+	finalNewline   newlinePolicy
+	header         []string
+}
+
+// newlinePolicy says how the output of an Encoder ends.
+type newlinePolicy int
+
+const (
+	// keepFinalNewline ends canonical output with a line ending, and
+	// lossless output as it was read.
+	keepFinalNewline newlinePolicy = iota
+	addFinalNewline
+	stripFinalNewline
+)
+
+// newEncoderConfig returns the configuration with the given options
+// applied.
+func newEncoderConfig(opts []EncoderOption) encoderConfig {
+	cfg := encoderConfig{sectionSpacing: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// newline returns the line ending to write.
+func (c encoderConfig) newline() string {
+	if c.crlf {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// separator returns the text to write between an option name and its
+// value.
+func (c encoderConfig) separator() string {
+	if c.spaced {
+		return " = "
+	}
+	return "="
+}
+
+// keyWidth returns the width to pad the names of the options of section
+// written in canonical form to, so that their values line up.
+func (c encoderConfig) keyWidth(section *Section) int {
+	width := 0
+	if !c.align {
+		return width
+	}
+	for _, option := range section.Options {
+		if option.src == nil || option.modified() {
+			width = max(width, len(option.Option))
+		}
+	}
+	return width
+}
+
+// An Encoder writes units to an output stream. Its options control the
+// style of the output and how values are encoded, e.g. to match the
+// conventions of an upstream file without diff noise.
+type Encoder struct {
+	w io.Writer
+	encoderConfig
+}
+
+// EncoderOption configures an Encoder.
+type EncoderOption func(*encoderConfig)
+
+// NewEncoder returns a new encoder that writes to w. Without options it
+// writes like Unit.WriteTo.
+func NewEncoder(w io.Writer, opts ...EncoderOption) *Encoder {
+	return &Encoder{w: w, encoderConfig: newEncoderConfig(opts)}
+}
+
+// Encode writes u to the output stream, as Unit.WriteTo does. It writes
+// nothing and returns a *ValidationError when a section, option or
+// comment of u cannot be written so that it parses back unchanged.
+func (e *Encoder) Encode(u *Unit) error {
+	_, err := u.writeTo(e.w, e.encoderConfig)
+	return err
+}
+
+// WithLineWidth wraps values written in canonical form so that their
+// lines are at most n bytes long, using backslash continuations. A value
+// can only be wrapped at a space, which the continuation replaces, so a
+// word longer than the line stays on a line of its own. Values longer
+// than LineMax are wrapped even without this option.
+func WithLineWidth(n int) EncoderOption {
+	return func(c *encoderConfig) {
+		c.width = n
+	}
+}
+
+// WithValueEscaping writes values that cannot be written verbatim, those
+// containing a newline, starting or ending with whitespace or ending in a
+// backslash, using the C escapes of systemd.syntax(7) instead of
+// rejecting them: the value parses back in its escaped form, which
+// settings that unescape their value, such as Environment=, decode to the
+// original. Other values are written verbatim.
+func WithValueEscaping() EncoderOption {
+	return func(c *encoderConfig) {
+		c.escape = true
+	}
+}
+
+// WithCRLF ends the lines written in canonical form with "\r\n" instead
+// of "\n". Lines parsed by DeserializeLossless and not modified keep
+// their line endings.
+func WithCRLF() EncoderOption {
+	return func(c *encoderConfig) {
+		c.crlf = true
+	}
+}
+
+// WithSectionSpacing sets the number of blank lines written before a
+// section header in canonical form that is not the first, 1 by default.
+func WithSectionSpacing(n int) EncoderOption {
+	return func(c *encoderConfig) {
+		c.sectionSpacing = max(n, 0)
+	}
+}
+
+// WithSpacedAssignments writes assignments in canonical form as
+// "Key = Value" instead of "Key=Value".
+func WithSpacedAssignments() EncoderOption {
+	return func(c *encoderConfig) {
+		c.spaced = true
+	}
+}
+
+// WithAlignedEquals pads the names of the options of a section written in
+// canonical form with spaces, so that their '=' line up.
+func WithAlignedEquals() EncoderOption {
+	return func(c *encoderConfig) {
+		c.align = true
+	}
+}
+
+// WithFinalNewline sets whether the output ends with a line ending. By
+// default canonical output does, and the output of a unit parsed by
+// DeserializeLossless ends as its input did.
+func WithFinalNewline(on bool) EncoderOption {
+	return func(c *encoderConfig) {
+		c.finalNewline = stripFinalNewline
+		if on {
+			c.finalNewline = addFinalNewline
+		}
+	}
+}
+
+// WithHeaderComment writes the given comments as '#' comment lines at the
+// top of the output, followed by a blank line, e.g. a "generated file, do
+// not edit" notice. A comment containing newlines is written as several
+// lines.
+func WithHeaderComment(comments ...string) EncoderOption {
+	return func(c *encoderConfig) {
+		c.header = splitLines(comments)
+	}
+}
+
+// encodeValue returns the text to write after the '=' of an assignment
+// whose line starts with prefix bytes, so that it parses back to value:
+// the value, escaped if needed and allowed, and wrapped with backslash
+// continuations. When that is not possible, it returns a reason instead.
+func encodeValue(prefix int, value string, cfg encoderConfig) (string, string) {
+	if reason := rawValueProblem(value); reason != "" {
+		if !cfg.escape {
+			return "", reason
+		}
+		value = EscapeValue(value)
+	}
+
+	limit := LineMax
+	if cfg.width > 0 && cfg.width < limit {
+		limit = cfg.width
+	}
+	if prefix+len(value) <= limit {
+		return value, ""
+	}
+
+	var b strings.Builder
+	start, lineLen := 0, prefix
+	for lineLen+len(value)-start > limit {
+		end := wrapPoint(value, start, limit-lineLen-len(`\`))
+		if end < 0 {
+			break
+		}
+		if lineLen+end-start+len(`\`) > LineMax {
+			return "", "too long"
+		}
+		b.WriteString(value[start:end])
+		b.WriteByte('\\')
+		b.WriteString(cfg.newline())
+		start, lineLen = end+1, 0
+	}
+	if lineLen+len(value)-start > LineMax {
+		return "", "too long"
+	}
+	b.WriteString(value[start:])
+	return b.String(), ""
+}
+
+// wrapPoint returns the index of the space to replace with a continuation
+// in value[start:], preferably the last one leaving at most n bytes on
+// the line, or else the first one; it returns -1 when there is none. The
+// continuation line must not look like a comment once its leading
+// whitespace is stripped.
+func wrapPoint(value string, start, n int) int {
+	point := -1
+	for i := start + 1; i < len(value)-1; i++ {
+		if value[i] != ' ' {
+			continue
+		}
+		if next := strings.TrimLeft(value[i+1:], whitespace); next == "" || IsComment(rune(next[0])) {
+			continue
+		}
+		if i-start > n && point >= 0 {
+			break
+		}
+		point = i
+		if i-start > n {
+			break
+		}
+	}
+	return point
+}
+
+// rawValueProblem describes why value cannot be written verbatim after
+// the '=' of an assignment, or returns an empty string.
+func rawValueProblem(value string) string {
+	switch {
+	case strings.ContainsRune(value, '\n'):
+		return "contains a newline"
+	case strings.TrimSpace(value) != value:
+		return "starts or ends with whitespace"
+	case strings.HasSuffix(value, `\`):
+		return "ends in a backslash"
+	}
+	return ""
+}
@@ -0,0 +1,268 @@
+package systemdconfig
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEncoder_LineWidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		width int
+		want  string
+	}{
+		{
+			name:  "Fits",
+			value: "/usr/bin/app --flag",
+			width: 40,
+			want:  "ExecStart=/usr/bin/app --flag\n",
+		},
+		{
+			name:  "Wrapped",
+			value: "/usr/bin/app --first --second --third",
+			width: 24,
+			want:  "ExecStart=/usr/bin/app\\\n--first --second --third\n",
+		},
+		{
+			name:  "WrappedTwice",
+			value: "aaaa bbbb cccc dddd eeee",
+			width: 15,
+			want:  "ExecStart=aaaa\\\nbbbb cccc dddd\\\neeee\n",
+		},
+		{
+			name:  "LongWordOnItsOwnLine",
+			value: "a " + strings.Repeat("x", 20) + " b",
+			width: 12,
+			want:  "ExecStart=a\\\n" + strings.Repeat("x", 20) + "\\\nb\n",
+		},
+		{
+			name:  "NoSpace",
+			value: strings.Repeat("x", 20),
+			width: 12,
+			want:  "ExecStart=" + strings.Repeat("x", 20) + "\n",
+		},
+		{
+			name:  "NotBeforeComment",
+			value: "aaaa #bbb ;ccc dddd",
+			width: 16,
+			want:  "ExecStart=aaaa #bbb ;ccc\\\ndddd\n",
+		},
+		{
+			name:  "NotBeforeSpacesAndComment",
+			value: "aaaa  #bbb cccc",
+			width: 16,
+			want:  "ExecStart=aaaa  #bbb\\\ncccc\n",
+		},
+		{
+			name:  "OnlyBeforeSpacesAndComment",
+			value: "aaa  #b",
+			width: 8,
+			want:  "ExecStart=aaa  #b\n",
+		},
+		{
+			name:  "RepeatedSpaces",
+			value: "aaaa  bbbb",
+			width: 16,
+			want:  "ExecStart=aaaa \\\nbbbb\n",
+		},
+		{
+			name:  "Backslash",
+			value: `C:\dir\ x`,
+			width: 17,
+			want:  "ExecStart=C:\\dir\\\\\nx\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit := NewUnit()
+			unit.AddSection("Service").AddOption("ExecStart", tt.value)
+
+			var buf bytes.Buffer
+			if err := NewEncoder(&buf, WithLineWidth(tt.width)).Encode(unit); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if got := strings.TrimPrefix(buf.String(), "[Service]\n"); got != tt.want {
+				t.Errorf("Encode() = %q, want %q", got, tt.want)
+			}
+
+			reparsed, err := Deserialize(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, _ := reparsed.Value("Service", "ExecStart"); got != tt.value {
+				t.Errorf("reparsed value = %q, want %q", got, tt.value)
+			}
+		})
+	}
+}
+
+func TestEncoder_LineMax(t *testing.T) {
+	word := strings.Repeat("x", LineMax/2)
+	unit := NewUnit()
+	unit.AddSection("Service").AddOption("ExecStart", word+" "+word+" "+word)
+
+	var buf bytes.Buffer
+	if _, err := unit.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	if got := strings.Count(buf.String(), "\\\n"); got != 2 {
+		t.Errorf("WriteTo() wrote %d continuations, want 2", got)
+	}
+	reparsed, err := Deserialize(&buf)
+	if err != nil || !reparsed.Match(unit) {
+		t.Errorf("reparsed = %v, %v", reparsed, err)
+	}
+}
+
+func TestEncoder_ValueEscaping(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"plain \\ value", "plain \\ value"},
+		{"line one\nline two", `line one\nline two`},
+		{" padded\t", `\spadded\t`},
+		{"\u00a0nbsp", `\u00a0nbsp`},
+		{`C:\dir\`, `C:\\dir\x5c`},
+		{"say \"hi\"\x1b\n", `say \"hi\"\x1b\n`},
+		{"caf\xe9 \n", "caf\xe9\\s\\n"},
+	}
+	for _, tt := range tests {
+		unit := NewUnit()
+		unit.AddSection("Service").AddOption("Environment", tt.value)
+
+		var buf bytes.Buffer
+		if err := NewEncoder(&buf, WithValueEscaping()).Encode(unit); err != nil {
+			t.Fatalf("Encode(%q) error = %v", tt.value, err)
+		}
+		reparsed, err := Deserialize(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := reparsed.Value("Service", "Environment"); got != tt.want {
+			t.Errorf("Encode(%q) wrote %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestEncoder_Rejects(t *testing.T) {
+	unit := NewUnit()
+	unit.AddSection("Service").AddOption("ExecStart", "a\nb")
+
+	var buf bytes.Buffer
+	err := NewEncoder(&buf, WithLineWidth(10)).Encode(unit)
+	if !errors.Is(err, ErrInvalidValue) || buf.Len() != 0 {
+		t.Errorf("Encode() error = %v, wrote %q, want ErrInvalidValue and nothing", err, buf.String())
+	}
+}
+
+func TestEncoder_Style(t *testing.T) {
+	build := func() *Unit {
+		unit := NewUnit()
+		service := unit.AddSection("Service")
+		service.SetComments("the daemon")
+		service.AddOption("Type", "notify")
+		service.AddOption("ExecStart", "/usr/bin/app")
+		unit.AddSection("Install").AddOption("WantedBy", "multi-user.target")
+		return unit
+	}
+	tests := []struct {
+		name string
+		opts []EncoderOption
+		want string
+	}{
+		{
+			name: "Default",
+			want: "# the daemon\n[Service]\nType=notify\nExecStart=/usr/bin/app\n\n[Install]\nWantedBy=multi-user.target\n",
+		},
+		{
+			name: "CRLF",
+			opts: []EncoderOption{WithCRLF()},
+			want: "# the daemon\r\n[Service]\r\nType=notify\r\nExecStart=/usr/bin/app\r\n\r\n[Install]\r\nWantedBy=multi-user.target\r\n",
+		},
+		{
+			name: "NoSectionSpacing",
+			opts: []EncoderOption{WithSectionSpacing(0)},
+			want: "# the daemon\n[Service]\nType=notify\nExecStart=/usr/bin/app\n[Install]\nWantedBy=multi-user.target\n",
+		},
+		{
+			name: "SectionSpacing",
+			opts: []EncoderOption{WithSectionSpacing(2)},
+			want: "# the daemon\n[Service]\nType=notify\nExecStart=/usr/bin/app\n\n\n[Install]\nWantedBy=multi-user.target\n",
+		},
+		{
+			name: "SpacedAssignments",
+			opts: []EncoderOption{WithSpacedAssignments()},
+			want: "# the daemon\n[Service]\nType = notify\nExecStart = /usr/bin/app\n\n[Install]\nWantedBy = multi-user.target\n",
+		},
+		{
+			name: "AlignedEquals",
+			opts: []EncoderOption{WithAlignedEquals(), WithSpacedAssignments()},
+			want: "# the daemon\n[Service]\nType      = notify\nExecStart = /usr/bin/app\n\n[Install]\nWantedBy = multi-user.target\n",
+		},
+		{
+			name: "NoFinalNewline",
+			opts: []EncoderOption{WithFinalNewline(false), WithCRLF()},
+			want: "# the daemon\r\n[Service]\r\nType=notify\r\nExecStart=/usr/bin/app\r\n\r\n[Install]\r\nWantedBy=multi-user.target",
+		},
+		{
+			name: "HeaderComment",
+			opts: []EncoderOption{WithHeaderComment("Generated by deployd.\nDo not edit.")},
+			want: "# Generated by deployd.\n# Do not edit.\n\n# the daemon\n[Service]\nType=notify\nExecStart=/usr/bin/app\n\n[Install]\nWantedBy=multi-user.target\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit := build()
+			var buf bytes.Buffer
+			if err := NewEncoder(&buf, tt.opts...).Encode(unit); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("Encode() wrote:\n%q\nwant:\n%q", buf.String(), tt.want)
+			}
+			reparsed, err := DeserializeLossless(&buf)
+			if err != nil || !reparsed.Match(unit) {
+				t.Errorf("reparsed = %v, %v, want %v", reparsed, err, unit)
+			}
+		})
+	}
+}
+
+func TestEncoder_StyleLossless(t *testing.T) {
+	in := "[Service]\r\nType=simple\r\nExecStart=/usr/bin/app"
+	unit, err := DeserializeLossless(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := unit.Sections[0]
+	service.Options[0].Value = "notify"
+	service.AddOption("Restart", "always")
+
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf, WithAlignedEquals(), WithFinalNewline(true), WithCRLF()).Encode(unit); err != nil {
+		t.Fatal(err)
+	}
+	want := "[Service]\r\nType   =notify\r\nExecStart=/usr/bin/app\r\nRestart=always\r\n"
+	if buf.String() != want {
+		t.Errorf("Encode() wrote %q, want %q", buf.String(), want)
+	}
+}
+
+func TestEncoder_HeaderComment(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf, WithHeaderComment("generated")).Encode(NewUnit()); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "# generated\n" {
+		t.Errorf("Encode() of empty unit wrote %q", buf.String())
+	}
+
+	err := NewEncoder(&buf, WithHeaderComment("bad\r")).Encode(NewUnit())
+	if !errors.Is(err, ErrInvalidComment) {
+		t.Errorf("Encode() error = %v, want ErrInvalidComment", err)
+	}
+}
@@ -0,0 +1,173 @@
+package systemdconfig
+
+import "fmt"
+
+// ErrorKind classifies a ParseError.
+type ErrorKind int
+
+const (
+	// UnterminatedSectionHeader is a line starting with '[' that has no
+	// closing ']'.
+	UnterminatedSectionHeader ErrorKind = iota + 1
+	// GarbageAfterSectionHeader is non-whitespace text following the
+	// closing ']' of a section header.
+	GarbageAfterSectionHeader
+	// MissingEquals is a line inside a section that is neither a comment
+	// nor a section header and has no '='.
+	MissingEquals
+	// LineTooLong is a line longer than LineMax bytes.
+	LineTooLong
+	// AssignmentOutsideSection is an assignment (or any other non-comment
+	// text) before the first section header.
+	AssignmentOutsideSection
+	// InvalidUTF8 is a byte sequence that is not valid UTF-8, reported
+	// with WithStrictUTF8.
+	InvalidUTF8
+	// ControlCharacter is a control character other than tab, carriage
+	// return and line feed, reported with WithRejectControlChars.
+	ControlCharacter
+	// DanglingBackslash is a value ending in a backslash with nothing to
+	// continue with, reported with WithStrictContinuations.
+	DanglingBackslash
+	// FileTooLarge is input beyond the size set with WithMaxFileSize.
+	FileTooLarge
+	// TooManySections is a section beyond the limit set with
+	// WithMaxSections.
+	TooManySections
+	// TooManyOptions is an assignment beyond the limit set with
+	// WithMaxOptions.
+	TooManyOptions
+)
+
+var errorKindNames = map[ErrorKind]string{
+	UnterminatedSectionHeader: "unterminated section header",
+	GarbageAfterSectionHeader: "garbage after section header",
+	MissingEquals:             "missing '='",
+	LineTooLong:               "line too long",
+	AssignmentOutsideSection:  "assignment outside of section",
+	InvalidUTF8:               "invalid UTF-8",
+	ControlCharacter:          "control character",
+	DanglingBackslash:         "dangling backslash",
+	FileTooLarge:              "file too large",
+	TooManySections:           "too many sections",
+	TooManyOptions:            "too many options",
+}
+
+func (k ErrorKind) String() string {
+	if name, ok := errorKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("ErrorKind(%d)", int(k))
+}
+
+// fatal reports whether parsing cannot recover from errors of kind k:
+// limits guard against hostile input and stop the parse.
+func (k ErrorKind) fatal() bool {
+	return k == FileTooLarge || k == TooManySections || k == TooManyOptions
+}
+
+// ParseError describes a syntax error in a unit file, and where it is.
+type ParseError struct {
+	// Filename is the name of the parsed file, or empty when it is not
+	// known (Deserialize only knows it when reading from an *os.File).
+	Filename string
+	// Pos is the position of Text in the input.
+	Pos Position
+	// Text is the offending input, e.g. the garbage after a section
+	// header. It is empty for LineTooLong, InvalidUTF8 and the limits.
+	Text string
+	Kind ErrorKind
+	// Err is the sentinel error behind the kind, if any: ErrLineTooLong,
+	// ErrAssignmentOutsideSection, ErrFileTooLarge, ErrTooManySections or
+	// ErrTooManyOptions.
+	Err error
+}
+
+// Error returns the error in the "file:line:column: message" form.
+func (e *ParseError) Error() string {
+	msg := e.Kind.String()
+	if e.Err != nil {
+		msg = e.Err.Error()
+	}
+	if e.Text != "" {
+		msg = fmt.Sprintf("%s: %q", msg, e.Text)
+	}
+	if e.Filename != "" {
+		return fmt.Sprintf("%s:%s: %s", e.Filename, e.Pos, msg)
+	}
+	return fmt.Sprintf("%s: %s", e.Pos, msg)
+}
+
+// Unwrap returns e.Err, so that e.g. errors.Is(err, ErrLineTooLong) and
+// errors.Is(err, ErrAssignmentOutsideSection) keep working.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorList is a list of syntax errors, as returned by DeserializeAll.
+type ErrorList []*ParseError
+
+// Error returns the first error, followed by the number of further
+// errors, if any.
+func (l ErrorList) Error() string {
+	switch len(l) {
+	case 0:
+		return "no errors"
+	case 1:
+		return l[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", l[0], len(l)-1)
+}
+
+// Unwrap returns the errors in l, so that errors.Is and errors.As look at
+// every one of them.
+func (l ErrorList) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i, e := range l {
+		errs[i] = e
+	}
+	return errs
+}
+
+// ValidationError describes a section, option or comment that does not
+// follow systemd's grammar or cannot be written so that it parses back
+// unchanged, as reported by Validate and WriteTo.
+type ValidationError struct {
+	// Pos is the position of the section header or assignment, or an
+	// invalid Position when it was not produced by the parser.
+	Pos Position
+	// Section is the name of the section, and Option the name of the
+	// option, if the error is about one; both are empty for the trailing
+	// comments of a unit.
+	Section, Option string
+	// Reason explains what is wrong, e.g. "contains ']'".
+	Reason string
+	// Err is ErrInvalidSectionName, ErrInvalidOptionName, ErrInvalidValue
+	// or ErrInvalidComment.
+	Err error
+}
+
+// Error returns the error in the "line:column: message" form, without the
+// position when it is not known.
+func (e *ValidationError) Error() string {
+	msg := e.Err.Error()
+	switch {
+	case e.Option != "" && e.Section != "":
+		msg = fmt.Sprintf("%s: option %q in section %q", msg, e.Option, e.Section)
+	case e.Option != "":
+		msg = fmt.Sprintf("%s: option %q", msg, e.Option)
+	case e.Section != "":
+		msg = fmt.Sprintf("%s: section %q", msg, e.Section)
+	}
+	msg += ": " + e.Reason
+	if e.Pos.IsValid() {
+		return fmt.Sprintf("%s: %s", e.Pos, msg)
+	}
+	return msg
+}
+
+// Unwrap returns e.Err, so that e.g. errors.Is(err, ErrInvalidValue)
+// works.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
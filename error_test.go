@@ -0,0 +1,107 @@
+package systemdconfig
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrorKind_String(t *testing.T) {
+	tests := []struct {
+		kind ErrorKind
+		want string
+	}{
+		{UnterminatedSectionHeader, "unterminated section header"},
+		{GarbageAfterSectionHeader, "garbage after section header"},
+		{MissingEquals, "missing '='"},
+		{LineTooLong, "line too long"},
+		{AssignmentOutsideSection, "assignment outside of section"},
+		{ErrorKind(0), "ErrorKind(0)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.kind.String(); got != tt.want {
+				t.Errorf("ErrorKind.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseError_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *ParseError
+		want string
+	}{
+		{
+			name: "WithFilename",
+			err: &ParseError{
+				Filename: "docker.service",
+				Pos:      Position{Offset: 200, Line: 14, Column: 8},
+				Text:     "junk",
+				Kind:     GarbageAfterSectionHeader,
+			},
+			want: `docker.service:14:8: garbage after section header: "junk"`,
+		},
+		{
+			name: "WithoutFilename",
+			err: &ParseError{
+				Pos:  Position{Offset: 0, Line: 1, Column: 1},
+				Text: "[Unit",
+				Kind: UnterminatedSectionHeader,
+			},
+			want: `1:1: unterminated section header: "[Unit"`,
+		},
+		{
+			name: "SentinelMessage",
+			err: &ParseError{
+				Pos:  Position{Offset: 0, Line: 1, Column: 1},
+				Text: "A=B",
+				Kind: AssignmentOutsideSection,
+				Err:  ErrAssignmentOutsideSection,
+			},
+			want: `1:1: assignment outside of section: "A=B"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("ParseError.Error() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseError_Unwrap(t *testing.T) {
+	err := error(&ParseError{Kind: LineTooLong, Err: ErrLineTooLong})
+	if !errors.Is(err, ErrLineTooLong) {
+		t.Errorf("errors.Is(%v, ErrLineTooLong) = false, want true", err)
+	}
+	if errors.Is(err, ErrAssignmentOutsideSection) {
+		t.Errorf("errors.Is(%v, ErrAssignmentOutsideSection) = true, want false", err)
+	}
+}
+
+func TestErrorList_Error(t *testing.T) {
+	first := &ParseError{Pos: Position{Line: 1, Column: 1}, Text: "[Unit", Kind: UnterminatedSectionHeader}
+	second := &ParseError{Pos: Position{Line: 3, Column: 1}, Kind: LineTooLong, Err: ErrLineTooLong}
+	tests := []struct {
+		name string
+		list ErrorList
+		want string
+	}{
+		{"Empty", nil, "no errors"},
+		{"One", ErrorList{first}, `1:1: unterminated section header: "[Unit"`},
+		{"Several", ErrorList{first, second, second}, `1:1: unterminated section header: "[Unit" (and 2 more errors)`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.list.Error(); got != tt.want {
+				t.Errorf("ErrorList.Error() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if err := error(ErrorList{first, second}); !errors.Is(err, ErrLineTooLong) {
+		t.Errorf("errors.Is(%v, ErrLineTooLong) = false, want true", err)
+	}
+}
@@ -1,10 +1,13 @@
 package systemdconfig_test
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strings"
+	"testing/fstest"
 
 	systemdconfig "github.com/javadh75/systemd-config"
 )
@@ -87,6 +90,58 @@ ExecStart=/usr/sbin/nginx -c /etc/nginx/custom.conf
 	// [always]
 }
 
+func ExampleLoader_Files() {
+	// The file system of an image, e.g. a chroot with WithRoot, or an
+	// unpacked OCI layer with WithFS.
+	image := fstest.MapFS{
+		"usr/lib/systemd/system/nginx.service": {Data: []byte(`[Service]
+ExecStart=/usr/sbin/nginx
+`)},
+		"etc/systemd/system/nginx.service.d/override.conf": {Data: []byte(`[Service]
+ExecStart=
+ExecStart=/usr/sbin/nginx -c /etc/nginx/custom.conf
+`)},
+	}
+	l := systemdconfig.NewLoader(systemdconfig.ScopeSystem, systemdconfig.WithFS(image))
+
+	files, err := l.Files("nginx.service")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := files.WriteTo(os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(files.Merge().Values("Service", "ExecStart"))
+	// Output:
+	// # /usr/lib/systemd/system/nginx.service
+	// [Service]
+	// ExecStart=/usr/sbin/nginx
+	//
+	// # /etc/systemd/system/nginx.service.d/override.conf
+	// [Service]
+	// ExecStart=
+	// ExecStart=/usr/sbin/nginx -c /etc/nginx/custom.conf
+	// [/usr/sbin/nginx -c /etc/nginx/custom.conf]
+}
+
+func ExampleExpandSpecifiers() {
+	name, err := systemdconfig.ParseUnitName("serial-getty@ttyS0.service")
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx := &systemdconfig.SpecifierContext{Hostname: "web1.example.com"}
+
+	v, err := systemdconfig.ExpandSpecifiers("-/sbin/agetty -o '-p -- \\u' --keep-baud 115200,57600,38400,9600 - %I on %l", name, ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(v)
+	fmt.Println(name.Template(), name.Instance)
+	// Output:
+	// -/sbin/agetty -o '-p -- \u' --keep-baud 115200,57600,38400,9600 - ttyS0 on web1
+	// serial-getty@.service ttyS0
+}
+
 func ExampleUnit_WriteTo() {
 	unit := systemdconfig.NewUnit()
 	unit.AddSection("Match").AddOption("Name", "eth0")
@@ -102,3 +157,85 @@ func ExampleUnit_WriteTo() {
 	// [Network]
 	// DHCP=yes
 }
+
+func ExampleSection_SetComments() {
+	unit := systemdconfig.NewUnit()
+	service := unit.AddSection("Service")
+	service.SetComments("Managed by deployd, do not edit.")
+	service.AddOption("ExecStart", "/usr/bin/app").SetComments("Restarted by the watchdog.")
+
+	if _, err := unit.WriteTo(os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+	// Output:
+	// # Managed by deployd, do not edit.
+	// [Service]
+	// # Restarted by the watchdog.
+	// ExecStart=/usr/bin/app
+}
+
+func ExampleTokenizer() {
+	in := `[Service]
+# the real binary
+ExecStart=/usr/bin/app
+[Install]
+WantedBy=multi-user.target
+`
+	tok := systemdconfig.NewTokenizer(strings.NewReader(in))
+	for {
+		token, err := tok.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if token.Kind == systemdconfig.Assignment {
+			fmt.Printf("%s: %s.%s\n", token.Pos, token.Section, token.Option)
+		}
+	}
+	// Output:
+	// 3:1: Service.ExecStart
+	// 5:1: Install.WantedBy
+}
+
+func ExampleMarshal() {
+	type Address struct {
+		Address string `systemd:"Address"`
+	}
+	type Network struct {
+		Name      string    `systemd:"Match.Name"`
+		DNS       []string  `systemd:"Network.DNS,omitempty"`
+		Addresses []Address `systemd:"Address"`
+	}
+
+	unit, err := systemdconfig.Marshal(Network{
+		Name:      "eth0",
+		DNS:       []string{"1.1.1.1", "9.9.9.9"},
+		Addresses: []Address{{"10.0.0.2/24"}, {"fd00::2/64"}},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Print(unit)
+
+	var back Network
+	if err := systemdconfig.Unmarshal(unit, &back); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(back.DNS, len(back.Addresses))
+	// Output:
+	// [Match]
+	// Name=eth0
+	//
+	// [Network]
+	// DNS=1.1.1.1
+	// DNS=9.9.9.9
+	//
+	// [Address]
+	// Address=10.0.0.2/24
+	//
+	// [Address]
+	// Address=fd00::2/64
+	// [1.1.1.1 9.9.9.9] 2
+}
@@ -2,14 +2,24 @@ package systemdconfig
 
 import (
 	"bytes"
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 )
 
 // FuzzDeserialize checks that Deserialize never panics on arbitrary input
 // and that, whenever parsing succeeds, the serialized form is canonical:
-// parsing and serializing it again reproduces it byte for byte.
+// parsing and serializing it again reproduces it byte for byte. It also
+// checks that DeserializeLossless accepts the same input and writes it
+// back unchanged, that DeserializeAll never panics either and agrees
+// with Deserialize on valid input, and that a lossless decoder recovering
+// from errors writes back any input unchanged. The strict decoder options
+// must not panic either, and a Tokenizer must return the assignments of
+// the parsed unit.
 func FuzzDeserialize(f *testing.F) {
 	seeds := []string{
 		"",
@@ -43,11 +53,61 @@ func FuzzDeserialize(f *testing.F) {
 	}
 
 	f.Fuzz(func(t *testing.T, data []byte) {
+		recovered, recoverErr := DeserializeAll(bytes.NewReader(data))
+
+		_, _ = NewDecoder(bytes.NewReader(data), WithRecovery(), WithLineMax(LegacyLineMax), WithBOMStripping(),
+			WithStrictUTF8(), WithRejectControlChars(), WithStrictContinuations(),
+			WithMaxFileSize(512), WithMaxSections(4), WithMaxOptions(8)).Decode()
+
+		verbatimAll, _ := NewDecoder(bytes.NewReader(data), WithLossless(), WithRecovery()).Decode()
+		if got := verbatimAll.String(); got != string(data) {
+			t.Errorf("lossless recovering round trip changed the input:\ngot:  %q\nwant: %q", got, data)
+		}
+
 		unit, err := Deserialize(bytes.NewReader(data))
 		if err != nil {
 			return
 		}
 
+		if recoverErr != nil || !recovered.Match(unit) {
+			t.Errorf("DeserializeAll() = %v, %v, want %v, nil", recovered, recoverErr, unit)
+		}
+
+		var want, got []string
+		for _, section := range unit.Sections {
+			for _, option := range section.Options {
+				want = append(want, section.Name+"."+option.Option+"="+option.Value)
+			}
+		}
+		tok := NewTokenizer(bytes.NewReader(data))
+		for {
+			token, err := tok.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatalf("Tokenizer.Next() error = %v, but Deserialize() succeeded", err)
+			}
+			if token.Kind == Assignment {
+				got = append(got, token.Section+"."+token.Option+"="+token.Value)
+			}
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("Tokenizer assignments = %q, want %q", got, want)
+		}
+
+		lossless, err := DeserializeLossless(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("DeserializeLossless() error = %v, but Deserialize() succeeded", err)
+		}
+		var verbatim bytes.Buffer
+		if _, err := lossless.WriteTo(&verbatim); err != nil {
+			t.Fatalf("WriteTo() of lossless unit error = %v", err)
+		}
+		if !bytes.Equal(verbatim.Bytes(), data) {
+			t.Errorf("lossless round trip changed the input:\ngot:  %q\nwant: %q", verbatim.String(), data)
+		}
+
 		var first bytes.Buffer
 		if _, err := unit.WriteTo(&first); err != nil {
 			t.Fatalf("WriteTo() error = %v", err)
@@ -67,3 +127,105 @@ func FuzzDeserialize(f *testing.F) {
 		}
 	})
 }
+
+// FuzzWriteTo checks that WriteTo either rejects a unit built in code or
+// writes it so that it parses back unchanged, comments included, and that
+// a unit passing Validate is always written. An Encoder wrapping values
+// and escaping them must write any value with a valid option name, and
+// the output style options must not change what the output parses to.
+func FuzzWriteTo(f *testing.F) {
+	f.Add("Service", "ExecStart", "/bin/true", "comment", 0)
+	f.Add("Unit]", "A=B", "a\nb", "c \\", 5)
+	f.Add("", "", " \\", "\r", 20)
+	f.Add("Service", "ExecStart", "a #b  c\\ d ;e", "", 3)
+
+	f.Fuzz(func(t *testing.T, name, option, value, comment string, width int) {
+		wrapped := NewUnit()
+		wrapped.AddSection("Service").AddOption("ExecStart", value)
+		var out bytes.Buffer
+		if err := NewEncoder(&out, WithLineWidth(width), WithValueEscaping()).Encode(wrapped); err != nil {
+			if !strings.Contains(err.Error(), "too long") {
+				t.Fatalf("Encode() error = %v", err)
+			}
+		} else {
+			want := value
+			if rawValueProblem(value) != "" {
+				want = EscapeValue(value)
+			}
+			reparsed, err := Deserialize(&out)
+			if err != nil {
+				t.Fatalf("reparse failed: %v", err)
+			}
+			if got, _ := reparsed.Value("Service", "ExecStart"); got != want {
+				t.Fatalf("wrapped value = %q, want %q", got, want)
+			}
+		}
+
+		unit := NewUnit()
+		section := unit.AddSection(name)
+		section.SetComments(comment)
+		section.AddOption(option, value).SetComments(comment)
+		unit.SetTrailingComments(comment)
+
+		var buf bytes.Buffer
+		if _, err := unit.WriteTo(&buf); err != nil {
+			if unit.Validate() == nil {
+				t.Fatalf("WriteTo() error = %v, but Validate() succeeded", err)
+			}
+			return
+		}
+
+		var styled bytes.Buffer
+		if err := NewEncoder(&styled, WithCRLF(), WithSectionSpacing(width%3), WithSpacedAssignments(),
+			WithAlignedEquals(), WithFinalNewline(width%2 == 0), WithHeaderComment("header")).Encode(unit); err != nil {
+			t.Fatalf("Encode() error = %v, but WriteTo() succeeded", err)
+		}
+		if reparsed, err := Deserialize(&styled); err != nil || !reparsed.Match(unit) {
+			t.Fatalf("styled reparse = %v, %v, want %v", reparsed, err, unit)
+		}
+
+		reparsed, err := DeserializeLossless(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("reparse failed: %v\noutput: %q", err, buf.String())
+		}
+		if !reparsed.Match(unit) {
+			t.Fatalf("reparsed = %v, want %v\noutput: %q", reparsed, unit, buf.String())
+		}
+		got := reparsed.Sections[0]
+		if !slices.Equal(got.Comments(), section.Comments()) ||
+			!slices.Equal(got.Options[0].Comments(), section.Options[0].Comments()) ||
+			!slices.Equal(reparsed.TrailingComments(), unit.TrailingComments()) {
+			t.Errorf("comments changed in round trip\noutput: %q", buf.String())
+		}
+	})
+}
+
+// FuzzSplitWords checks that SplitWords never panics, that JoinWords and
+// EscapeValue are the inverses of SplitWords and UnquoteValue, and that
+// escaped values can be written verbatim.
+func FuzzSplitWords(f *testing.F) {
+	f.Add(`/bin/sh -c "echo 'hi'" \x41\u00e9`, "a b")
+	f.Add(`a"b c"d \`, " \\\n")
+
+	f.Fuzz(func(t *testing.T, value, word string) {
+		_, _ = SplitWords(value)
+		_, _ = UnquoteValue(value)
+
+		escaped := EscapeValue(value)
+		if rawValueProblem(escaped) != "" {
+			t.Errorf("EscapeValue(%q) = %q cannot be written verbatim", value, escaped)
+		}
+		// systemd rejects escaped NUL characters
+		if strings.Contains(value+word, "\x00") {
+			return
+		}
+
+		words := []string{word, value}
+		if got, err := SplitWords(JoinWords(words...)); err != nil || !slices.Equal(got, words) {
+			t.Errorf("SplitWords(JoinWords(%q)) = %q, %v", words, got, err)
+		}
+		if got, err := UnquoteValue(escaped); err != nil || got != value {
+			t.Errorf("UnquoteValue(EscapeValue(%q)) = %q, %v", value, got, err)
+		}
+	})
+}
@@ -0,0 +1,34 @@
+// Package timename parses the time zone and day names that both the
+// timestamps and the calendar event expressions of systemd.time(7)
+// accept, so that the two parsers agree on their spellings.
+package timename
+
+import (
+	"strings"
+	"time"
+)
+
+// Location parses a time zone name: "UTC", in any case, or a name from
+// the IANA database such as "Europe/Berlin".
+func Location(name string) (*time.Location, bool) {
+	if strings.EqualFold(name, "UTC") {
+		return time.UTC, true
+	}
+	// anything that looks like a date or time is not a zone
+	if name == "" || name == "Local" || strings.ContainsAny(name, ":*~,.") || '0' <= name[0] && name[0] <= '9' {
+		return nil, false
+	}
+	loc, err := time.LoadLocation(name)
+	return loc, err == nil
+}
+
+// Weekday parses the name of a day, abbreviated or not and in any case,
+// e.g. "Mon" or "monday".
+func Weekday(name string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(name, d.String()) || strings.EqualFold(name, d.String()[:3]) {
+			return d, true
+		}
+	}
+	return 0, false
+}
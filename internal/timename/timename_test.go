@@ -0,0 +1,33 @@
+package timename
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestLocation(t *testing.T) {
+	for _, name := range []string{"UTC", "utc", "Europe/Berlin"} {
+		if loc, ok := Location(name); !ok || loc == nil {
+			t.Errorf("Location(%q) = %v, %v", name, loc, ok)
+		}
+	}
+	for _, name := range []string{"", "Local", "Mars/Olympus", "11:12", "2012-11-23", "*-*-*", "Mon,Tue"} {
+		if loc, ok := Location(name); ok {
+			t.Errorf("Location(%q) = %v, want no zone", name, loc)
+		}
+	}
+}
+
+func TestWeekday(t *testing.T) {
+	for name, want := range map[string]time.Weekday{"Mon": time.Monday, "sunday": time.Sunday, "FRI": time.Friday} {
+		if got, ok := Weekday(name); !ok || got != want {
+			t.Errorf("Weekday(%q) = %v, %v, want %v", name, got, ok, want)
+		}
+	}
+	for _, name := range []string{"", "Mo", "Mond", "Funday"} {
+		if got, ok := Weekday(name); ok {
+			t.Errorf("Weekday(%q) = %v, want none", name, got)
+		}
+	}
+}
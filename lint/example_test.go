@@ -0,0 +1,52 @@
+package lint_test
+
+import (
+	"fmt"
+	"strings"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+	"github.com/javadh75/systemd-config/lint"
+)
+
+func Example() {
+	u, err := systemdconfig.Deserialize(strings.NewReader(`[Unit]
+Description=Nightly job
+
+[Service]
+Type=oneshot
+Restart=always
+After=network-online.target
+PrivateTmp=sure
+`))
+	if err != nil {
+		panic(err)
+	}
+	for _, d := range lint.New().Lint("job.service", u) {
+		fmt.Println(d)
+	}
+	// Output:
+	// job.service:6:1: error: Restart=always is not allowed with Type=oneshot (oneshot-restart)
+	// job.service:7:1: error: option After= belongs in [Unit], not [Service] (wrong-section)
+	// job.service:8:1: error: Service.PrivateTmp: invalid boolean "sure" (invalid-value)
+}
+
+func ExampleWithRules() {
+	requireDescription := &lint.Rule{
+		ID:       "require-description",
+		Doc:      "a unit without Description=",
+		Severity: lint.Warning,
+		Check: func(p *lint.Pass) {
+			if _, ok := p.Unit.Value("Unit", "Description"); !ok {
+				p.Reportf(nil, nil, "unit has no Description=")
+			}
+		},
+	}
+
+	// lint.Register(requireDescription) would add the rule to every Linter
+	linter := lint.New(lint.WithRules(requireDescription))
+	for _, d := range linter.Lint("job.service", systemdconfig.NewUnit()) {
+		fmt.Println(d)
+	}
+	// Output:
+	// job.service: warning: unit has no Description= (require-description)
+}
@@ -0,0 +1,266 @@
+// Package lint checks unit files for mistakes systemd would tolerate or
+// ignore, such as unknown options, values systemd cannot parse and
+// settings that contradict each other.
+//
+// A Linter runs rules over a parsed *systemdconfig.Unit and returns
+// Diagnostics with the position, severity and ID of the rule that
+// reported them. The built-in rules are registered by this package; other
+// packages can Register their own, typically from an init function, or
+// pass them to a single Linter with WithRules.
+package lint
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+)
+
+// Severity is how serious a Diagnostic is.
+type Severity int
+
+const (
+	// Error is a mistake that keeps systemd from doing what the unit
+	// says, e.g. an option in the wrong section, which systemd ignores.
+	Error Severity = iota + 1
+	// Warning is a likely mistake, or something systemd warns about,
+	// e.g. a deprecated option.
+	Warning
+	// Info is a suggestion.
+	Info
+)
+
+var severityNames = map[Severity]string{
+	Error:   "error",
+	Warning: "warning",
+	Info:    "info",
+}
+
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Diagnostic is a problem a rule found in a unit.
+type Diagnostic struct {
+	// Filename is the name passed to Linter.Lint.
+	Filename string
+	// Pos is the position of the section header or assignment the
+	// diagnostic is about, or an invalid Position when it is about the
+	// whole unit or the unit was not parsed.
+	Pos systemdconfig.Position
+	// Section is the name of the section, and Option the name of the
+	// option, if the diagnostic is about one.
+	Section, Option string
+	// Rule is the ID of the rule that reported the diagnostic.
+	Rule     string
+	Severity Severity
+	Message  string
+}
+
+// String returns the diagnostic in the "file:line:column: severity:
+// message (rule)" form, without the file name or position when they are
+// not known.
+func (d Diagnostic) String() string {
+	var prefix []string
+	if d.Filename != "" {
+		prefix = append(prefix, d.Filename)
+	}
+	if d.Pos.IsValid() {
+		prefix = append(prefix, d.Pos.String())
+	}
+	msg := fmt.Sprintf("%s: %s (%s)", d.Severity, d.Message, d.Rule)
+	if len(prefix) == 0 {
+		return msg
+	}
+	return strings.Join(prefix, ":") + ": " + msg
+}
+
+// Rule is a check a Linter runs on every unit.
+type Rule struct {
+	// ID identifies the rule in diagnostics and options, e.g.
+	// "unknown-option".
+	ID string
+	// Doc describes what the rule reports.
+	Doc string
+	// Severity is the severity of the diagnostics of the rule, unless
+	// configured otherwise with WithSeverity.
+	Severity Severity
+	// Check reports the problems of p.Unit with p.Reportf.
+	Check func(p *Pass)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*Rule{}
+)
+
+// Register makes a rule run by every Linter, unless disabled. It panics
+// if the rule has no ID or Check function, or if a rule with its ID is
+// already registered.
+func Register(r *Rule) {
+	if r.ID == "" || r.Check == nil {
+		panic("lint: Register of a rule without ID or Check")
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, dup := registry[r.ID]; dup {
+		panic("lint: Register called twice for rule " + r.ID)
+	}
+	registry[r.ID] = r
+}
+
+// Rules returns the registered rules, sorted by ID.
+func Rules() []*Rule {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	rules := make([]*Rule, 0, len(registry))
+	for _, r := range registry {
+		rules = append(rules, r)
+	}
+	slices.SortFunc(rules, func(a, b *Rule) int { return strings.Compare(a.ID, b.ID) })
+	return rules
+}
+
+// config holds the options of a Linter.
+type config struct {
+	disabled   map[string]bool
+	severities map[string]Severity
+	rules      []*Rule
+	enabled    map[string]bool
+}
+
+// Option configures a Linter. Options naming rules that do not exist are
+// ignored.
+type Option func(*config)
+
+// WithDisabled turns off the rules with the given IDs.
+func WithDisabled(ids ...string) Option {
+	return func(c *config) {
+		for _, id := range ids {
+			c.disabled[id] = true
+		}
+	}
+}
+
+// WithSeverity sets the severity of the diagnostics of the rule with the
+// given ID.
+func WithSeverity(id string, s Severity) Option {
+	return func(c *config) {
+		c.severities[id] = s
+	}
+}
+
+// WithRules adds rules that only this Linter runs, in addition to the
+// registered ones. A rule with the ID of a registered rule replaces it.
+func WithRules(rules ...*Rule) Option {
+	return func(c *config) {
+		c.rules = append(c.rules, rules...)
+	}
+}
+
+// WithEnabled names the units, e.g. "docker.service", that will be
+// enabled, which the "missing-install" rule checks can be.
+func WithEnabled(names ...string) Option {
+	return func(c *config) {
+		for _, name := range names {
+			c.enabled[name] = true
+		}
+	}
+}
+
+// A Linter runs rules over units. It is safe for concurrent use.
+type Linter struct {
+	rules      []*Rule
+	severities map[string]Severity
+	enabled    map[string]bool
+}
+
+// New returns a Linter that runs the registered rules, configured by
+// opts. Rules registered after New returns are not run.
+func New(opts ...Option) *Linter {
+	cfg := config{
+		disabled:   map[string]bool{},
+		severities: map[string]Severity{},
+		enabled:    map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	rules := Rules()
+	for _, r := range cfg.rules {
+		if i := slices.IndexFunc(rules, func(reg *Rule) bool { return reg.ID == r.ID }); i >= 0 {
+			rules[i] = r
+		} else {
+			rules = append(rules, r)
+		}
+	}
+	rules = slices.DeleteFunc(rules, func(r *Rule) bool { return cfg.disabled[r.ID] })
+	return &Linter{rules: rules, severities: cfg.severities, enabled: cfg.enabled}
+}
+
+// Lint runs the rules of l over u and returns their diagnostics, sorted
+// by position. The name is the file name of the unit, e.g.
+// "/etc/systemd/system/docker.service"; its extension is the unit type,
+// without which rules that need to know the options of the unit type
+// report nothing.
+func (l *Linter) Lint(name string, u *systemdconfig.Unit) []Diagnostic {
+	base := filepath.Base(name)
+	p := &Pass{
+		Filename: name,
+		UnitType: strings.TrimPrefix(filepath.Ext(base), "."),
+		Unit:     u,
+		Enabled:  l.enabled[base],
+	}
+	for _, r := range l.rules {
+		p.rule = r
+		p.severity = r.Severity
+		if s, ok := l.severities[r.ID]; ok {
+			p.severity = s
+		}
+		r.Check(p)
+	}
+	slices.SortStableFunc(p.diags, func(a, b Diagnostic) int {
+		return cmp.Or(cmp.Compare(a.Pos.Offset, b.Pos.Offset), strings.Compare(a.Rule, b.Rule))
+	})
+	return p.diags
+}
+
+// Pass is the unit a rule checks, and where it reports problems.
+type Pass struct {
+	// Filename is the name passed to Linter.Lint, and UnitType the
+	// extension of it without the dot, e.g. "service".
+	Filename, UnitType string
+	Unit               *systemdconfig.Unit
+	// Enabled reports whether the unit will be enabled, see WithEnabled.
+	Enabled bool
+
+	rule     *Rule
+	severity Severity
+	diags    []Diagnostic
+}
+
+// Reportf reports a problem with option o of section s, or with section
+// s when o is nil, or with the whole unit when both are nil.
+func (p *Pass) Reportf(s *systemdconfig.Section, o *systemdconfig.OptionValue, format string, args ...any) {
+	d := Diagnostic{
+		Filename: p.Filename,
+		Rule:     p.rule.ID,
+		Severity: p.severity,
+		Message:  fmt.Sprintf(format, args...),
+	}
+	if s != nil {
+		d.Pos, d.Section = s.Pos(), s.Name
+	}
+	if o != nil {
+		d.Pos, d.Option = o.Pos(), o.Option
+	}
+	p.diags = append(p.diags, d)
+}
@@ -0,0 +1,137 @@
+package lint
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+)
+
+func parse(t *testing.T, text string) *systemdconfig.Unit {
+	t.Helper()
+	u, err := systemdconfig.Deserialize(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+// summary returns the diagnostics as "line:column rule" strings.
+func summary(diags []Diagnostic) []string {
+	var s []string
+	for _, d := range diags {
+		s = append(s, d.Pos.String()+" "+d.Rule)
+	}
+	return s
+}
+
+func TestDiagnostic_String(t *testing.T) {
+	d := Diagnostic{
+		Filename: "foo.service",
+		Pos:      systemdconfig.Position{Offset: 20, Line: 3, Column: 1},
+		Rule:     "unknown-option",
+		Severity: Warning,
+		Message:  "unknown option Foo= in [Service]",
+	}
+	if got, want := d.String(), "foo.service:3:1: warning: unknown option Foo= in [Service] (unknown-option)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	d.Filename = ""
+	if got, want := d.String(), "3:1: warning: unknown option Foo= in [Service] (unknown-option)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	d.Pos = systemdconfig.Position{}
+	if got, want := d.String(), "warning: unknown option Foo= in [Service] (unknown-option)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := Severity(0).String(); got != "Severity(0)" {
+		t.Errorf("Severity(0).String() = %q", got)
+	}
+}
+
+func TestLinter_Configure(t *testing.T) {
+	u := parse(t, "[Service]\nPersistent=yes\nNice=high\n")
+
+	got := summary(New().Lint("foo.service", u))
+	if want := []string{"2:1 unknown-option", "3:1 invalid-value"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint() = %q, want %q", got, want)
+	}
+
+	got = summary(New(WithDisabled("unknown-option")).Lint("foo.service", u))
+	if want := []string{"3:1 invalid-value"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Lint() with unknown-option disabled = %q, want %q", got, want)
+	}
+
+	diags := New(WithSeverity("invalid-value", Info)).Lint("foo.service", u)
+	if len(diags) != 2 || diags[0].Severity != Warning || diags[1].Severity != Info {
+		t.Errorf("Lint() with invalid-value as info = %v", diags)
+	}
+}
+
+func TestLinter_CustomRule(t *testing.T) {
+	noDescription := &Rule{
+		ID:       "no-description",
+		Severity: Info,
+		Check: func(p *Pass) {
+			if _, ok := p.Unit.Value("Unit", "Description"); !ok {
+				p.Reportf(p.Unit.SectionByName("Unit"), nil, "unit has no description")
+			}
+		},
+	}
+	u := parse(t, "[Unit]\nAfter=foo.service\n")
+
+	diags := New(WithRules(noDescription), WithDisabled("after-without-wants")).Lint("foo.service", u)
+	want := []Diagnostic{{
+		Filename: "foo.service",
+		Pos:      systemdconfig.Position{Line: 1, Column: 1},
+		Section:  "Unit",
+		Rule:     "no-description",
+		Severity: Info,
+		Message:  "unit has no description",
+	}}
+	if !reflect.DeepEqual(diags, want) {
+		t.Errorf("Lint() = %v, want %v", diags, want)
+	}
+
+	// a rule of WithRules replaces the registered rule with its ID
+	quiet := &Rule{ID: "after-without-wants", Check: func(*Pass) {}}
+	if diags := New(WithRules(quiet)).Lint("foo.service", u); len(diags) != 0 {
+		t.Errorf("Lint() with a replaced rule = %v", diags)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	r := &Rule{ID: "test-registered", Severity: Warning, Check: func(p *Pass) {
+		p.Reportf(nil, nil, "checked %s", p.UnitType)
+	}}
+	Register(r)
+	defer func() {
+		registryMu.Lock()
+		delete(registry, r.ID)
+		registryMu.Unlock()
+	}()
+
+	found := false
+	for _, reg := range Rules() {
+		found = found || reg == r
+	}
+	if !found {
+		t.Errorf("Rules() does not include the registered rule")
+	}
+	diags := New().Lint("/etc/systemd/system/foo.socket", systemdconfig.NewUnit())
+	if got := summary(diags); !reflect.DeepEqual(got, []string{"- test-registered"}) || diags[0].Message != "checked socket" {
+		t.Errorf("Lint() = %v", diags)
+	}
+
+	for _, bad := range []*Rule{r, {ID: "no-check"}, {Check: r.Check}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) did not panic", bad.ID)
+				}
+			}()
+			Register(bad)
+		}()
+	}
+}
@@ -0,0 +1,330 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+	"github.com/javadh75/systemd-config/calendar"
+	"github.com/javadh75/systemd-config/catalog"
+	"github.com/javadh75/systemd-config/schema"
+)
+
+func init() {
+	for _, r := range []*Rule{
+		{
+			ID:       "unknown-section",
+			Doc:      "a section the unit type does not have, which systemd ignores",
+			Severity: Warning,
+			Check:    checkUnknownSection,
+		},
+		{
+			ID:       "unknown-option",
+			Doc:      "an option systemd does not know, which it ignores",
+			Severity: Warning,
+			Check:    checkUnknownOption,
+		},
+		{
+			ID:       "wrong-section",
+			Doc:      "an option of another section of the unit type, which systemd ignores",
+			Severity: Error,
+			Check:    checkWrongSection,
+		},
+		{
+			ID:       "deprecated-option",
+			Doc:      "a deprecated option",
+			Severity: Warning,
+			Check:    checkDeprecatedOption,
+		},
+		{
+			ID:       "invalid-value",
+			Doc:      "a boolean, number, time span, size, file mode, calendar event or command systemd cannot parse",
+			Severity: Error,
+			Check:    checkInvalidValue,
+		},
+		{
+			ID:       "duplicate-option",
+			Doc:      "an option that is not a list assigned again, silently overriding the earlier assignment",
+			Severity: Warning,
+			Check:    checkDuplicateOption,
+		},
+		{
+			ID:       "after-without-wants",
+			Doc:      "an ordering dependency on a unit that no requirement dependency pulls in",
+			Severity: Info,
+			Check:    checkAfterWithoutWants,
+		},
+		{
+			ID:       "oneshot-restart",
+			Doc:      "Restart=always or on-success with Type=oneshot, which systemd refuses",
+			Severity: Error,
+			Check:    checkOneshotRestart,
+		},
+		{
+			ID:       "missing-install",
+			Doc:      "a unit to be enabled without [Install] settings to enable it by",
+			Severity: Error,
+			Check:    checkMissingInstall,
+		},
+	} {
+		Register(r)
+	}
+}
+
+// ignored reports whether systemd ignores the named section or option
+// without a warning, as it does for names starting with "X-".
+func ignored(name string) bool {
+	return strings.HasPrefix(name, "X-")
+}
+
+// knownSection reports whether the unit type of p has the named section.
+// It reports true when the unit type is unknown, so that rules about
+// options of unknown sections stay silent.
+func knownSection(p *Pass, section string) bool {
+	sections := catalog.Sections(p.UnitType)
+	return sections == nil || slices.Contains(sections, section)
+}
+
+// otherSection returns the section of the unit type of p other than the
+// given one that has the named option, if any.
+func otherSection(p *Pass, section, option string) (string, bool) {
+	for _, s := range catalog.Sections(p.UnitType) {
+		if _, ok := catalog.Lookup(p.UnitType, s, option); ok && s != section {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// options calls f for every option of p.Unit that the catalog knows.
+func options(p *Pass, f func(s *systemdconfig.Section, o *systemdconfig.OptionValue, opt catalog.Option)) {
+	for _, s := range p.Unit.Sections {
+		for _, o := range s.Options {
+			if opt, ok := catalog.Lookup(p.UnitType, s.Name, o.Option); ok {
+				f(s, o, opt)
+			}
+		}
+	}
+}
+
+func checkUnknownSection(p *Pass) {
+	if catalog.Sections(p.UnitType) == nil {
+		return
+	}
+	for _, s := range p.Unit.Sections {
+		if !ignored(s.Name) && !knownSection(p, s.Name) {
+			p.Reportf(s, nil, "unknown section [%s]", s.Name)
+		}
+	}
+}
+
+func checkUnknownOption(p *Pass) {
+	if catalog.Sections(p.UnitType) == nil {
+		return
+	}
+	for _, s := range p.Unit.Sections {
+		if ignored(s.Name) || !knownSection(p, s.Name) {
+			continue
+		}
+		for _, o := range s.Options {
+			if ignored(o.Option) {
+				continue
+			}
+			if _, ok := catalog.Lookup(p.UnitType, s.Name, o.Option); ok {
+				continue
+			}
+			if _, ok := otherSection(p, s.Name, o.Option); !ok {
+				p.Reportf(s, o, "unknown option %s= in [%s]", o.Option, s.Name)
+			}
+		}
+	}
+}
+
+func checkWrongSection(p *Pass) {
+	for _, s := range p.Unit.Sections {
+		if !knownSection(p, s.Name) {
+			continue
+		}
+		for _, o := range s.Options {
+			if _, ok := catalog.Lookup(p.UnitType, s.Name, o.Option); ok {
+				continue
+			}
+			if other, ok := otherSection(p, s.Name, o.Option); ok {
+				p.Reportf(s, o, "option %s= belongs in [%s], not [%s]", o.Option, other, s.Name)
+			}
+		}
+	}
+}
+
+func checkDeprecatedOption(p *Pass) {
+	options(p, func(s *systemdconfig.Section, o *systemdconfig.OptionValue, opt catalog.Option) {
+		if !opt.Deprecated {
+			return
+		}
+		section, option, _ := strings.Cut(opt.ReplacedBy, ".")
+		switch {
+		case option == "":
+			p.Reportf(s, o, "option %s= is deprecated", o.Option)
+		case section == s.Name:
+			p.Reportf(s, o, "option %s= is deprecated, use %s=", o.Option, option)
+		case option == o.Option:
+			p.Reportf(s, o, "option %s= in [%s] is deprecated, move it to [%s]", o.Option, s.Name, section)
+		default:
+			p.Reportf(s, o, "option %s= is deprecated, use %s= in [%s]", o.Option, option, section)
+		}
+	})
+}
+
+func checkInvalidValue(p *Pass) {
+	options(p, func(s *systemdconfig.Section, o *systemdconfig.OptionValue, opt catalog.Option) {
+		// an empty value resets the option, and specifiers are only
+		// expanded when systemd loads the unit
+		if o.Value == "" || opt.Type != catalog.Command && strings.Contains(o.Value, "%") {
+			return
+		}
+		if err := parseValue(opt.Type, o.Value); err != nil {
+			err = &systemdconfig.ValueError{Section: s.Name, Option: o.Option, Value: o.Value, Err: err}
+			p.Reportf(s, o, "%v", err)
+		}
+	})
+}
+
+// parseValue returns the error parsing a value of the given type, or nil
+// for the types that are not checked.
+func parseValue(t catalog.ValueType, value string) error {
+	var err error
+	switch t {
+	case catalog.Bool:
+		_, err = systemdconfig.ParseBool(value)
+	case catalog.Int:
+		if _, perr := strconv.ParseInt(strings.TrimSpace(value), 10, 64); perr != nil {
+			err = fmt.Errorf("%w %q", systemdconfig.ErrInvalidInt, value)
+		}
+	case catalog.Duration:
+		_, err = systemdconfig.ParseTimespan(value)
+	case catalog.Bytes:
+		_, err = systemdconfig.ParseBytes(value)
+	case catalog.Mode:
+		if m, perr := strconv.ParseUint(strings.TrimSpace(value), 8, 32); perr != nil || m > 0o7777 {
+			err = fmt.Errorf("invalid file mode %q", value)
+		}
+	case catalog.Calendar:
+		_, err = calendar.Parse(value)
+	case catalog.Command:
+		_, err = schema.ParseCommand(value)
+	}
+	return err
+}
+
+func checkDuplicateOption(p *Pass) {
+	type key struct{ section, option string }
+	last := map[key]*systemdconfig.OptionValue{}
+	options(p, func(s *systemdconfig.Section, o *systemdconfig.OptionValue, opt catalog.Option) {
+		if opt.List {
+			return
+		}
+		k := key{s.Name, o.Option}
+		// assigning the empty value first, to reset the option, is fine
+		if prev := last[k]; prev != nil && prev.Value != "" {
+			if prev.Pos().IsValid() {
+				p.Reportf(s, o, "%s= overrides the assignment at %s", o.Option, prev.Pos())
+			} else {
+				p.Reportf(s, o, "%s= overrides an earlier assignment", o.Option)
+			}
+		}
+		last[k] = o
+	})
+}
+
+// pullingDependencies are the dependencies that add a unit to the
+// transaction, so that ordering dependencies on it take effect.
+var pullingDependencies = []string{"Wants", "Requires", "Requisite", "BindsTo", "Upholds"}
+
+// passiveUnits are units that units are ordered after without pulling
+// them in: the passive targets of systemd.special(7), which the units
+// providing them pull in, and the targets every boot reaches early.
+var passiveUnits = []string{
+	"basic.target", "cryptsetup-pre.target", "first-boot-complete.target", "getty-pre.target",
+	"local-fs-pre.target", "local-fs.target", "network-pre.target", "network.target",
+	"nss-lookup.target", "nss-user-lookup.target", "remote-fs-pre.target", "sysinit.target",
+	"time-set.target", "time-sync.target",
+}
+
+func checkAfterWithoutWants(p *Pass) {
+	pulled := map[string]bool{}
+	for _, dep := range pullingDependencies {
+		for _, v := range p.Unit.Values("Unit", dep) {
+			for _, name := range strings.Fields(v) {
+				pulled[name] = true
+			}
+		}
+	}
+	reported := map[string]bool{}
+	for _, s := range p.Unit.SectionsByName("Unit") {
+		for _, o := range s.Options {
+			if o.Option != "After" {
+				continue
+			}
+			for _, name := range strings.Fields(o.Value) {
+				if !pulled[name] && !reported[name] && !slices.Contains(passiveUnits, name) {
+					reported[name] = true
+					p.Reportf(s, o, "After=%s orders the unit without pulling %s in; add it to Wants= or Requires= too", name, name)
+				}
+			}
+		}
+	}
+}
+
+// lastOption returns the assignment of the named option that is in
+// effect, the last one, and its section.
+func lastOption(u *systemdconfig.Unit, section, option string) (*systemdconfig.Section, *systemdconfig.OptionValue) {
+	sections := u.SectionsByName(section)
+	for i := len(sections) - 1; i >= 0; i-- {
+		opts := sections[i].Options
+		for j := len(opts) - 1; j >= 0; j-- {
+			if opts[j].Option == option {
+				return sections[i], opts[j]
+			}
+		}
+	}
+	return nil, nil
+}
+
+func checkOneshotRestart(p *Pass) {
+	if p.UnitType != "service" && p.UnitType != "" {
+		return
+	}
+	if _, typ := lastOption(p.Unit, "Service", "Type"); typ == nil || typ.Value != string(schema.ServiceOneshot) {
+		return
+	}
+	s, restart := lastOption(p.Unit, "Service", "Restart")
+	if restart == nil {
+		return
+	}
+	if restart.Value == string(schema.RestartAlways) || restart.Value == string(schema.RestartOnSuccess) {
+		p.Reportf(s, restart, "Restart=%s is not allowed with Type=oneshot", restart.Value)
+	}
+}
+
+// installOptions are the options of [Install] that enabling a unit acts
+// on.
+var installOptions = []string{"WantedBy", "RequiredBy", "UpheldBy", "Alias", "Also"}
+
+func checkMissingInstall(p *Pass) {
+	if !p.Enabled {
+		return
+	}
+	sections := p.Unit.SectionsByName("Install")
+	if len(sections) == 0 {
+		p.Reportf(nil, nil, "unit is to be enabled but has no [Install] section")
+		return
+	}
+	for _, name := range installOptions {
+		if _, ok := p.Unit.Value("Install", name); ok {
+			return
+		}
+	}
+	p.Reportf(sections[0], nil, "unit is to be enabled but [Install] has none of WantedBy=, RequiredBy=, UpheldBy=, Alias= and Also=")
+}
@@ -0,0 +1,126 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+)
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		rule, name, text string
+		want             []string
+	}{
+		{"unknown-section", "foo.service", "[Unit]\n[Timer]\n[X-Vendor]\n", []string{"2:1 unknown section [Timer]"}},
+		{"unknown-section", "foo.conf", "[Whatever]\n", nil},
+		{"unknown-option", "foo.service", "[Service]\nExecStrat=/bin/true\nX-Custom=1\nAfter=bar.service\n", []string{"2:1 unknown option ExecStrat= in [Service]"}},
+		{"wrong-section", "foo.service", "[Service]\nAfter=bar.service\n", []string{"2:1 option After= belongs in [Unit], not [Service]"}},
+		{"wrong-section", "foo.service", "[Timer]\nAfter=bar.service\n", nil},
+		{"deprecated-option", "foo.service", "[Service]\nMemoryLimit=1G\nStartLimitBurst=5\nPermissionsStartOnly=yes\nStartLimitInterval=10s\n", []string{
+			"2:1 option MemoryLimit= is deprecated, use MemoryMax=",
+			"3:1 option StartLimitBurst= in [Service] is deprecated, move it to [Unit]",
+			"4:1 option PermissionsStartOnly= is deprecated",
+			"5:1 option StartLimitInterval= is deprecated, use StartLimitIntervalSec= in [Unit]",
+		}},
+		{"invalid-value", "foo.timer", "[Timer]\nPersistent=maybe\nPersistent=\nAccuracySec=1 fortnight\nOnCalendar=Funday\nOnBootSec=%i\n", []string{
+			`2:1 Timer.Persistent: invalid boolean "maybe"`,
+			`4:1 Timer.AccuracySec: invalid time span "1 fortnight"`,
+			`5:1 Timer.OnCalendar: invalid calendar specification "Funday": unknown weekday "Funday"`,
+		}},
+		{"invalid-value", "foo.socket", "[Socket]\nSocketMode=0999\nBacklog=lots\nReceiveBuffer=8M\nExecStartPre=\"/bin/true\n", []string{
+			`2:1 Socket.SocketMode: invalid file mode "0999"`,
+			`3:1 Socket.Backlog: invalid integer "lots"`,
+			`5:1 Socket.ExecStartPre: unterminated quote in "\"/bin/true"`,
+		}},
+		{"duplicate-option", "foo.service", "[Service]\nType=simple\nUser=a\nType=notify\nExecStart=/a\nExecStart=/b\n[Service]\nUser=\nUser=b\n", []string{
+			"4:1 Type= overrides the assignment at 2:1",
+			"8:1 User= overrides the assignment at 3:1",
+		}},
+		{"after-without-wants", "foo.service", "[Unit]\nAfter=network-online.target network.target db.service\nWants=network-online.target\n[Unit]\nAfter=cache.service db.service\nRequires=cache.service\n", []string{
+			"2:1 After=db.service orders the unit without pulling db.service in; add it to Wants= or Requires= too",
+		}},
+		{"oneshot-restart", "foo.service", "[Service]\nType=oneshot\nRestart=always\n", []string{"3:1 Restart=always is not allowed with Type=oneshot"}},
+		{"oneshot-restart", "foo.service", "[Service]\nType=oneshot\nRestart=on-failure\n", nil},
+		{"oneshot-restart", "foo.service", "[Service]\nType=oneshot\nRestart=always\nType=simple\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			var rule *Rule
+			for _, r := range Rules() {
+				if r.ID == tt.rule {
+					rule = r
+				}
+			}
+			if rule == nil {
+				t.Fatalf("no rule %q", tt.rule)
+			}
+			p := &Pass{Filename: tt.name, UnitType: strings.TrimPrefix(filepath.Ext(tt.name), "."), Unit: parse(t, tt.text), rule: rule}
+			rule.Check(p)
+			var got []string
+			for _, d := range p.diags {
+				got = append(got, d.Pos.String()+" "+d.Message)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s on %q =\n%q\nwant\n%q", tt.rule, tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMissingInstall(t *testing.T) {
+	linter := New(WithEnabled("foo.service"))
+	tests := []struct {
+		name, text string
+		want       []string
+	}{
+		{"foo.service", "[Unit]\nDescription=Foo\n", []string{"- missing-install"}},
+		{"foo.service", "[Unit]\nDescription=Foo\n[Install]\nDefaultInstance=a\n", []string{"3:1 missing-install"}},
+		{"foo.service", "[Install]\nWantedBy=multi-user.target\n", nil},
+		{"bar.service", "[Unit]\nDescription=Bar\n", nil},
+	}
+	for _, tt := range tests {
+		got := summary(linter.Lint(tt.name, parse(t, tt.text)))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Lint(%s, %q) = %q, want %q", tt.name, tt.text, got, tt.want)
+		}
+	}
+}
+
+// TestLint_Testdata checks that the unit files in testdata only get the
+// diagnostics they deserve.
+func TestLint_Testdata(t *testing.T) {
+	want := map[string][]string{
+		"docker.service": {
+			"4:1 after-without-wants",
+			"18:1 deprecated-option",
+			"19:1 deprecated-option",
+		},
+		"sshd.service": {"5:1 after-without-wants"},
+	}
+	paths, err := filepath.Glob(filepath.Join("..", "testdata", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range paths {
+		if filepath.Ext(path) == ".golden" {
+			continue
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u, err := systemdconfig.Deserialize(f)
+		f.Close()
+		if err != nil {
+			continue
+		}
+		name := filepath.Base(path)
+		if got := summary(New().Lint(path, u)); !reflect.DeepEqual(got, want[name]) {
+			t.Errorf("Lint(%s) = %q, want %q", name, got, want[name])
+		}
+	}
+}
@@ -0,0 +1,479 @@
+package systemdconfig
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+var (
+	// ErrUnitNotFound is wrapped by the error a Loader returns for a unit
+	// whose file is in no directory of the search path.
+	ErrUnitNotFound = errors.New("unit not found")
+	// ErrUnitMasked is wrapped by the error a Loader returns for a unit
+	// whose file is empty or a symlink to /dev/null.
+	ErrUnitMasked = errors.New("unit is masked")
+)
+
+// Scope selects the unit search path of a Loader: that of the system
+// manager or of a user's manager (systemctl --user).
+type Scope int
+
+const (
+	// ScopeSystem is the system manager, whose units are in
+	// /etc/systemd/system, /usr/lib/systemd/system and so on.
+	ScopeSystem Scope = iota
+	// ScopeUser is the manager of the user running the program, whose
+	// units are in ~/.config/systemd/user, /usr/lib/systemd/user and so
+	// on.
+	ScopeUser
+)
+
+// systemSearchPath is the unit search path of the system manager, as in
+// systemd.unit(5), highest priority first.
+var systemSearchPath = []string{
+	"/etc/systemd/system.control",
+	"/run/systemd/system.control",
+	"/run/systemd/transient",
+	"/run/systemd/generator.early",
+	"/etc/systemd/system",
+	"/etc/systemd/system.attached",
+	"/run/systemd/system",
+	"/run/systemd/system.attached",
+	"/run/systemd/generator",
+	"/usr/local/lib/systemd/system",
+	"/usr/lib/systemd/system",
+	"/run/systemd/generator.late",
+}
+
+// loaderConfig holds the options of a Loader.
+type loaderConfig struct {
+	searchPath []string
+	fsys       fs.FS
+}
+
+// LoaderOption configures a Loader.
+type LoaderOption func(*loaderConfig)
+
+// WithSearchPath replaces the unit search path of the scope with the
+// given directories, highest priority first.
+func WithSearchPath(dirs ...string) LoaderOption {
+	return func(c *loaderConfig) {
+		c.searchPath = slices.Clone(dirs)
+	}
+}
+
+// WithRoot makes a Loader look for units in the directory tree under
+// root, as "systemctl --root" does, e.g. in a chroot or an unpacked
+// image. Symlinks are resolved as if root were "/", so an absolute
+// symlink does not lead out of the tree, and a symlink to /dev/null
+// masks a unit even when the tree has no /dev.
+func WithRoot(root string) LoaderOption {
+	return func(c *loaderConfig) {
+		c.fsys = rootFS(root)
+	}
+}
+
+// WithFS makes a Loader look for units in fsys, e.g. an fstest.MapFS or
+// a file system backed by a tar archive: a directory /etc/systemd/system
+// of the search path is "etc/systemd/system" in fsys. Whether fsys
+// follows symlinks is up to it; an empty file or a character device
+// masks a unit.
+func WithFS(fsys fs.FS) LoaderOption {
+	return func(c *loaderConfig) {
+		c.fsys = fsys
+	}
+}
+
+// A Loader finds unit files and their drop-ins on the unit search path
+// and merges them, as systemd does when it loads a unit. It is safe for
+// concurrent use if its file system is.
+type Loader struct {
+	searchPath []string
+	fsys       fs.FS
+}
+
+// NewLoader returns a Loader for units of the given scope. The search
+// path is that of systemd.unit(5): for ScopeUser it depends on
+// $XDG_CONFIG_HOME, $XDG_CONFIG_DIRS, $XDG_DATA_HOME, $XDG_DATA_DIRS,
+// $XDG_RUNTIME_DIR and $HOME, and for both scopes $SYSTEMD_UNIT_PATH
+// replaces it, or is prepended to it when it ends with ':'. The
+// environment is that of the program, even with WithRoot or WithFS. The
+// directories of the search path are absolute, and the Loader reads them
+// from the root file system unless configured otherwise.
+func NewLoader(scope Scope, opts ...LoaderOption) *Loader {
+	var cfg loaderConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.searchPath == nil {
+		cfg.searchPath = defaultSearchPath(scope)
+	}
+	if cfg.fsys == nil {
+		cfg.fsys = rootFS("/")
+	}
+	return &Loader{searchPath: cfg.searchPath, fsys: cfg.fsys}
+}
+
+// defaultSearchPath returns the unit search path of the scope in the
+// current environment.
+func defaultSearchPath(scope Scope) []string {
+	dirs := systemSearchPath
+	if scope == ScopeUser {
+		dirs = userSearchPath()
+	}
+	env, ok := os.LookupEnv("SYSTEMD_UNIT_PATH")
+	if !ok {
+		return dirs
+	}
+	var custom []string
+	for _, dir := range strings.Split(env, ":") {
+		if dir != "" {
+			custom = append(custom, dir)
+		}
+	}
+	if strings.HasSuffix(env, ":") {
+		return append(custom, dirs...)
+	}
+	return custom
+}
+
+// absEnv returns the value of the environment variable name if it is an
+// absolute path, or "".
+func absEnv(name string) string {
+	if dir := os.Getenv(name); filepath.IsAbs(dir) {
+		return dir
+	}
+	return ""
+}
+
+// xdgDir returns the XDG base directory of the environment variable
+// name, e.g. XDG_CONFIG_HOME, or, if that is unset or relative, fallback
+// under home, or "" if home is "" too. The user search path and the
+// specifiers of a user's manager both use it.
+func xdgDir(name, home, fallback string) string {
+	if dir := absEnv(name); dir != "" {
+		return dir
+	}
+	if home == "" {
+		return ""
+	}
+	return filepath.Join(home, fallback)
+}
+
+// userSearchPath returns the unit search path of a user's manager.
+// Directories under an unknown or relative base directory are left out.
+func userSearchPath() []string {
+	home, _ := os.UserHomeDir()
+	xdgDirs := func(name string, fallback ...string) []string {
+		dirs := fallback
+		if env := os.Getenv(name); env != "" {
+			dirs = strings.Split(env, ":")
+		}
+		return slices.DeleteFunc(dirs, func(dir string) bool { return !filepath.IsAbs(dir) })
+	}
+	configHome := xdgDir("XDG_CONFIG_HOME", home, ".config")
+	dataHome := xdgDir("XDG_DATA_HOME", home, ".local/share")
+	runtime := absEnv("XDG_RUNTIME_DIR")
+
+	var dirs []string
+	add := func(base string, elem ...string) {
+		if base != "" {
+			dirs = append(dirs, filepath.Join(append([]string{base}, elem...)...))
+		}
+	}
+	add(configHome, "systemd/user.control")
+	add(runtime, "systemd/user.control")
+	add(runtime, "systemd/transient")
+	add(runtime, "systemd/generator.early")
+	add(configHome, "systemd/user")
+	for _, dir := range xdgDirs("XDG_CONFIG_DIRS", "/etc/xdg") {
+		add(dir, "systemd/user")
+	}
+	add("/etc/systemd/user")
+	add(runtime, "systemd/user")
+	add("/run/systemd/user")
+	add(runtime, "systemd/generator")
+	add(dataHome, "systemd/user")
+	for _, dir := range xdgDirs("XDG_DATA_DIRS", "/usr/local/share", "/usr/share") {
+		add(dir, "systemd/user")
+	}
+	add("/usr/local/lib/systemd/user")
+	add("/usr/lib/systemd/user")
+	add(runtime, "systemd/generator.late")
+	return dirs
+}
+
+// SearchPath returns the directories l looks for units in, highest
+// priority first. They are relative to the root or file system of l.
+func (l *Loader) SearchPath() []string {
+	return slices.Clone(l.searchPath)
+}
+
+// UnitFiles are the files a unit is loaded from: the unit file and its
+// drop-ins, in the order systemd applies them. Paths are absolute paths
+// in the root or file system of the Loader, e.g.
+// "/etc/systemd/system/docker.service" for
+// /mnt/etc/systemd/system/docker.service with WithRoot("/mnt").
+type UnitFiles struct {
+	// Name is the name of the unit, e.g. "docker.service".
+	Name string
+	// Path is the unit file, and Unit its content, parsed as by
+	// DeserializeLossless.
+	Path string
+	Unit *Unit
+	// DropIns are the drop-ins of the unit.
+	DropIns []*DropIn
+}
+
+// DropIn is a drop-in file of a unit.
+type DropIn struct {
+	// Path is the drop-in file, and Unit its content, parsed as by
+	// DeserializeLossless.
+	Path string
+	Unit *Unit
+}
+
+// Merge returns the unit file merged with its drop-ins, see Merge.
+func (f *UnitFiles) Merge() *Unit {
+	dropins := make([]*Unit, len(f.DropIns))
+	for i, d := range f.DropIns {
+		dropins[i] = d.Unit
+	}
+	return Merge(f.Unit, dropins...)
+}
+
+// WriteTo writes the unit file and its drop-ins to w as "systemctl cat"
+// prints them: each file preceded by a "# path" line, and a blank line
+// before the header of every file but the first. It implements
+// io.WriterTo.
+func (f *UnitFiles) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	write := func(path string, u *Unit, first bool) error {
+		header := "# " + path + "\n"
+		if !first {
+			header = "\n" + header
+		}
+		n, err := io.WriteString(w, header)
+		total += int64(n)
+		if err != nil {
+			return err
+		}
+		m, err := u.WriteTo(w)
+		total += m
+		return err
+	}
+	if err := write(f.Path, f.Unit, true); err != nil {
+		return total, err
+	}
+	for _, d := range f.DropIns {
+		if err := write(d.Path, d.Unit, false); err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// Load returns the named unit, e.g. "docker.service", merged with its
+// drop-ins: the files Files finds, merged by UnitFiles.Merge.
+func (l *Loader) Load(name string) (*Unit, error) {
+	files, err := l.Files(name)
+	if err != nil {
+		return nil, err
+	}
+	return files.Merge(), nil
+}
+
+// Files finds and parses the files of the named unit. The unit file is
+// the first one named name in the directories of the search path or, for
+// an instance of a template such as "getty@tty1.service" that has no
+// file of its own, the first one named after the template,
+// "getty@.service". The drop-ins are the files ending in ".conf" in
+// these directories of every directory of the search path, as in
+// systemd.unit(5):
+//
+//   - name.d, e.g. "foo-bar-baz.service.d" or "getty@tty1.service.d";
+//   - for an instance, the directory of the template, e.g.
+//     "getty@.service.d";
+//   - the directories of the prefixes of name ending in a dash, longest
+//     first, e.g. "foo-bar-.service.d" and "foo-.service.d";
+//   - the directory of the unit type, e.g. "service.d".
+//
+// Of drop-ins with the same file name only one counts: the one in the
+// directory with the highest priority, or in the first of the
+// directories above in the same directory of the search path. The
+// directory of the unit type has the lowest priority in every directory
+// of the search path. The drop-ins that count apply in lexical order of
+// their file names, wherever they are. Empty drop-ins and symlinks to
+// /dev/null are left out, and hide the drop-ins with the same file name
+// in lower priority directories.
+//
+// The error wraps ErrUnitNotFound when there is no unit file,
+// ErrUnitMasked when it is empty or a symlink to /dev/null, and
+// ErrInvalidUnitName when name is no valid unit name, see ParseUnitName.
+// Syntax errors are reported as by Deserialize.
+func (l *Loader) Files(name string) (*UnitFiles, error) {
+	n, err := ParseUnitName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	files := &UnitFiles{Name: name}
+	files.Path, err = l.unitFile(name)
+	if errors.Is(err, ErrUnitNotFound) && n.IsInstance() {
+		files.Path, err = l.unitFile(n.Template())
+	}
+	if errors.Is(err, ErrUnitNotFound) {
+		return nil, fmt.Errorf("%w: %s", ErrUnitNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if files.Unit, err = l.parseFile(files.Path); err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	for _, dir := range l.searchPath {
+		for _, dirName := range dropInDirNames(n) {
+			dirs = append(dirs, path.Join(dir, dirName))
+		}
+	}
+	for _, dir := range l.searchPath {
+		dirs = append(dirs, path.Join(dir, n.Type+".d"))
+	}
+	paths, err := l.dropInPaths(dirs)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range paths {
+		u, err := l.parseFile(file)
+		if err != nil {
+			return nil, err
+		}
+		files.DropIns = append(files.DropIns, &DropIn{Path: file, Unit: u})
+	}
+	return files, nil
+}
+
+// unitFile returns the first file with the given name in the
+// directories of the search path.
+func (l *Loader) unitFile(name string) (string, error) {
+	for _, dir := range l.searchPath {
+		file := path.Join(dir, name)
+		info, err := fs.Stat(l.fsys, fsName(file))
+		if errors.Is(err, fs.ErrNotExist) || err == nil && info.IsDir() {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if masks(info) {
+			return "", fmt.Errorf("%w: %s", ErrUnitMasked, file)
+		}
+		return file, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnitNotFound, name)
+}
+
+// dropInDirNames returns the names of the drop-in directories of the
+// unit other than that of its type, most specific first: that of the
+// name, that of the template of an instance and those of the prefixes
+// of the name ending in a dash. As in systemd, a dash at the end of the
+// prefix is not a prefix of its own, and neither is one at its start,
+// so "-.slice" has no prefixes.
+func dropInDirNames(n UnitName) []string {
+	names := []string{n.String() + ".d"}
+	if n.IsInstance() {
+		names = append(names, n.Template()+".d")
+	}
+	prefix := strings.TrimSuffix(n.Prefix, "-")
+	for {
+		i := strings.LastIndexByte(prefix, '-')
+		if i <= 0 {
+			return names
+		}
+		prefix = prefix[:i]
+		names = append(names, prefix+"-."+n.Type+".d")
+	}
+}
+
+// dropInPaths returns the drop-ins in the given directories, highest
+// priority first, in the order they apply, leaving out masked ones.
+func (l *Loader) dropInPaths(dirs []string) ([]string, error) {
+	// the drop-in with each file name in the directory with the highest
+	// priority, or "" when it is masked
+	found := map[string]string{}
+	for _, dropInDir := range dirs {
+		entries, err := fs.ReadDir(l.fsys, fsName(dropInDir))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if _, ok := found[e.Name()]; ok || !strings.HasSuffix(e.Name(), ".conf") {
+				continue
+			}
+			file := path.Join(dropInDir, e.Name())
+			info, err := fs.Stat(l.fsys, fsName(file))
+			if errors.Is(err, fs.ErrNotExist) || err == nil && info.IsDir() {
+				// a dangling symlink or a directory is no drop-in
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			found[e.Name()] = file
+			if masks(info) {
+				found[e.Name()] = ""
+			}
+		}
+	}
+
+	names := make([]string, 0, len(found))
+	for name, file := range found {
+		if file != "" {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	paths := make([]string, len(names))
+	for i, name := range names {
+		paths[i] = found[name]
+	}
+	return paths, nil
+}
+
+// masks reports whether a file masks a unit or drop-in: it is empty or,
+// like /dev/null, a character device.
+func masks(info fs.FileInfo) bool {
+	return info.Mode()&fs.ModeCharDevice != 0 || info.Mode().IsRegular() && info.Size() == 0
+}
+
+// fsName returns the name in a file system of l of an absolute path of
+// the search path.
+func fsName(p string) string {
+	if p = strings.TrimPrefix(path.Clean("/"+p), "/"); p == "" {
+		return "."
+	}
+	return p
+}
+
+// parseFile parses the named file of the file system of l as by
+// DeserializeLossless, with the path as the file name of syntax errors.
+func (l *Loader) parseFile(file string) (*Unit, error) {
+	f, err := l.fsys.Open(fsName(file))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return NewDecoder(f, WithLossless(), WithFilename(file)).Decode()
+}
@@ -0,0 +1,443 @@
+package systemdconfig
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+	"testing/fstest"
+)
+
+// writeFiles creates the files under dir, mapping slash-separated paths
+// to their content.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoader_Files(t *testing.T) {
+	root := t.TempDir()
+	etc, run, lib := filepath.Join(root, "etc"), filepath.Join(root, "run"), filepath.Join(root, "lib")
+	writeFiles(t, root, map[string]string{
+		"lib/app.service": "[Unit]\nDescription=App\n\n[Service]\nExecStart=/usr/bin/app\n",
+		// hidden by the unit file in etc
+		"lib/web.service":                   "[Unit]\nDescription=Vendor web\n",
+		"etc/web.service":                   "[Unit]\nDescription=Local web\n",
+		"lib/app.service.d/10-limits.conf":  "[Service]\nLimitNOFILE=1024\n",
+		"lib/app.service.d/override.conf":   "[Service]\nUser=vendor\n",
+		"lib/app.service.d/50-masked.conf":  "[Service]\nUser=masked\n",
+		"lib/app.service.d/README":          "not a drop-in\n",
+		"run/app.service.d/50-masked.conf":  "",
+		"etc/app.service.d/override.conf":   "# local override\n[Service]\nExecStart=\nExecStart=/usr/bin/app --verbose\n",
+		"etc/app.service.d/20-env.conf":     "[Service]\nEnvironment=A=1\n",
+		"etc/app.service.d/30-dir.conf/x":   "a directory is no drop-in\n",
+		"run/app.service.d/05-runtime.conf": "[Service]\nNice=5\n",
+	})
+	l := NewLoader(ScopeSystem, WithSearchPath(etc, run, lib))
+
+	files, err := l.Files("app.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files.Name != "app.service" || files.Path != filepath.Join(lib, "app.service") {
+		t.Errorf("Name, Path = %q, %q", files.Name, files.Path)
+	}
+	var paths []string
+	for _, d := range files.DropIns {
+		paths = append(paths, d.Path)
+	}
+	wantPaths := []string{
+		filepath.Join(run, "app.service.d", "05-runtime.conf"),
+		filepath.Join(lib, "app.service.d", "10-limits.conf"),
+		filepath.Join(etc, "app.service.d", "20-env.conf"),
+		filepath.Join(etc, "app.service.d", "override.conf"),
+	}
+	if !reflect.DeepEqual(paths, wantPaths) {
+		t.Errorf("drop-ins =\n%q\nwant\n%q", paths, wantPaths)
+	}
+
+	var b strings.Builder
+	if _, err := files.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	wantCat := "# " + filepath.Join(lib, "app.service") + "\n" +
+		"[Unit]\nDescription=App\n\n[Service]\nExecStart=/usr/bin/app\n" +
+		"\n# " + wantPaths[0] + "\n[Service]\nNice=5\n" +
+		"\n# " + wantPaths[1] + "\n[Service]\nLimitNOFILE=1024\n" +
+		"\n# " + wantPaths[2] + "\n[Service]\nEnvironment=A=1\n" +
+		"\n# " + wantPaths[3] + "\n# local override\n[Service]\nExecStart=\nExecStart=/usr/bin/app --verbose\n"
+	if b.String() != wantCat {
+		t.Errorf("WriteTo() =\n%s\nwant:\n%s", b.String(), wantCat)
+	}
+
+	u, err := l.Load("app.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := u.Values("Service", "ExecStart"); !reflect.DeepEqual(got, []string{"/usr/bin/app --verbose"}) {
+		t.Errorf("ExecStart = %q", got)
+	}
+	if _, ok := u.Value("Service", "User"); ok {
+		t.Errorf("User is set by a hidden or masked drop-in")
+	}
+
+	u, err = l.Load("web.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := u.Value("Unit", "Description"); v != "Local web" {
+		t.Errorf("Description = %q, want the one in etc", v)
+	}
+}
+
+func TestLoader_Errors(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"empty.service":         "",
+		"broken.service":        "Description=outside of a section\n",
+		"dir.service/x":         "",
+		"ok.service":            "[Unit]\n",
+		"ok.service.d/bad.conf": "Description=outside of a section\n",
+	})
+	if err := os.Symlink("/dev/null", filepath.Join(dir, "null.service")); err != nil {
+		t.Fatal(err)
+	}
+	l := NewLoader(ScopeSystem, WithSearchPath(dir))
+
+	tests := []struct {
+		name string
+		want error
+	}{
+		{"missing.service", ErrUnitNotFound},
+		{"dir.service", ErrUnitNotFound},
+		{"null.service", ErrUnitMasked},
+		{"empty.service", ErrUnitMasked},
+		{"", ErrInvalidUnitName},
+		{"../etc/passwd", ErrInvalidUnitName},
+		{"noext", ErrInvalidUnitName},
+		{"broken.service", ErrAssignmentOutsideSection},
+		{"ok.service", ErrAssignmentOutsideSection},
+	}
+	for _, tt := range tests {
+		if _, err := l.Load(tt.name); !errors.Is(err, tt.want) {
+			t.Errorf("Load(%q) error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestNewLoader_SearchPath(t *testing.T) {
+	t.Setenv("SYSTEMD_UNIT_PATH", "")
+	os.Unsetenv("SYSTEMD_UNIT_PATH")
+	if got := NewLoader(ScopeSystem).SearchPath(); !reflect.DeepEqual(got, systemSearchPath) {
+		t.Errorf("system search path = %q", got)
+	}
+
+	t.Setenv("HOME", "/home/u")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "relative/is/ignored")
+	t.Setenv("XDG_CONFIG_DIRS", "/etc/xdg:/opt/xdg")
+	t.Setenv("XDG_DATA_DIRS", "")
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	want := []string{
+		"/home/u/.config/systemd/user.control",
+		"/run/user/1000/systemd/user.control",
+		"/run/user/1000/systemd/transient",
+		"/run/user/1000/systemd/generator.early",
+		"/home/u/.config/systemd/user",
+		"/etc/xdg/systemd/user",
+		"/opt/xdg/systemd/user",
+		"/etc/systemd/user",
+		"/run/user/1000/systemd/user",
+		"/run/systemd/user",
+		"/run/user/1000/systemd/generator",
+		"/home/u/.local/share/systemd/user",
+		"/usr/local/share/systemd/user",
+		"/usr/share/systemd/user",
+		"/usr/local/lib/systemd/user",
+		"/usr/lib/systemd/user",
+		"/run/user/1000/systemd/generator.late",
+	}
+	if got := NewLoader(ScopeUser).SearchPath(); !reflect.DeepEqual(got, want) {
+		t.Errorf("user search path =\n%q\nwant\n%q", got, want)
+	}
+
+	t.Setenv("SYSTEMD_UNIT_PATH", "/a:/b")
+	if got := NewLoader(ScopeSystem).SearchPath(); !reflect.DeepEqual(got, []string{"/a", "/b"}) {
+		t.Errorf("search path with SYSTEMD_UNIT_PATH = %q", got)
+	}
+	t.Setenv("SYSTEMD_UNIT_PATH", "/a:")
+	if got := NewLoader(ScopeSystem).SearchPath(); len(got) != len(systemSearchPath)+1 || got[0] != "/a" {
+		t.Errorf("search path with SYSTEMD_UNIT_PATH ending in ':' = %q", got)
+	}
+	if got := NewLoader(ScopeSystem, WithSearchPath("/x")).SearchPath(); !reflect.DeepEqual(got, []string{"/x"}) {
+		t.Errorf("search path WithSearchPath = %q", got)
+	}
+}
+
+func TestLoader_WithRoot(t *testing.T) {
+	t.Setenv("SYSTEMD_UNIT_PATH", "")
+	os.Unsetenv("SYSTEMD_UNIT_PATH")
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"usr/lib/systemd/system/app.service": "[Unit]\nDescription=App\n",
+		"usr/lib/app/dropins/10-a.conf":      "[Unit]\nDocumentation=man:app(8)\n",
+	})
+	for link, target := range map[string]string{
+		// absolute targets are in the root, not on the host
+		"etc/systemd/system/alias.service": "/usr/lib/systemd/system/app.service",
+		// ".." does not lead above the root
+		"etc/systemd/system/up.service":       "../../../../../../usr/lib/systemd/system/app.service",
+		"etc/systemd/system/app.service.d":    "../../../usr/lib/app/dropins",
+		"etc/systemd/system/masked.service":   "/dev/null",
+		"etc/systemd/system/loop.service":     "loop.service",
+		"etc/systemd/system/dangling.service": "/nonexistent",
+	} {
+		path := filepath.Join(root, link)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(target, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	l := NewLoader(ScopeSystem, WithRoot(root))
+
+	files, err := l.Files("app.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files.Path != "/usr/lib/systemd/system/app.service" {
+		t.Errorf("Path = %q", files.Path)
+	}
+	if len(files.DropIns) != 1 || files.DropIns[0].Path != "/etc/systemd/system/app.service.d/10-a.conf" {
+		t.Errorf("DropIns = %v", files.DropIns)
+	}
+	for _, name := range []string{"alias.service", "up.service"} {
+		u, err := l.Load(name)
+		if err != nil {
+			t.Errorf("Load(%q) error = %v", name, err)
+			continue
+		}
+		if v, _ := u.Value("Unit", "Description"); v != "App" {
+			t.Errorf("Load(%q) Description = %q", name, v)
+		}
+	}
+	for name, want := range map[string]error{
+		"masked.service":   ErrUnitMasked,
+		"loop.service":     syscall.ELOOP,
+		"dangling.service": ErrUnitNotFound,
+	} {
+		if _, err := l.Load(name); !errors.Is(err, want) {
+			t.Errorf("Load(%q) error = %v, want %v", name, err, want)
+		}
+	}
+}
+
+func TestLoader_WithFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"usr/lib/systemd/system/app.service":             {Data: []byte("[Service]\nExecStart=/usr/bin/app\n")},
+		"usr/lib/systemd/system/app.service.d/a.conf":    {Data: []byte("[Service]\nUser=a\n")},
+		"usr/lib/systemd/system/app.service.d/b.conf":    {Data: []byte("[Service]\nNice=1\n")},
+		"etc/systemd/system/app.service.d/a.conf":        {Mode: fs.ModeCharDevice | fs.ModeDevice},
+		"etc/systemd/system/app.service.d/c.conf":        {Data: []byte("[Service]\nNice=2\n")},
+		"etc/systemd/system/masked.service":              {},
+		"usr/lib/systemd/system/masked.service":          {Data: []byte("[Unit]\n")},
+		"usr/lib/systemd/system/syntax.service":          {Data: []byte("Nice=1\n")},
+		"usr/lib/systemd/system/syntax.service.d/x.conf": {Data: []byte("[Service]\n")},
+	}
+	l := NewLoader(ScopeSystem, WithFS(fsys), WithSearchPath("/etc/systemd/system", "/usr/lib/systemd/system"))
+
+	files, err := l.Files("app.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, d := range files.DropIns {
+		paths = append(paths, d.Path)
+	}
+	want := []string{"/usr/lib/systemd/system/app.service.d/b.conf", "/etc/systemd/system/app.service.d/c.conf"}
+	if files.Path != "/usr/lib/systemd/system/app.service" || !reflect.DeepEqual(paths, want) {
+		t.Errorf("Path, DropIns = %q, %q", files.Path, paths)
+	}
+	if v, _ := files.Merge().Value("Service", "Nice"); v != "2" {
+		t.Errorf("Nice = %q, want 2", v)
+	}
+
+	if _, err := l.Load("masked.service"); !errors.Is(err, ErrUnitMasked) {
+		t.Errorf("Load(masked.service) error = %v, want %v", err, ErrUnitMasked)
+	}
+	var perr *ParseError
+	if _, err := l.Load("syntax.service"); !errors.As(err, &perr) || !strings.HasPrefix(err.Error(), "/usr/lib/systemd/system/syntax.service:1:1: ") {
+		t.Errorf("Load(syntax.service) error = %v, want a *ParseError with the path", err)
+	}
+}
+
+func TestDropInDirNames(t *testing.T) {
+	tests := []struct {
+		name string
+		want []string
+	}{
+		{"foo.service", []string{"foo.service.d"}},
+		{"foo-bar-baz.service", []string{"foo-bar-baz.service.d", "foo-bar-.service.d", "foo-.service.d"}},
+		{"foo-bar-.service", []string{"foo-bar-.service.d", "foo-.service.d"}},
+		{"foo-.service", []string{"foo-.service.d"}},
+		{"a--b.service", []string{"a--b.service.d", "a--.service.d", "a-.service.d"}},
+		{"-.slice", []string{"-.slice.d"}},
+		{"-foo.mount", []string{"-foo.mount.d"}},
+		{"user-1000.slice", []string{"user-1000.slice.d", "user-.slice.d"}},
+		{"foo-bar@baz-qux.service", []string{"foo-bar@baz-qux.service.d", "foo-bar@.service.d", "foo-.service.d"}},
+		{"foo-bar@.service", []string{"foo-bar@.service.d", "foo-.service.d"}},
+		{"getty@tty1.service", []string{"getty@tty1.service.d", "getty@.service.d"}},
+	}
+	for _, tt := range tests {
+		n, err := ParseUnitName(tt.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := dropInDirNames(n); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("dropInDirNames(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLoader_HierarchicalDropIns(t *testing.T) {
+	file := func(content string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(content)} }
+	fsys := fstest.MapFS{
+		"lib/foo-bar-baz.service": file("[Service]\nExecStart=/bin/true\n"),
+		"lib/foo-bar.service":     file("[Service]\nExecStart=/bin/true\n"),
+		"lib/foo.socket":          file("[Socket]\nListenStream=80\n"),
+
+		// the exact name beats the prefixes in the same directory
+		"lib/foo-bar-baz.service.d/10-same.conf": file("[Service]\nNice=1\n"),
+		"lib/foo-bar-.service.d/10-same.conf":    file("[Service]\nNice=2\n"),
+		"lib/foo-.service.d/10-same.conf":        file("[Service]\nNice=3\n"),
+		// but a prefix in a directory with a higher priority beats them
+		"etc/foo-.service.d/20-prio.conf":        file("[Service]\nUser=etc\n"),
+		"lib/foo-bar-baz.service.d/20-prio.conf": file("[Service]\nUser=lib\n"),
+		"lib/foo-bar-.service.d/30-prefix.conf":  file("[Service]\nGroup=foo-bar\n"),
+		// the type-wide directory has the lowest priority everywhere
+		"etc/service.d/40-type.conf":            file("[Service]\nNice=10\n"),
+		"lib/foo-.service.d/40-type.conf":       file("[Service]\nNice=4\n"),
+		"etc/service.d/50-all.conf":             file("[Unit]\nDescription=any service\n"),
+		"etc/foo-bar-baz.service.d/50-all.conf": file(""),
+		"lib/service.d/05-first.conf":           file("[Service]\nNice=0\n"),
+		"run/socket.d/10-socket.conf":           file("[Socket]\nBacklog=10\n"),
+	}
+	l := NewLoader(ScopeSystem, WithFS(fsys), WithSearchPath("/etc", "/run", "/lib"))
+
+	paths := func(name string) []string {
+		t.Helper()
+		files, err := l.Files(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var paths []string
+		for _, d := range files.DropIns {
+			paths = append(paths, d.Path)
+		}
+		return paths
+	}
+	want := []string{
+		"/lib/service.d/05-first.conf",
+		"/lib/foo-bar-baz.service.d/10-same.conf",
+		"/etc/foo-.service.d/20-prio.conf",
+		"/lib/foo-bar-.service.d/30-prefix.conf",
+		"/lib/foo-.service.d/40-type.conf",
+	}
+	if got := paths("foo-bar-baz.service"); !reflect.DeepEqual(got, want) {
+		t.Errorf("drop-ins of foo-bar-baz.service =\n%q\nwant\n%q", got, want)
+	}
+	want = []string{
+		"/lib/service.d/05-first.conf",
+		"/lib/foo-.service.d/10-same.conf",
+		"/etc/foo-.service.d/20-prio.conf",
+		"/lib/foo-.service.d/40-type.conf",
+		"/etc/service.d/50-all.conf",
+	}
+	if got := paths("foo-bar.service"); !reflect.DeepEqual(got, want) {
+		t.Errorf("drop-ins of foo-bar.service =\n%q\nwant\n%q", got, want)
+	}
+	if got, want := paths("foo.socket"), []string{"/run/socket.d/10-socket.conf"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("drop-ins of foo.socket = %q, want %q", got, want)
+	}
+
+	u, err := l.Load("foo-bar-baz.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for option, want := range map[string]string{"Nice": "4", "User": "etc", "Group": "foo-bar"} {
+		if got, _ := u.Value("Service", option); got != want {
+			t.Errorf("%s = %q, want %q", option, got, want)
+		}
+	}
+}
+
+func TestLoader_Templates(t *testing.T) {
+	file := func(content string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(content)} }
+	fsys := fstest.MapFS{
+		"lib/getty@.service":                 file("[Service]\nExecStart=-/sbin/agetty %I $TERM\n"),
+		"lib/getty@.service.d/10-a.conf":     file("[Service]\nNice=1\n"),
+		"etc/getty@.service.d/20-b.conf":     file("[Service]\nNice=2\n"),
+		"lib/getty@tty1.service.d/20-b.conf": file("[Service]\nNice=3\n"),
+		"lib/getty@tty2.service":             file("[Service]\nExecStart=/sbin/agetty tty2\n"),
+		"etc/getty@tty3.service":             {},
+		"lib/masked@.service":                file("[Service]\n"),
+		"etc/masked@.service":                {},
+	}
+	l := NewLoader(ScopeSystem, WithFS(fsys), WithSearchPath("/etc", "/lib"))
+
+	files, err := l.Files("getty@tty1.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for _, d := range files.DropIns {
+		paths = append(paths, d.Path)
+	}
+	// a drop-in of the template in a directory with a higher priority
+	// beats the one of the instance with the same name
+	want := []string{"/lib/getty@.service.d/10-a.conf", "/etc/getty@.service.d/20-b.conf"}
+	if files.Name != "getty@tty1.service" || files.Path != "/lib/getty@.service" || !reflect.DeepEqual(paths, want) {
+		t.Errorf("Name, Path, DropIns = %q, %q, %q", files.Name, files.Path, paths)
+	}
+
+	// an instance with a file of its own is loaded from it
+	files, err = l.Files("getty@tty2.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files.Path != "/lib/getty@tty2.service" || len(files.DropIns) != 2 {
+		t.Errorf("Path, DropIns = %q, %v", files.Path, files.DropIns)
+	}
+
+	// the template itself can be loaded too
+	if _, err := l.Files("getty@.service"); err != nil {
+		t.Errorf("Files(getty@.service) error = %v", err)
+	}
+
+	for name, want := range map[string]error{
+		"getty@tty3.service":  ErrUnitMasked,
+		"masked@x.service":    ErrUnitMasked,
+		"missing@x.service":   ErrUnitNotFound,
+		"getty@tty 4.service": ErrInvalidUnitName,
+	} {
+		if _, err := l.Load(name); !errors.Is(err, want) {
+			t.Errorf("Load(%q) error = %v, want %v", name, err, want)
+		}
+	}
+	if _, err := l.Load("missing@x.service"); err == nil || !strings.Contains(err.Error(), "missing@x.service") {
+		t.Errorf("Load(missing@x.service) error = %v, want it to name the instance", err)
+	}
+}
@@ -0,0 +1,614 @@
+package systemdconfig
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidTag is wrapped by the error Marshal and Unmarshal return
+	// for a malformed systemd struct tag.
+	ErrInvalidTag = errors.New("invalid systemd struct tag")
+	// ErrUnsupportedType is wrapped by the error Marshal and Unmarshal
+	// return for a tagged field of a type they cannot convert.
+	ErrUnsupportedType = errors.New("unsupported type")
+)
+
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	extraSectionsType   = reflect.TypeFor[[]*Section]()
+	extraOptionsType    = reflect.TypeFor[[]*OptionValue]()
+)
+
+// structField is a tagged field of a struct, either an option or, in the
+// top-level struct only, a section.
+type structField struct {
+	index []int
+	name  string
+	// section is the section of an option of the top-level struct, empty
+	// for an option of a section struct and for a section
+	section   string
+	isSection bool
+	omitEmpty bool
+	// words splits every value of a list into words, commas at commas
+	// and whitespace
+	words, commas bool
+	// extra holds the sections or options no other field holds
+	extra bool
+}
+
+// structFields returns the tagged fields of the struct type t, including
+// those of untagged embedded structs. The tags of a section struct name
+// options, those of the top-level one name "Section.Option" or a section.
+func structFields(t reflect.Type, top bool) ([]structField, error) {
+	var fields []structField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, ok := f.Tag.Lookup("systemd")
+		if !ok && f.Anonymous && f.Type.Kind() == reflect.Struct {
+			embedded, err := structFields(f.Type, top)
+			if err != nil {
+				return nil, err
+			}
+			for _, sf := range embedded {
+				sf.index = append([]int{i}, sf.index...)
+				fields = append(fields, sf)
+			}
+			continue
+		}
+		if !ok || tag == "-" {
+			continue
+		}
+		invalid := func(reason string) ([]structField, error) {
+			return nil, fmt.Errorf("%w %q on field %s of %s: %s", ErrInvalidTag, tag, f.Name, t, reason)
+		}
+		if !f.IsExported() {
+			return invalid("field is not exported")
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		sf := structField{index: []int{i}, name: name}
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "":
+			case "omitempty":
+				sf.omitEmpty = true
+			case "words":
+				sf.words = true
+			case "commas":
+				sf.commas = true
+			case "extra":
+				sf.extra = true
+			default:
+				return invalid(fmt.Sprintf("unknown option %q", opt))
+			}
+		}
+
+		if sf.extra {
+			want := extraOptionsType
+			if top {
+				want = extraSectionsType
+			}
+			if name != "" || f.Type != want {
+				return invalid(fmt.Sprintf("extra must be an unnamed %s", want))
+			}
+			fields = append(fields, sf)
+			continue
+		}
+		if (sf.words || sf.commas) && !isList(f.Type) {
+			return invalid("words and commas need a slice")
+		}
+		if sf.words && sf.commas {
+			return invalid("words and commas exclude each other")
+		}
+
+		if top {
+			// option names cannot contain a dot, section names can
+			if i := strings.LastIndexByte(name, '.'); i >= 0 {
+				sf.section, sf.name = name[:i], name[i+1:]
+			} else {
+				sf.isSection = true
+				if sectionType(f.Type) == nil {
+					return invalid("a section must be a struct, a pointer to one or a slice of them")
+				}
+			}
+		} else if strings.Contains(name, ".") {
+			return invalid("options of a section struct are named without their section")
+		}
+		if sf.name == "" || top && !sf.isSection && sf.section == "" {
+			return invalid("empty name")
+		}
+		fields = append(fields, sf)
+	}
+	return fields, nil
+}
+
+// sectionType returns the struct type of a field that holds a section: a
+// struct, a pointer to one or a slice of them, but not a struct
+// converted as text, such as time.Time. It returns nil for other types.
+func sectionType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Slice || t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || isText(t) {
+		return nil
+	}
+	return t
+}
+
+// isList reports whether a field of type t holds a list of values, one
+// per assignment: a slice other than a []byte or a type converted as
+// text.
+func isList(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 && !isText(t)
+}
+
+// isText reports whether values of type t convert to text themselves.
+func isText(t reflect.Type) bool {
+	return t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) ||
+		t.Implements(textUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// Marshal returns a unit holding the tagged fields of the struct v, or
+// of the struct v points to.
+//
+// The tag of a field of v is either "Section.Option", for an option, or
+// the name of a section, for a struct, a pointer to a struct or a slice
+// of structs whose fields are tagged with option names; a slice holds
+// duplicate sections, such as the [Address] sections of a .network
+// file. The fields of an untagged embedded struct count as fields of
+// the struct embedding it. A tag may be followed by ",omitempty", which
+// leaves out a field with the zero value, and the tag "-" or no tag at
+// all leaves out a field. A list tagged ",words" has its elements
+// quoted with QuoteWord, and Unmarshal splits each of its values into
+// words with SplitWords, for options such as After= that take a
+// space-separated list, and a list tagged ",commas" has each value
+// split at commas and whitespace, for options such as AllowedIPs=. An
+// unnamed field tagged ",extra" holds what no other field does: a
+// []*Section in v holds the other sections, and a []*OptionValue in a
+// section struct the other options of the section; Marshal appends
+// copies of them, skipping nil entries.
+//
+//	type Network struct {
+//		Name      string    `systemd:"Match.Name"`
+//		DNS       []string  `systemd:"Network.DNS,omitempty"`
+//		Addresses []Address `systemd:"Address"`
+//	}
+//
+//	type Address struct {
+//		Address string `systemd:"Address"`
+//	}
+//
+// An option field may be a string, a bool ("yes" or "no"), an integer, a
+// float, a time.Duration (as formatted by FormatTimespan), a type
+// implementing encoding.TextMarshaler, a pointer to one of these, which
+// is left out when nil, or a slice of them, written as one assignment
+// per element. Sections and options are added in the order of the fields;
+// options of the same section share one section, and every element of a
+// slice of sections gets a section of its own.
+func Marshal(v any) (*Unit, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("systemdconfig: Marshal of %T: %w, want a struct", v, ErrUnsupportedType)
+	}
+	// an addressable copy, so that pointer receivers of MarshalText work
+	addressable := reflect.New(rv.Type()).Elem()
+	addressable.Set(rv)
+	rv = addressable
+
+	fields, err := structFields(rv.Type(), true)
+	if err != nil {
+		return nil, err
+	}
+	u := NewUnit()
+	// shared are the sections options and single section structs go to
+	shared := map[string]*Section{}
+	section := func(name string) *Section {
+		if shared[name] == nil {
+			shared[name] = u.AddSection(name)
+		}
+		return shared[name]
+	}
+
+	for _, f := range fields {
+		fv := rv.FieldByIndex(f.index)
+		if f.omitEmpty && fv.IsZero() {
+			continue
+		}
+		if f.extra {
+			for _, extra := range fv.Interface().([]*Section) {
+				if extra == nil {
+					continue
+				}
+				s := u.AddSection(extra.Name)
+				addOptions(s, extra.Options)
+			}
+			continue
+		}
+		if !f.isSection {
+			if err := marshalOption(section(f.section), f, fv); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		switch fv.Kind() {
+		case reflect.Slice:
+			for i := range fv.Len() {
+				if err := marshalSection(u.AddSection(f.name), fv.Index(i)); err != nil {
+					return nil, err
+				}
+			}
+		case reflect.Pointer:
+			if !fv.IsNil() {
+				if err := marshalSection(section(f.name), fv.Elem()); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			if err := marshalSection(section(f.name), fv); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return u, nil
+}
+
+// marshalSection adds the tagged fields of the section struct sv to s.
+func marshalSection(s *Section, sv reflect.Value) error {
+	fields, err := structFields(sv.Type(), false)
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		fv := sv.FieldByIndex(f.index)
+		if f.omitEmpty && fv.IsZero() {
+			continue
+		}
+		if f.extra {
+			addOptions(s, fv.Interface().([]*OptionValue))
+			continue
+		}
+		if err := marshalOption(s, f, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addOptions adds copies of options, which may contain nil entries, to
+// s.
+func addOptions(s *Section, options []*OptionValue) {
+	for _, option := range options {
+		if option != nil {
+			s.AddOption(option.Option, option.Value)
+		}
+	}
+}
+
+// marshalOption adds the assignments of the option field f, whose value
+// is fv, to s.
+func marshalOption(s *Section, f structField, fv reflect.Value) error {
+	switch {
+	case fv.Kind() == reflect.Pointer:
+		if fv.IsNil() {
+			return nil
+		}
+		fv = fv.Elem()
+	case isList(fv.Type()):
+		for i := range fv.Len() {
+			if err := marshalOption(s, f, fv.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	value, err := marshalValue(fv)
+	if err != nil {
+		return fmt.Errorf("systemdconfig: %s.%s: %w", s.Name, f.name, err)
+	}
+	if f.words {
+		value = QuoteWord(value)
+	}
+	s.AddOption(f.name, value)
+	return nil
+}
+
+// marshalValue formats a single value of an option.
+func marshalValue(v reflect.Value) (string, error) {
+	if v.CanAddr() && reflect.PointerTo(v.Type()).Implements(textMarshalerType) {
+		v = v.Addr()
+	}
+	if v.Type().Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+	if v.Type() == durationType {
+		return FormatTimespan(time.Duration(v.Int())), nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	case reflect.Slice:
+		// a []byte, as a string
+		return string(v.Bytes()), nil
+	}
+	return "", fmt.Errorf("%w %s", ErrUnsupportedType, v.Type())
+}
+
+// optionSource looks up the options of a section.
+type optionSource interface {
+	Value(option string) (string, bool)
+	Values(option string) []string
+	options() []*OptionValue
+}
+
+// unitSection looks up options across every section with the same name,
+// as Unit.Value and Unit.Values do.
+type unitSection struct {
+	u    *Unit
+	name string
+}
+
+func (s unitSection) Value(option string) (string, bool) {
+	return s.u.Value(s.name, option)
+}
+
+func (s unitSection) Values(option string) []string {
+	return s.u.Values(s.name, option)
+}
+
+func (s unitSection) options() []*OptionValue {
+	var options []*OptionValue
+	for _, section := range s.u.SectionsByName(s.name) {
+		options = append(options, section.Options...)
+	}
+	return options
+}
+
+// singleSection looks up the options of one section.
+type singleSection struct {
+	*Section
+}
+
+func (s singleSection) options() []*OptionValue {
+	return s.Options
+}
+
+// Unmarshal stores the options of u in the tagged fields of the struct v
+// points to, the reverse of Marshal.
+//
+// An option field gets the value in effect, that of the last assignment;
+// an empty value resets it to the zero value. A slice field gets every
+// value, where an empty value resets the list as in systemd. A struct or
+// pointer section field gets the options of every section with its name,
+// and a slice of sections one element per section. Fields of options and
+// sections u does not have are left unchanged. The ",extra" fields get
+// the sections and options of u themselves, not copies. A value that
+// cannot be parsed yields a *ValueError.
+func Unmarshal(u *Unit, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("systemdconfig: Unmarshal into %T: %w, want a non-nil pointer to a struct", v, ErrUnsupportedType)
+	}
+	rv = rv.Elem()
+
+	fields, err := structFields(rv.Type(), true)
+	if err != nil {
+		return err
+	}
+	// held are the sections fields other than ",extra" hold
+	held := map[string]bool{}
+	for _, f := range fields {
+		switch {
+		case f.isSection:
+			held[f.name] = true
+		case !f.extra:
+			held[f.section] = true
+		}
+	}
+	for _, f := range fields {
+		fv := rv.FieldByIndex(f.index)
+		if f.extra {
+			var extra []*Section
+			for _, s := range u.Sections {
+				if !held[s.Name] {
+					extra = append(extra, s)
+				}
+			}
+			fv.Set(reflect.ValueOf(extra))
+			continue
+		}
+		if !f.isSection {
+			if err := unmarshalOption(unitSection{u, f.section}, f.section, f, fv); err != nil {
+				return err
+			}
+			continue
+		}
+		sections := u.SectionsByName(f.name)
+		if len(sections) == 0 {
+			continue
+		}
+		switch fv.Kind() {
+		case reflect.Slice:
+			elems := reflect.MakeSlice(fv.Type(), len(sections), len(sections))
+			for i, s := range sections {
+				if err := unmarshalSection(singleSection{s}, f.name, elems.Index(i)); err != nil {
+					return err
+				}
+			}
+			fv.Set(elems)
+		case reflect.Pointer:
+			if fv.IsNil() {
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			if err := unmarshalSection(unitSection{u, f.name}, f.name, fv.Elem()); err != nil {
+				return err
+			}
+		default:
+			if err := unmarshalSection(unitSection{u, f.name}, f.name, fv); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// unmarshalSection stores the options of src in the tagged fields of the
+// section struct sv.
+func unmarshalSection(src optionSource, section string, sv reflect.Value) error {
+	fields, err := structFields(sv.Type(), false)
+	if err != nil {
+		return err
+	}
+	held := map[string]bool{}
+	for _, f := range fields {
+		held[f.name] = !f.extra
+	}
+	for _, f := range fields {
+		fv := sv.FieldByIndex(f.index)
+		if f.extra {
+			var extra []*OptionValue
+			for _, option := range src.options() {
+				if !held[option.Option] {
+					extra = append(extra, option)
+				}
+			}
+			fv.Set(reflect.ValueOf(extra))
+			continue
+		}
+		if err := unmarshalOption(src, section, f, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unmarshalOption stores the value or values of the option field f of
+// src in fv.
+func unmarshalOption(src optionSource, section string, f structField, fv reflect.Value) error {
+	option := f.name
+	if isList(fv.Type()) {
+		values := src.Values(option)
+		if values == nil {
+			return nil
+		}
+		elems := reflect.MakeSlice(fv.Type(), 0, len(values))
+		for _, value := range values {
+			if value == "" {
+				elems = elems.Slice(0, 0)
+				continue
+			}
+			words := []string{value}
+			switch {
+			case f.words:
+				var err error
+				if words, err = SplitWords(value); err != nil {
+					return &ValueError{Section: section, Option: option, Value: value, Err: err}
+				}
+			case f.commas:
+				words = strings.FieldsFunc(value, func(r rune) bool {
+					return r == ',' || strings.ContainsRune(whitespace, r)
+				})
+			}
+			for _, word := range words {
+				elem := reflect.New(fv.Type().Elem()).Elem()
+				target := elem
+				if target.Kind() == reflect.Pointer {
+					target.Set(reflect.New(target.Type().Elem()))
+					target = target.Elem()
+				}
+				if err := unmarshalValue(target, word); err != nil {
+					return &ValueError{Section: section, Option: option, Value: value, Err: err}
+				}
+				elems = reflect.Append(elems, elem)
+			}
+		}
+		fv.Set(elems)
+		return nil
+	}
+
+	value, ok := src.Value(option)
+	if !ok {
+		return nil
+	}
+	if value == "" {
+		fv.SetZero()
+		return nil
+	}
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		fv = fv.Elem()
+	}
+	if err := unmarshalValue(fv, value); err != nil {
+		return &ValueError{Section: section, Option: option, Value: value, Err: err}
+	}
+	return nil
+}
+
+// unmarshalValue parses a single value of an option into v, which is
+// addressable.
+func unmarshalValue(v reflect.Value, value string) error {
+	if reflect.PointerTo(v.Type()).Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	}
+	if v.Type() == durationType {
+		d, err := ParseTimespan(value)
+		v.SetInt(int64(d))
+		return err
+	}
+
+	trimmed := strings.Trim(value, whitespace)
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(trimmed, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%w %q", ErrInvalidInt, value)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(trimmed, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%w %q", ErrInvalidInt, value)
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(trimmed, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		v.SetBytes([]byte(value))
+	default:
+		return fmt.Errorf("%w %s", ErrUnsupportedType, v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,352 @@
+package systemdconfig
+
+import (
+	"errors"
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testService struct {
+	Description string        `systemd:"Unit.Description"`
+	After       []string      `systemd:"Unit.After,omitempty"`
+	Service     testExec      `systemd:"Service"`
+	Install     *testInstall  `systemd:"Install"`
+	Addresses   []testAddress `systemd:"Address"`
+	Ignored     string
+	Skipped     string `systemd:"-"`
+}
+
+type testExec struct {
+	Type       string        `systemd:"Type"`
+	ExecStart  []string      `systemd:"ExecStart"`
+	Restart    *string       `systemd:"Restart"`
+	RemainExit bool          `systemd:"RemainAfterExit"`
+	Timeout    time.Duration `systemd:"TimeoutStartSec,omitempty"`
+	Nice       int           `systemd:"Nice,omitempty"`
+	Weight     uint16        `systemd:"CPUWeight,omitempty"`
+	Mode       testMode      `systemd:"X-Mode,omitempty"`
+}
+
+type testInstall struct {
+	WantedBy []string `systemd:"WantedBy"`
+}
+
+type testAddress struct {
+	Address netip.Prefix `systemd:"Address"`
+	Peer    *netip.Addr  `systemd:"Peer"`
+}
+
+// testMode implements encoding.TextMarshaler with a pointer receiver.
+type testMode struct{ on bool }
+
+func (m *testMode) MarshalText() ([]byte, error) {
+	if m.on {
+		return []byte("on"), nil
+	}
+	return []byte("off"), nil
+}
+
+func (m *testMode) UnmarshalText(text []byte) error {
+	m.on = string(text) == "on"
+	return nil
+}
+
+const testServiceText = `[Unit]
+Description=Test service
+After=network.target
+After=local-fs.target
+
+[Service]
+Type=oneshot
+ExecStart=/bin/true
+ExecStart=/bin/false
+Restart=on-failure
+RemainAfterExit=yes
+TimeoutStartSec=1min 30s
+Nice=-5
+CPUWeight=200
+X-Mode=on
+
+[Install]
+WantedBy=multi-user.target
+
+[Address]
+Address=10.0.0.1/24
+Peer=10.0.0.2
+
+[Address]
+Address=fd00::1/64
+`
+
+func TestMarshal(t *testing.T) {
+	restart := "on-failure"
+	peer := netip.MustParseAddr("10.0.0.2")
+	v := testService{
+		Description: "Test service",
+		After:       []string{"network.target", "local-fs.target"},
+		Service: testExec{
+			Type:       "oneshot",
+			ExecStart:  []string{"/bin/true", "/bin/false"},
+			Restart:    &restart,
+			RemainExit: true,
+			Timeout:    90 * time.Second,
+			Nice:       -5,
+			Weight:     200,
+			Mode:       testMode{on: true},
+		},
+		Install: &testInstall{WantedBy: []string{"multi-user.target"}},
+		Addresses: []testAddress{
+			{Address: netip.MustParsePrefix("10.0.0.1/24"), Peer: &peer},
+			{Address: netip.MustParsePrefix("fd00::1/64")},
+		},
+		Ignored: "x",
+		Skipped: "y",
+	}
+
+	for _, in := range []any{v, &v} {
+		u, err := Marshal(in)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		if got := u.String(); got != testServiceText {
+			t.Errorf("Marshal() =\n%s\nwant:\n%s", got, testServiceText)
+		}
+	}
+
+	var got testService
+	u, err := Deserialize(strings.NewReader(testServiceText))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Unmarshal(u, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	v.Ignored, v.Skipped = "", ""
+	if !reflect.DeepEqual(got, v) {
+		t.Errorf("Unmarshal() = %+v, want %+v", got, v)
+	}
+}
+
+func TestMarshal_Empty(t *testing.T) {
+	u, err := Marshal(testService{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "[Unit]\nDescription=\n\n[Service]\nType=\nRemainAfterExit=no\n"
+	if got := u.String(); got != want {
+		t.Errorf("Marshal() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestUnmarshal_Semantics(t *testing.T) {
+	u, err := Deserialize(strings.NewReader(`[Unit]
+Description=First
+After=a.target
+[Service]
+ExecStart=/bin/a
+ExecStart=
+ExecStart=/bin/b
+Nice=5
+[Unit]
+Description=Second
+[Service]
+Nice=
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := testService{Description: "kept", Service: testExec{Type: "kept", Nice: 1}}
+	if err := Unmarshal(u, &got); err != nil {
+		t.Fatal(err)
+	}
+	want := testService{
+		Description: "Second",
+		After:       []string{"a.target"},
+		Service:     testExec{Type: "kept", ExecStart: []string{"/bin/b"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmarshal() = %+v, want %+v", got, want)
+	}
+}
+
+func TestUnmarshal_ValueError(t *testing.T) {
+	u, err := Deserialize(strings.NewReader("[Service]\nRemainAfterExit=maybe\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v testService
+	err = Unmarshal(u, &v)
+	var verr *ValueError
+	if !errors.As(err, &verr) || verr.Section != "Service" || verr.Option != "RemainAfterExit" || !errors.Is(err, ErrInvalidBool) {
+		t.Errorf("Unmarshal() error = %v, want a *ValueError wrapping ErrInvalidBool", err)
+	}
+}
+
+func TestMarshal_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+		want error
+	}{
+		{"NotStruct", "x", ErrUnsupportedType},
+		{"NilPointer", (*testService)(nil), ErrUnsupportedType},
+		{"UnsupportedType", struct {
+			M map[string]string `systemd:"Service.M"`
+		}{M: map[string]string{}}, ErrUnsupportedType},
+		{"SectionNotStruct", struct {
+			S string `systemd:"Service"`
+		}{}, ErrInvalidTag},
+		{"DottedOptionInSection", struct {
+			S struct {
+				A string `systemd:"Service.A"`
+			} `systemd:"Service"`
+		}{}, ErrInvalidTag},
+		{"EmptySection", struct {
+			A string `systemd:".A"`
+		}{}, ErrInvalidTag},
+		{"UnknownOption", struct {
+			A string `systemd:"Service.A,omitnil"`
+		}{}, ErrInvalidTag},
+		{"Unexported", struct {
+			a string `systemd:"Service.A"`
+		}{}, ErrInvalidTag},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Marshal(tt.v); !errors.Is(err, tt.want) {
+				t.Errorf("Marshal() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if err := Unmarshal(NewUnit(), testService{}); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Unmarshal(non-pointer) error = %v, want ErrUnsupportedType", err)
+	}
+}
+
+func TestMarshal_NilExtra(t *testing.T) {
+	type service struct {
+		Type  string         `systemd:"Type"`
+		Extra []*OptionValue `systemd:",extra"`
+	}
+	custom := NewSection("X-Custom")
+	custom.Options = []*OptionValue{nil, NewOptionValue("Key", "value")}
+	v := struct {
+		Service service    `systemd:"Service"`
+		Extra   []*Section `systemd:",extra"`
+	}{
+		Service: service{Type: "oneshot", Extra: []*OptionValue{nil, NewOptionValue("RemainAfterExit", "yes"), nil}},
+		Extra:   []*Section{nil, custom, nil},
+	}
+	u, err := Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "[Service]\nType=oneshot\nRemainAfterExit=yes\n\n[X-Custom]\nKey=value\n"
+	if got := u.String(); got != want {
+		t.Errorf("Marshal() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMarshal_WordsExtraEmbedded(t *testing.T) {
+	type environment struct {
+		Environment []string `systemd:"Environment,words"`
+	}
+	type exec struct {
+		environment
+		Extra []*OptionValue `systemd:",extra"`
+	}
+	type unit struct {
+		After   []string   `systemd:"Unit.After,words"`
+		Service exec       `systemd:"Service"`
+		Extra   []*Section `systemd:",extra"`
+	}
+
+	const input = `[Unit]
+After=a.target "b c.target"
+Description=kept
+[Service]
+Environment=A=1 "B=2 3"
+Type=oneshot
+Environment=
+Environment=C=4
+[X-Custom]
+Key=value
+`
+	u, err := Deserialize(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v unit
+	if err := Unmarshal(u, &v); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(v.After, []string{"a.target", "b c.target"}) || !reflect.DeepEqual(v.Service.Environment, []string{"C=4"}) {
+		t.Errorf("Unmarshal() = %+v", v)
+	}
+	if len(v.Service.Extra) != 1 || !v.Service.Extra[0].Match(NewOptionValue("Type", "oneshot")) {
+		t.Errorf("extra options = %v, want Type=oneshot", v.Service.Extra)
+	}
+	// [Unit] has a field, so its other options are not kept
+	if len(v.Extra) != 1 || v.Extra[0].Name != "X-Custom" {
+		t.Errorf("extra sections = %v, want [X-Custom]", v.Extra)
+	}
+
+	v.Service.Environment = append(v.Service.Environment, "D=5 6")
+	out, err := Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "[Unit]\nAfter=a.target\nAfter=\"b c.target\"\n\n[Service]\nEnvironment=C=4\nEnvironment=\"D=5 6\"\nType=oneshot\n\n[X-Custom]\nKey=value\n"
+	if got := out.String(); got != want {
+		t.Errorf("Marshal() =\n%s\nwant:\n%s", got, want)
+	}
+	if out.Sections[2] == u.Sections[2] {
+		t.Error("Marshal() reused an extra section instead of copying it")
+	}
+
+	bad := struct {
+		Extra []*OptionValue `systemd:",extra"`
+	}{}
+	if _, err := Marshal(bad); !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("Marshal() of extra options in the top-level struct error = %v, want ErrInvalidTag", err)
+	}
+	words := struct {
+		A string `systemd:"Service.A,words"`
+	}{}
+	if _, err := Marshal(words); !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("Marshal() of words on a string error = %v, want ErrInvalidTag", err)
+	}
+}
+
+func TestUnmarshal_Commas(t *testing.T) {
+	type peer struct {
+		AllowedIPs []netip.Prefix `systemd:"AllowedIPs,commas"`
+	}
+	var v struct {
+		Peers []peer `systemd:"Peer"`
+	}
+	u, err := Deserialize(strings.NewReader("[Peer]\nAllowedIPs=10.0.0.1/32, 10.0.0.0/24\nAllowedIPs=fd00::/64\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Unmarshal(u, &v); err != nil {
+		t.Fatal(err)
+	}
+	want := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.1/32"), netip.MustParsePrefix("10.0.0.0/24"), netip.MustParsePrefix("fd00::/64"),
+	}
+	if len(v.Peers) != 1 || !reflect.DeepEqual(v.Peers[0].AllowedIPs, want) {
+		t.Errorf("Unmarshal() = %+v, want AllowedIPs %v", v, want)
+	}
+
+	both := struct {
+		A []string `systemd:"Service.A,words,commas"`
+	}{}
+	if _, err := Marshal(both); !errors.Is(err, ErrInvalidTag) {
+		t.Errorf("Marshal() of words and commas error = %v, want ErrInvalidTag", err)
+	}
+}
@@ -6,6 +6,10 @@ import "fmt"
 type OptionValue struct {
 	Option string
 	Value  string
+
+	pos, end Position
+	src      *source
+	comments []string
 }
 
 // NewOptionValue returns a new OptionValue with pre-set option and value.
@@ -21,3 +25,38 @@ func (uo *OptionValue) String() string {
 func (uo *OptionValue) Match(other *OptionValue) bool {
 	return uo.Option == other.Option && uo.Value == other.Value
 }
+
+// modified reports whether uo differs from the assignment it was parsed
+// from in lossless mode.
+func (uo *OptionValue) modified() bool {
+	return uo.Option != uo.src.name || uo.Value != uo.src.value
+}
+
+// Pos returns the position of the first character of the option name, or
+// an invalid Position when uo was not produced by the parser.
+func (uo *OptionValue) Pos() Position {
+	return uo.pos
+}
+
+// End returns the position immediately after the last character of the
+// value. For a value continued with trailing backslashes it lies on the
+// last continuation line.
+func (uo *OptionValue) End() Position {
+	return uo.end
+}
+
+// Comments returns the text of the comment block directly above the
+// assignment, as parsed by DeserializeLossless or set by SetComments. Each
+// comment is one line without its comment marker ('#' or ';') and the
+// single space following it. Comments separated from the assignment by a
+// blank line are not attached to it.
+func (uo *OptionValue) Comments() []string {
+	return uo.comments
+}
+
+// SetComments replaces the comment block directly above the assignment; call
+// it without arguments to remove it. Comments are written as '#' comment
+// lines; a comment containing newlines is written as several lines.
+func (uo *OptionValue) SetComments(comments ...string) {
+	uo.comments = splitLines(comments)
+}
@@ -0,0 +1,33 @@
+package systemdconfig
+
+import "fmt"
+
+// Position describes a location in parsed input. Line and Column are
+// 1-based, Column counts bytes; Offset is the 0-based byte offset from the
+// start of the input. The zero Position is invalid: sections and options
+// built in code rather than parsed have no position.
+type Position struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+// IsValid reports whether p refers to a location in parsed input.
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String returns p in the "line:column" form, or "-" for an invalid
+// position. Prefix it with a file name to get the familiar
+// "file:line:column" diagnostic form.
+func (p Position) String() string {
+	if !p.IsValid() {
+		return "-"
+	}
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// advance returns the position n bytes further on the same line.
+func (p Position) advance(n int) Position {
+	return Position{Offset: p.Offset + n, Line: p.Line, Column: p.Column + n}
+}
@@ -0,0 +1,33 @@
+package systemdconfig
+
+import "testing"
+
+func TestPosition_String(t *testing.T) {
+	tests := []struct {
+		name string
+		pos  Position
+		want string
+	}{
+		{"Invalid", Position{}, "-"},
+		{"FirstByte", Position{Offset: 0, Line: 1, Column: 1}, "1:1"},
+		{"LaterLine", Position{Offset: 120, Line: 14, Column: 3}, "14:3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.pos.String(); got != tt.want {
+				t.Errorf("Position.String() = %v, want %v", got, tt.want)
+			}
+			if got := tt.pos.IsValid(); got != (tt.want != "-") {
+				t.Errorf("Position.IsValid() = %v, want %v", got, tt.want != "-")
+			}
+		})
+	}
+}
+
+func TestPosition_CodeBuiltNodesAreInvalid(t *testing.T) {
+	s := NewSection("Unit")
+	o := s.AddOption("Description", "Test")
+	if s.Pos().IsValid() || s.End().IsValid() || o.Pos().IsValid() || o.End().IsValid() {
+		t.Errorf("positions of code-built nodes are valid: %v %v %v %v", s.Pos(), s.End(), o.Pos(), o.End())
+	}
+}
@@ -0,0 +1,286 @@
+package systemdconfig
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// ErrInvalidEscape is returned for a value with an unknown or
+	// malformed C escape, an escape of a NUL character or a backslash at
+	// its end.
+	ErrInvalidEscape = errors.New("invalid escape")
+	// ErrUnterminatedQuote is returned for a value with an opening quote
+	// and no closing one.
+	ErrUnterminatedQuote = errors.New("unterminated quote")
+)
+
+// whitespace separates words, as WHITESPACE in systemd.
+const whitespace = " \t\n\r"
+
+// UnquoteValue decodes value as systemd does for settings that take a
+// single string: the C escapes of systemd.syntax(7) (\n, \t, \xNN,
+// \uNNNN, \NNN octal, \s for a space and so on) are replaced by what
+// they stand for, and single and double quotes are removed, keeping the
+// text between them verbatim apart from escapes. Whitespace is kept.
+func UnquoteValue(value string) (string, error) {
+	word, _, err := extractWord(value, false)
+	return word, err
+}
+
+// SplitWords splits value into words as systemd's extract_first_word()
+// does for settings that take a list, such as ExecStart= and
+// Environment=: words are separated by whitespace, quotes group text with
+// whitespace into a word, and C escapes are decoded (see UnquoteValue). A
+// backslash before whitespace makes it part of the word.
+// Quoted and unquoted text without whitespace in between form one word,
+// e.g. a"b c"d is the word "ab cd".
+func SplitWords(value string) ([]string, error) {
+	var words []string
+	for {
+		value = strings.TrimLeft(value, whitespace)
+		if value == "" {
+			return words, nil
+		}
+		word, rest, err := extractWord(value, true)
+		if err != nil {
+			return nil, err
+		}
+		words = append(words, word)
+		value = rest
+	}
+}
+
+// extractWord decodes the first word of value and returns it and the rest
+// of value. Unless split, the word extends to the end of value.
+func extractWord(value string, split bool) (string, string, error) {
+	var b strings.Builder
+	var quote byte
+	for i := 0; i < len(value); {
+		c := value[i]
+		switch {
+		case c == '\\':
+			n, err := unescape(&b, value[i:])
+			if err != nil {
+				return "", "", err
+			}
+			i += n
+			continue
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && split && strings.IndexByte(whitespace, c) >= 0:
+			return b.String(), value[i:], nil
+		default:
+			b.WriteByte(c)
+		}
+		i++
+	}
+	if quote != 0 {
+		return "", "", fmt.Errorf("%w in %q", ErrUnterminatedQuote, value)
+	}
+	return b.String(), "", nil
+}
+
+// unescape decodes the C escape at the start of s to given builder and
+// returns its length, following systemd's cunescape_one().
+func unescape(b *strings.Builder, s string) (int, error) {
+	invalid := func(n int) (int, error) {
+		return 0, fmt.Errorf("%w %q", ErrInvalidEscape, s[:min(n, len(s))])
+	}
+	if len(s) < 2 {
+		return invalid(len(s))
+	}
+	if c, ok := simpleEscapes[s[1]]; ok {
+		b.WriteByte(c)
+		return 2, nil
+	}
+	// an escaped separator stands for itself, as for ExecStart=
+	if strings.IndexByte(whitespace, s[1]) >= 0 {
+		b.WriteByte(s[1])
+		return 2, nil
+	}
+	switch s[1] {
+	case 'x':
+		v, ok := parseDigits(s[2:], 2, 16)
+		if !ok || v == 0 {
+			return invalid(4)
+		}
+		// like octal escapes, \xNN stands for a byte, not a code point
+		b.WriteByte(byte(v))
+		return 4, nil
+	case 'u', 'U':
+		n := 4
+		if s[1] == 'U' {
+			n = 8
+		}
+		v, ok := parseDigits(s[2:], n, 16)
+		if !ok || v == 0 || v > unicode.MaxRune || !utf8.ValidRune(rune(v)) {
+			return invalid(n + 2)
+		}
+		b.WriteRune(rune(v))
+		return n + 2, nil
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		v, ok := parseDigits(s[1:], 3, 8)
+		if !ok || v == 0 || v > 0xff {
+			return invalid(4)
+		}
+		b.WriteByte(byte(v))
+		return 4, nil
+	}
+	_, size := utf8.DecodeRuneInString(s[1:])
+	return invalid(1 + size)
+}
+
+// simpleEscapes maps the character after a backslash to what it stands
+// for, for the escapes made of one character.
+var simpleEscapes = map[byte]byte{
+	'a': '\a', 'b': '\b', 'f': '\f', 'n': '\n', 'r': '\r', 't': '\t', 'v': '\v',
+	'\\': '\\', '"': '"', '\'': '\'', 's': ' ',
+}
+
+// parseDigits parses the first n characters of s as digits in the given
+// base. It reports false when s is too short or has a non-digit.
+func parseDigits(s string, n, base int) (uint64, bool) {
+	if len(s) < n {
+		return 0, false
+	}
+	var v uint64
+	for i := range n {
+		d := strings.IndexByte("0123456789abcdef", lower(s[i]))
+		if d < 0 || d >= base {
+			return 0, false
+		}
+		v = v*uint64(base) + uint64(d)
+	}
+	return v, true
+}
+
+// lower returns the lower case of an ASCII letter, and c itself otherwise.
+func lower(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// EscapeValue returns value with backslashes, quotes and control
+// characters replaced by C escapes, as well as the whitespace at its
+// start and end and a final backslash, which would otherwise not be
+// written verbatim after an '='. It is the inverse of UnquoteValue,
+// except that a NUL character, which systemd rejects, cannot be decoded.
+func EscapeValue(value string) string {
+	lead := len(value) - len(strings.TrimLeftFunc(value, unicode.IsSpace))
+	trail := len(strings.TrimRightFunc(value, unicode.IsSpace))
+
+	var b strings.Builder
+	for i := 0; i < len(value); {
+		r, size := utf8.DecodeRuneInString(value[i:])
+		edge := i < lead || i >= trail
+		switch {
+		case r == utf8.RuneError && size == 1:
+			// invalid UTF-8 is kept as is
+			b.WriteByte(value[i])
+		case r == '\\' && i == len(value)-1:
+			b.WriteString(`\x5c`)
+		case r == ' ' && edge:
+			b.WriteString(`\s`)
+		case edge && r >= utf8.RuneSelf && unicode.IsSpace(r):
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			writeEscaped(&b, r)
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// QuoteWord returns word as it must be written for SplitWords to return
+// it as a single word: verbatim when it is not empty and has no
+// whitespace, quotes, backslashes or control characters, and otherwise in
+// double quotes, with C escapes. A NUL character is written as \x00,
+// which systemd and SplitWords reject.
+func QuoteWord(word string) string {
+	plain := word != ""
+	for _, r := range word {
+		if r == utf8.RuneError || r < ' ' || r == 0x7f || strings.ContainsRune(`"'\ `, r) {
+			plain = false
+			break
+		}
+	}
+	if plain {
+		return word
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(word); {
+		r, size := utf8.DecodeRuneInString(word[i:])
+		if r == utf8.RuneError && size == 1 {
+			fmt.Fprintf(&b, `\x%02x`, word[i])
+		} else {
+			writeEscaped(&b, r)
+		}
+		i += size
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// JoinWords returns the words quoted with QuoteWord and separated by
+// spaces, the inverse of SplitWords for words without NUL characters.
+func JoinWords(words ...string) string {
+	quoted := make([]string, len(words))
+	for i, w := range words {
+		quoted[i] = QuoteWord(w)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// writeEscaped writes r to given builder, as a C escape if it is a
+// backslash, a quote or a control character.
+func writeEscaped(b *strings.Builder, r rune) {
+	switch r {
+	case '\\', '"', '\'':
+		b.WriteByte('\\')
+		b.WriteRune(r)
+	case '\n':
+		b.WriteString(`\n`)
+	case '\r':
+		b.WriteString(`\r`)
+	case '\t':
+		b.WriteString(`\t`)
+	default:
+		if r < ' ' || r == 0x7f {
+			fmt.Fprintf(b, `\x%02x`, r)
+			return
+		}
+		b.WriteRune(r)
+	}
+}
+
+// Words returns the words of the value of the last occurrence of the
+// named option, split and decoded by SplitWords. It returns nil and no
+// error when the option is absent.
+func (s *Section) Words(option string) ([]string, error) {
+	v, ok := s.Value(option)
+	if !ok {
+		return nil, nil
+	}
+	return SplitWords(v)
+}
+
+// Words returns the words of the value of the named option in the named
+// section, as found by Unit.Value and split and decoded by SplitWords. It
+// returns nil and no error when the option is absent.
+func (u *Unit) Words(section, option string) ([]string, error) {
+	v, ok := u.Value(section, option)
+	if !ok {
+		return nil, nil
+	}
+	return SplitWords(v)
+}
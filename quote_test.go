@@ -0,0 +1,148 @@
+package systemdconfig
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"", nil},
+		{"  \t ", nil},
+		{"/usr/bin/app --flag", []string{"/usr/bin/app", "--flag"}},
+		{`"a b"  'c "d"'`, []string{"a b", `c "d"`}},
+		{`a"b c"d`, []string{"ab cd"}},
+		{`""`, []string{""}},
+		{`one\ two`, []string{"one two"}},
+		{`tab\there\nnew`, []string{"tab\there\nnew"}},
+		{`\x41\101\u00e9\U0001F600\s`, []string{"AAé😀 "}},
+		{`\xe9`, []string{"\xe9"}},
+		{`'it\'s' "say \"hi\""`, []string{"it's", `say "hi"`}},
+		{`FOO=bar "BAZ=a b"`, []string{"FOO=bar", "BAZ=a b"}},
+	}
+	for _, tt := range tests {
+		got, err := SplitWords(tt.value)
+		if err != nil {
+			t.Errorf("SplitWords(%q) error = %v", tt.value, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitWords(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestSplitWords_Errors(t *testing.T) {
+	tests := []struct {
+		value string
+		want  error
+	}{
+		{`"unterminated`, ErrUnterminatedQuote},
+		{`a 'b`, ErrUnterminatedQuote},
+		{`trailing\`, ErrInvalidEscape},
+		{`\q`, ErrInvalidEscape},
+		{`\x4`, ErrInvalidEscape},
+		{`\xzz`, ErrInvalidEscape},
+		{`\x00`, ErrInvalidEscape},
+		{`\000`, ErrInvalidEscape},
+		{`\400`, ErrInvalidEscape},
+		{`\ud800`, ErrInvalidEscape},
+		{`\U00110000`, ErrInvalidEscape},
+	}
+	for _, tt := range tests {
+		if _, err := SplitWords(tt.value); !errors.Is(err, tt.want) {
+			t.Errorf("SplitWords(%q) error = %v, want %v", tt.value, err, tt.want)
+		}
+		if _, err := UnquoteValue(tt.value); !errors.Is(err, tt.want) {
+			t.Errorf("UnquoteValue(%q) error = %v, want %v", tt.value, err, tt.want)
+		}
+	}
+}
+
+func TestUnquoteValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"plain value", "plain value"},
+		{`"quoted  value" and 'more'`, "quoted  value and more"},
+		{`line\nbreak\x5c`, "line\nbreak\\"},
+		{`\s  padded\s`, "   padded "},
+	}
+	for _, tt := range tests {
+		got, err := UnquoteValue(tt.value)
+		if err != nil || got != tt.want {
+			t.Errorf("UnquoteValue(%q) = %q, %v, want %q", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestQuoteWord(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"/usr/bin/app", "/usr/bin/app"},
+		{"café", "café"},
+		{"", `""`},
+		{"a b", `"a b"`},
+		{`it's`, `"it\'s"`},
+		{`C:\dir`, `"C:\\dir"`},
+		{"tab\tnew\nbell\a", `"tab\tnew\nbell\x07"`},
+		{"caf\xe9", `"caf\xe9"`},
+	}
+	for _, tt := range tests {
+		got := QuoteWord(tt.word)
+		if got != tt.want {
+			t.Errorf("QuoteWord(%q) = %s, want %s", tt.word, got, tt.want)
+		}
+		if words, err := SplitWords(got); err != nil || len(words) != 1 || words[0] != tt.word {
+			t.Errorf("SplitWords(%s) = %q, %v, want [%q]", got, words, err, tt.word)
+		}
+	}
+
+	words := []string{"/bin/sh", "-c", "echo 'hello world'", ""}
+	if got, err := SplitWords(JoinWords(words...)); err != nil || !reflect.DeepEqual(got, words) {
+		t.Errorf("SplitWords(JoinWords(%q)) = %q, %v", words, got, err)
+	}
+}
+
+func TestEscapeValue(t *testing.T) {
+	for _, value := range []string{" padded\t", "line\nbreak", `C:\dir\`, "\u0085nel\u00a0", `"quoted" 'single'`} {
+		escaped := EscapeValue(value)
+		if rawValueProblem(escaped) != "" {
+			t.Errorf("EscapeValue(%q) = %q cannot be written verbatim", value, escaped)
+		}
+		if got, err := UnquoteValue(escaped); err != nil || got != value {
+			t.Errorf("UnquoteValue(EscapeValue(%q)) = %q, %v", value, got, err)
+		}
+	}
+}
+
+func TestWords(t *testing.T) {
+	unit, err := Deserialize(strings.NewReader("[Service]\nExecStart=/bin/true\n[Service]\nExecStart=/bin/sh -c \"echo hi\"\nBad='x\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/bin/sh", "-c", "echo hi"}
+	if got, err := unit.Words("Service", "ExecStart"); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("Unit.Words() = %q, %v, want %q", got, err, want)
+	}
+	if got, err := unit.Sections[0].Words("ExecStart"); err != nil || !reflect.DeepEqual(got, []string{"/bin/true"}) {
+		t.Errorf("Section.Words() = %q, %v", got, err)
+	}
+	if got, err := unit.Words("Service", "Missing"); got != nil || err != nil {
+		t.Errorf("Unit.Words() of missing option = %q, %v, want nil, nil", got, err)
+	}
+	if got, err := unit.Sections[1].Words("Missing"); got != nil || err != nil {
+		t.Errorf("Section.Words() of missing option = %q, %v, want nil, nil", got, err)
+	}
+	if _, err := unit.Words("Service", "Bad"); !errors.Is(err, ErrUnterminatedQuote) {
+		t.Errorf("Unit.Words() error = %v, want ErrUnterminatedQuote", err)
+	}
+}
@@ -324,7 +324,7 @@ func TestRealWorldConfigs(t *testing.T) {
 			if err != nil {
 				t.Fatalf("Deserialize(%s) error = %v", tt.file, err)
 			}
-			if !reflect.DeepEqual(got, tt.want) {
+			if !reflect.DeepEqual(withoutPositions(got), tt.want) {
 				var gotOut, wantOut bytes.Buffer
 				_, _ = got.WriteTo(&gotOut)
 				_, _ = tt.want.WriteTo(&wantOut)
@@ -0,0 +1,133 @@
+package systemdconfig
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// maxSymlinks is the number of symlinks rootFS follows resolving a name,
+// as the kernel does, before it gives up with ELOOP.
+const maxSymlinks = 40
+
+// rootFS is the file system of the directory tree under a root
+// directory, in which symlinks are resolved as if the root were "/".
+type rootFS string
+
+// resolve returns the name of the file the valid name refers to in fsys,
+// following symlinks in every element of it. An absolute symlink target
+// starts over at the root, and ".." does not lead above it. A symlink to
+// /dev/null resolves to "dev/null" whether or not that exists under the
+// root.
+func (fsys rootFS) resolve(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	var (
+		resolved string
+		rest     = strings.Split(name, "/")
+		links    int
+	)
+	for len(rest) > 0 {
+		elem := rest[0]
+		rest = rest[1:]
+		switch elem {
+		case "", ".":
+			continue
+		case "..":
+			resolved = strings.TrimPrefix(path.Dir("/"+resolved), "/")
+			continue
+		}
+		next := path.Join(resolved, elem)
+		info, err := os.Lstat(filepath.Join(string(fsys), filepath.FromSlash(next)))
+		if err != nil {
+			return "", &fs.PathError{Op: op, Path: name, Err: unwrapPathError(err)}
+		}
+		if info.Mode()&fs.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+		if links++; links > maxSymlinks {
+			return "", &fs.PathError{Op: op, Path: name, Err: syscall.ELOOP}
+		}
+		target, err := os.Readlink(filepath.Join(string(fsys), filepath.FromSlash(next)))
+		if err != nil {
+			return "", &fs.PathError{Op: op, Path: name, Err: unwrapPathError(err)}
+		}
+		target = filepath.ToSlash(target)
+		if path.Clean(target) == "/dev/null" && len(rest) == 0 {
+			return "dev/null", nil
+		}
+		if strings.HasPrefix(target, "/") {
+			resolved = ""
+		}
+		rest = append(strings.Split(target, "/"), rest...)
+	}
+	if resolved == "" {
+		resolved = "."
+	}
+	return resolved, nil
+}
+
+// hostName returns the name in the host file system of a resolved name.
+// "dev/null" is the null device of the host, so that symlinks to it mask
+// units in trees without /dev.
+func (fsys rootFS) hostName(resolved string) string {
+	if resolved == "dev/null" {
+		return os.DevNull
+	}
+	return filepath.Join(string(fsys), filepath.FromSlash(resolved))
+}
+
+// unwrapPathError returns the error of a *fs.PathError, so that it can be
+// reported with the name in the rootFS.
+func unwrapPathError(err error) error {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return pathErr.Err
+	}
+	return err
+}
+
+// Open implements fs.FS.
+func (fsys rootFS) Open(name string) (fs.File, error) {
+	resolved, err := fsys.resolve("open", name)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(fsys.hostName(resolved))
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: unwrapPathError(err)}
+	}
+	return f, nil
+}
+
+// Stat implements fs.StatFS.
+func (fsys rootFS) Stat(name string) (fs.FileInfo, error) {
+	resolved, err := fsys.resolve("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(fsys.hostName(resolved))
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: unwrapPathError(err)}
+	}
+	return info, nil
+}
+
+// ReadDir implements fs.ReadDirFS.
+func (fsys rootFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	resolved, err := fsys.resolve("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(fsys.hostName(resolved))
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: unwrapPathError(err)}
+	}
+	return entries, nil
+}
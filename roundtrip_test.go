@@ -59,3 +59,36 @@ func TestRoundTrip(t *testing.T) {
 		})
 	}
 }
+
+// TestLosslessRoundTrip checks that every fixture under testdata/ survives
+// DeserializeLossless and WriteTo byte for byte, comments, blank lines,
+// spacing, continuations and line endings included.
+func TestLosslessRoundTrip(t *testing.T) {
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, fixture := range fixtures {
+		if strings.HasSuffix(fixture, ".golden") {
+			continue
+		}
+		src, err := os.ReadFile(fixture)
+		if err != nil {
+			continue // testdata/fuzz is a directory
+		}
+		t.Run(filepath.Base(fixture), func(t *testing.T) {
+			unit, err := DeserializeLossless(bytes.NewReader(src))
+			if err != nil {
+				t.Fatalf("DeserializeLossless(%s) error = %v", fixture, err)
+			}
+			var out bytes.Buffer
+			if _, err := unit.WriteTo(&out); err != nil {
+				t.Fatalf("WriteTo() error = %v", err)
+			}
+			if out.String() != string(src) {
+				t.Errorf("lossless output of %s differs from input:\ngot:\n%s\nwant:\n%s", fixture, out.String(), src)
+			}
+		})
+	}
+}
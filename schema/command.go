@@ -0,0 +1,67 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+)
+
+// ErrInvalidCommand is wrapped by the error ParseCommand returns for a
+// command line without a command.
+var ErrInvalidCommand = errors.New("invalid command line")
+
+// commandPrefixes are the characters that may precede the executable of
+// a command line, see systemd.service(5).
+const commandPrefixes = "@-:+!|"
+
+// Command is a command line of an option such as ExecStart=.
+type Command struct {
+	// Prefix holds the special characters before the executable, e.g.
+	// "-" to ignore a failure of the command or "+" to run it with full
+	// privileges; see systemd.service(5).
+	Prefix string
+	// Argv is the executable followed by its arguments, with quotes and
+	// escapes decoded. With the "@" prefix the second word is passed as
+	// argv[0] instead of the executable.
+	Argv []string
+}
+
+// ParseCommand parses a command line as systemd does: the prefix, then
+// words split by systemdconfig.SplitWords.
+func ParseCommand(s string) (Command, error) {
+	trimmed := strings.TrimLeft(s, " \t")
+	rest := strings.TrimLeft(trimmed, commandPrefixes)
+	argv, err := systemdconfig.SplitWords(rest)
+	if err != nil {
+		return Command{}, err
+	}
+	if len(argv) == 0 {
+		return Command{}, fmt.Errorf("%w %q", ErrInvalidCommand, s)
+	}
+	return Command{Prefix: trimmed[:len(trimmed)-len(rest)], Argv: argv}, nil
+}
+
+// String returns the command line with its words quoted as needed.
+func (c Command) String() string {
+	return c.Prefix + systemdconfig.JoinWords(c.Argv...)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c Command) MarshalText() ([]byte, error) {
+	if len(c.Argv) == 0 {
+		return nil, fmt.Errorf("%w: no executable", ErrInvalidCommand)
+	}
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *Command) UnmarshalText(text []byte) error {
+	parsed, err := ParseCommand(string(text))
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
@@ -0,0 +1,39 @@
+package schema
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Command
+		out  string
+	}{
+		{"/bin/true", Command{Argv: []string{"/bin/true"}}, "/bin/true"},
+		{"-/bin/kill -s HUP $MAINPID", Command{Prefix: "-", Argv: []string{"/bin/kill", "-s", "HUP", "$MAINPID"}}, "-/bin/kill -s HUP $MAINPID"},
+		{"@/usr/sbin/sshd sshd -D", Command{Prefix: "@", Argv: []string{"/usr/sbin/sshd", "sshd", "-D"}}, "@/usr/sbin/sshd sshd -D"},
+		{"+!!/bin/sh -c 'echo a b'", Command{Prefix: "+!!", Argv: []string{"/bin/sh", "-c", "echo a b"}}, `+!!/bin/sh -c "echo a b"`},
+		{"  /bin/echo \\x41", Command{Argv: []string{"/bin/echo", "A"}}, "/bin/echo A"},
+	}
+	for _, tt := range tests {
+		got, err := ParseCommand(tt.in)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseCommand(%q) = %#v, %v, want %#v", tt.in, got, err, tt.want)
+		}
+		if got.String() != tt.out {
+			t.Errorf("String() = %q, want %q", got.String(), tt.out)
+		}
+	}
+
+	for _, in := range []string{"", "-", " @+ "} {
+		if _, err := ParseCommand(in); !errors.Is(err, ErrInvalidCommand) {
+			t.Errorf("ParseCommand(%q) error = %v, want ErrInvalidCommand", in, err)
+		}
+	}
+	if _, err := (Command{}).MarshalText(); !errors.Is(err, ErrInvalidCommand) {
+		t.Errorf("MarshalText() of an empty command error = %v, want ErrInvalidCommand", err)
+	}
+}
@@ -0,0 +1,85 @@
+package schema
+
+import "strings"
+
+// ExecSettings are the options of the execution environment of the
+// processes of a service, socket, mount or swap unit, see
+// systemd.exec(5).
+type ExecSettings struct {
+	User                string   `systemd:"User,omitempty"`
+	Group               string   `systemd:"Group,omitempty"`
+	SupplementaryGroups []string `systemd:"SupplementaryGroups,words"`
+	DynamicUser         *bool    `systemd:"DynamicUser"`
+
+	WorkingDirectory string `systemd:"WorkingDirectory,omitempty"`
+	RootDirectory    string `systemd:"RootDirectory,omitempty"`
+	UMask            string `systemd:"UMask,omitempty"`
+
+	// Environment holds "NAME=value" assignments.
+	Environment     []string `systemd:"Environment,words"`
+	EnvironmentFile []string `systemd:"EnvironmentFile"`
+
+	StandardInput    string `systemd:"StandardInput,omitempty"`
+	StandardOutput   string `systemd:"StandardOutput,omitempty"`
+	StandardError    string `systemd:"StandardError,omitempty"`
+	SyslogIdentifier string `systemd:"SyslogIdentifier,omitempty"`
+
+	Nice           *int `systemd:"Nice"`
+	OOMScoreAdjust *int `systemd:"OOMScoreAdjust"`
+	// Resource limits, e.g. "infinity", "4096" or "1024:4096"; the
+	// others are kept in Extra.
+	LimitNOFILE string `systemd:"LimitNOFILE,omitempty"`
+	LimitNPROC  string `systemd:"LimitNPROC,omitempty"`
+	LimitCORE   string `systemd:"LimitCORE,omitempty"`
+
+	NoNewPrivileges *bool    `systemd:"NoNewPrivileges"`
+	PrivateTmp      *bool    `systemd:"PrivateTmp"`
+	ProtectSystem   string   `systemd:"ProtectSystem,omitempty"`
+	ProtectHome     string   `systemd:"ProtectHome,omitempty"`
+	ReadWritePaths  []string `systemd:"ReadWritePaths,words"`
+	ReadOnlyPaths   []string `systemd:"ReadOnlyPaths,words"`
+	// Each assignment of CapabilityBoundingSet= and AmbientCapabilities=
+	// is one CapabilitySet, as a "~" inverts the whole assignment.
+	CapabilityBoundingSet []CapabilitySet `systemd:"CapabilityBoundingSet"`
+	AmbientCapabilities   []CapabilitySet `systemd:"AmbientCapabilities"`
+
+	RuntimeDirectory       []string `systemd:"RuntimeDirectory,words"`
+	StateDirectory         []string `systemd:"StateDirectory,words"`
+	CacheDirectory         []string `systemd:"CacheDirectory,words"`
+	LogsDirectory          []string `systemd:"LogsDirectory,words"`
+	ConfigurationDirectory []string `systemd:"ConfigurationDirectory,words"`
+}
+
+// KillSettings are the options of how the processes of a service,
+// socket, mount or swap unit are stopped, see systemd.kill(5).
+type KillSettings struct {
+	// KillMode is one of "control-group", "mixed", "process" and "none".
+	KillMode    string `systemd:"KillMode,omitempty"`
+	KillSignal  string `systemd:"KillSignal,omitempty"`
+	SendSIGKILL *bool  `systemd:"SendSIGKILL"`
+}
+
+// CapabilitySet is an assignment of capabilities, e.g. "CAP_NET_ADMIN
+// CAP_NET_RAW", or with Invert set of all capabilities but the given
+// ones, "~CAP_SYS_ADMIN CAP_NET_RAW".
+type CapabilitySet struct {
+	Invert       bool
+	Capabilities []string
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c CapabilitySet) MarshalText() ([]byte, error) {
+	text := strings.Join(c.Capabilities, " ")
+	if c.Invert {
+		text = "~" + text
+	}
+	return []byte(text), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *CapabilitySet) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	rest, invert := strings.CutPrefix(s, "~")
+	*c = CapabilitySet{Invert: invert, Capabilities: strings.Fields(rest)}
+	return nil
+}
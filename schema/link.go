@@ -0,0 +1,43 @@
+package schema
+
+import systemdconfig "github.com/javadh75/systemd-config"
+
+// LinkSection is the [Link] section of a .link file, see
+// systemd.link(5).
+type LinkSection struct {
+	Description            string   `systemd:"Description,omitempty"`
+	MACAddressPolicy       string   `systemd:"MACAddressPolicy,omitempty"`
+	MACAddress             string   `systemd:"MACAddress,omitempty"`
+	NamePolicy             []string `systemd:"NamePolicy,words"`
+	Name                   string   `systemd:"Name,omitempty"`
+	AlternativeNamesPolicy []string `systemd:"AlternativeNamesPolicy,words"`
+	AlternativeName        []string `systemd:"AlternativeName,words"`
+	MTUBytes               string   `systemd:"MTUBytes,omitempty"`
+	BitsPerSecond          string   `systemd:"BitsPerSecond,omitempty"`
+	Duplex                 string   `systemd:"Duplex,omitempty"`
+	AutoNegotiation        *bool    `systemd:"AutoNegotiation"`
+	WakeOnLan              []string `systemd:"WakeOnLan,words"`
+
+	Extra []*systemdconfig.OptionValue `systemd:",extra"`
+}
+
+// Link is a .link file of systemd-udevd, which configures a network
+// device when it appears, see systemd.link(5).
+type Link struct {
+	Match MatchSection `systemd:"Match,omitempty"`
+	Link  LinkSection  `systemd:"Link,omitempty"`
+
+	// Extra holds the other sections, such as [SR-IOV].
+	Extra []*systemdconfig.Section `systemd:",extra"`
+}
+
+// LinkFromUnit returns the link configuration u describes. The error is
+// a *systemdconfig.ValueError for an option whose value is malformed.
+func LinkFromUnit(u *systemdconfig.Unit) (*Link, error) {
+	return fromUnit[Link](u)
+}
+
+// ToUnit returns the unit describing l.
+func (l *Link) ToUnit() (*systemdconfig.Unit, error) {
+	return systemdconfig.Marshal(l)
+}
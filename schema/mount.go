@@ -0,0 +1,143 @@
+package schema
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+)
+
+// MountOptions are the comma-separated mount options of Options= and
+// ExtraOptions=, e.g. "defaults,noatime".
+type MountOptions []string
+
+// Has reports whether the options include name, alone or as
+// "name=value".
+func (o MountOptions) Has(name string) bool {
+	for _, opt := range o {
+		if key, _, _ := strings.Cut(opt, "="); key == name {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (o MountOptions) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(o, ",")), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (o *MountOptions) UnmarshalText(text []byte) error {
+	*o = nil
+	for _, opt := range strings.Split(string(text), ",") {
+		if opt = strings.TrimSpace(opt); opt != "" {
+			*o = append(*o, opt)
+		}
+	}
+	return nil
+}
+
+// MountPoint is the absolute path of Where=, e.g. "/home", or a value
+// with specifiers that are yet to be expanded, e.g. "%h/data".
+type MountPoint string
+
+// UnitName returns the name of the unit of type typ, "mount" or
+// "automount", for p: the path escaped with
+// systemdconfig.EscapeUnitPath, e.g. "var-lib-docker.mount" for
+// "/var/lib/docker".
+func (p MountPoint) UnitName(typ string) string {
+	return systemdconfig.EscapeUnitPath(string(p)) + "." + typ
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p MountPoint) MarshalText() ([]byte, error) {
+	return []byte(p), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It rejects a
+// relative path.
+func (p *MountPoint) UnmarshalText(text []byte) error {
+	s := string(text)
+	if !path.IsAbs(s) && !strings.Contains(s, "%") {
+		return fmt.Errorf("mount point %q is not an absolute path", text)
+	}
+	*p = MountPoint(s)
+	return nil
+}
+
+// MountSection is the [Mount] section of a .mount unit, see
+// systemd.mount(5).
+type MountSection struct {
+	// What is the device, e.g. "/dev/disk/by-uuid/...", or the source of
+	// a bind mount or network file system, e.g. "server:/export", which
+	// need not be a path.
+	What          string         `systemd:"What,omitempty"`
+	Where         MountPoint     `systemd:"Where,omitempty"`
+	Type          string         `systemd:"Type,omitempty"`
+	Options       MountOptions   `systemd:"Options,omitempty"`
+	SloppyOptions *bool          `systemd:"SloppyOptions"`
+	LazyUnmount   *bool          `systemd:"LazyUnmount"`
+	ReadWriteOnly *bool          `systemd:"ReadWriteOnly"`
+	ForceUnmount  *bool          `systemd:"ForceUnmount"`
+	DirectoryMode string         `systemd:"DirectoryMode,omitempty"`
+	TimeoutSec    *time.Duration `systemd:"TimeoutSec"`
+
+	ExecSettings
+	KillSettings
+
+	Extra []*systemdconfig.OptionValue `systemd:",extra"`
+}
+
+// Mount is a .mount unit.
+type Mount struct {
+	Unit    UnitSection    `systemd:"Unit,omitempty"`
+	Mount   MountSection   `systemd:"Mount,omitempty"`
+	Install InstallSection `systemd:"Install,omitempty"`
+
+	Extra []*systemdconfig.Section `systemd:",extra"`
+}
+
+// MountFromUnit returns the mount u describes. The error is a
+// *systemdconfig.ValueError for an option whose value is malformed.
+func MountFromUnit(u *systemdconfig.Unit) (*Mount, error) {
+	return fromUnit[Mount](u)
+}
+
+// ToUnit returns the unit describing m.
+func (m *Mount) ToUnit() (*systemdconfig.Unit, error) {
+	return systemdconfig.Marshal(m)
+}
+
+// AutomountSection is the [Automount] section of a .automount unit, see
+// systemd.automount(5).
+type AutomountSection struct {
+	Where          MountPoint     `systemd:"Where,omitempty"`
+	ExtraOptions   MountOptions   `systemd:"ExtraOptions,omitempty"`
+	DirectoryMode  string         `systemd:"DirectoryMode,omitempty"`
+	TimeoutIdleSec *time.Duration `systemd:"TimeoutIdleSec"`
+
+	Extra []*systemdconfig.OptionValue `systemd:",extra"`
+}
+
+// Automount is a .automount unit.
+type Automount struct {
+	Unit      UnitSection      `systemd:"Unit,omitempty"`
+	Automount AutomountSection `systemd:"Automount,omitempty"`
+	Install   InstallSection   `systemd:"Install,omitempty"`
+
+	Extra []*systemdconfig.Section `systemd:",extra"`
+}
+
+// AutomountFromUnit returns the automount u describes. The error is a
+// *systemdconfig.ValueError for an option whose value is malformed.
+func AutomountFromUnit(u *systemdconfig.Unit) (*Automount, error) {
+	return fromUnit[Automount](u)
+}
+
+// ToUnit returns the unit describing a.
+func (a *Automount) ToUnit() (*systemdconfig.Unit, error) {
+	return systemdconfig.Marshal(a)
+}
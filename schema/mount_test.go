@@ -0,0 +1,128 @@
+package schema
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+)
+
+func TestMount_Golden(t *testing.T) {
+	mount, err := MountFromUnit(readUnit(t, "home.mount"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := mount.Mount
+	if m.What != "/dev/disk/by-uuid/f5872a89-8a9c-4e42-a17e-6cb92b7e72a4" || m.Where != "/home" || m.Type != "ext4" {
+		t.Errorf("What, Where, Type = %q, %q, %q", m.What, m.Where, m.Type)
+	}
+	if want := (MountOptions{"defaults", "noatime"}); !reflect.DeepEqual(m.Options, want) {
+		t.Errorf("Options = %q, want %q", m.Options, want)
+	}
+	if !m.Options.Has("noatime") || m.Options.Has("ro") {
+		t.Errorf("Options.Has is wrong for %q", m.Options)
+	}
+
+	u, err := mount.ToUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden, err := os.ReadFile(filepath.Join("..", "testdata", "home.mount.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := u.String(); got != string(golden) {
+		t.Errorf("ToUnit() =\n%s\nwant:\n%s", got, golden)
+	}
+}
+
+func TestMountOptions(t *testing.T) {
+	var o MountOptions
+	if err := o.UnmarshalText([]byte("rw, uid=1000,,x-systemd.automount")); err != nil {
+		t.Fatal(err)
+	}
+	if want := (MountOptions{"rw", "uid=1000", "x-systemd.automount"}); !reflect.DeepEqual(o, want) {
+		t.Errorf("UnmarshalText = %q, want %q", o, want)
+	}
+	if !o.Has("uid") || o.Has("uid=1000") {
+		t.Errorf("Has is wrong for %q", o)
+	}
+	if text, _ := o.MarshalText(); string(text) != "rw,uid=1000,x-systemd.automount" {
+		t.Errorf("MarshalText = %q", text)
+	}
+}
+
+func TestMountPoint(t *testing.T) {
+	for _, where := range []string{"/home", "/var/lib/docker", "%h/data"} {
+		var p MountPoint
+		if err := p.UnmarshalText([]byte(where)); err != nil || string(p) != where {
+			t.Errorf("UnmarshalText(%q) = %q, %v", where, p, err)
+		}
+	}
+	if got := MountPoint("/var/lib/docker").UnitName("mount"); got != "var-lib-docker.mount" {
+		t.Errorf("UnitName() = %q", got)
+	}
+	if got := MountPoint("/").UnitName("automount"); got != "-.automount" {
+		t.Errorf("UnitName() = %q", got)
+	}
+
+	for _, in := range []string{"[Mount]\nWhere=home\n", "[Automount]\nWhere=mnt/nfs\n"} {
+		u, err := systemdconfig.Deserialize(strings.NewReader(in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		section := u.Sections[0].Name
+		if section == "Mount" {
+			_, err = MountFromUnit(u)
+		} else {
+			_, err = AutomountFromUnit(u)
+		}
+		var verr *systemdconfig.ValueError
+		if !errors.As(err, &verr) || verr.Section != section || verr.Option != "Where" {
+			t.Errorf("%sFromUnit(%q) error = %v, want a *ValueError for %s.Where", section, in, err, section)
+		}
+	}
+}
+
+func TestAutomount_RoundTrip(t *testing.T) {
+	idle := 10 * time.Minute
+	automount := &Automount{
+		Unit:      UnitSection{Description: "Automount /mnt/nfs"},
+		Automount: AutomountSection{Where: "/mnt/nfs", ExtraOptions: MountOptions{"soft"}, TimeoutIdleSec: &idle},
+		Install:   InstallSection{WantedBy: []string{"multi-user.target"}},
+	}
+	u, err := automount.ToUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[Unit]
+Description=Automount /mnt/nfs
+
+[Automount]
+Where=/mnt/nfs
+ExtraOptions=soft
+TimeoutIdleSec=10min
+
+[Install]
+WantedBy=multi-user.target
+`
+	if got := u.String(); got != want {
+		t.Errorf("ToUnit() =\n%s\nwant:\n%s", got, want)
+	}
+	back, err := AutomountFromUnit(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back, automount) {
+		t.Errorf("AutomountFromUnit() = %+v, want %+v", back, automount)
+	}
+
+	if _, err := AutomountFromUnit(systemdconfig.NewUnit()); err != nil {
+		t.Errorf("AutomountFromUnit(empty): %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"net/netip"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+)
+
+// NetDevSection is the [NetDev] section of a .netdev file, see
+// systemd.netdev(5).
+type NetDevSection struct {
+	Name string `systemd:"Name,omitempty"`
+	// Kind is the kind of device, e.g. "bridge", "vlan" or "wireguard".
+	Kind        string `systemd:"Kind,omitempty"`
+	Description string `systemd:"Description,omitempty"`
+	MTUBytes    string `systemd:"MTUBytes,omitempty"`
+	MACAddress  string `systemd:"MACAddress,omitempty"`
+
+	Extra []*systemdconfig.OptionValue `systemd:",extra"`
+}
+
+// WireGuardSection is the [WireGuard] section of a .netdev file of Kind
+// wireguard.
+type WireGuardSection struct {
+	// ListenPort is a port number or "auto".
+	ListenPort     string  `systemd:"ListenPort,omitempty"`
+	PrivateKey     string  `systemd:"PrivateKey,omitempty"`
+	PrivateKeyFile string  `systemd:"PrivateKeyFile,omitempty"`
+	FirewallMark   *uint32 `systemd:"FirewallMark"`
+	RouteTable     string  `systemd:"RouteTable,omitempty"`
+	RouteMetric    *uint32 `systemd:"RouteMetric"`
+
+	Extra []*systemdconfig.OptionValue `systemd:",extra"`
+}
+
+// WireGuardPeerSection is a [WireGuardPeer] section of a .netdev file;
+// a file may have any number of them.
+type WireGuardPeerSection struct {
+	PublicKey        string         `systemd:"PublicKey,omitempty"`
+	PresharedKey     string         `systemd:"PresharedKey,omitempty"`
+	PresharedKeyFile string         `systemd:"PresharedKeyFile,omitempty"`
+	AllowedIPs       []netip.Prefix `systemd:"AllowedIPs,commas"`
+	// Endpoint is "host:port", where the host may be a name.
+	Endpoint string `systemd:"Endpoint,omitempty"`
+	// PersistentKeepalive is a number of seconds or "off".
+	PersistentKeepalive string  `systemd:"PersistentKeepalive,omitempty"`
+	RouteTable          string  `systemd:"RouteTable,omitempty"`
+	RouteMetric         *uint32 `systemd:"RouteMetric"`
+
+	Extra []*systemdconfig.OptionValue `systemd:",extra"`
+}
+
+// NetDev is a .netdev file of systemd-networkd, which creates a virtual
+// network device, see systemd.netdev(5).
+type NetDev struct {
+	Match          MatchSection           `systemd:"Match,omitempty"`
+	NetDev         NetDevSection          `systemd:"NetDev,omitempty"`
+	WireGuard      WireGuardSection       `systemd:"WireGuard,omitempty"`
+	WireGuardPeers []WireGuardPeerSection `systemd:"WireGuardPeer"`
+
+	// Extra holds the sections of other kinds of devices, such as
+	// [VLAN] or [Bridge].
+	Extra []*systemdconfig.Section `systemd:",extra"`
+}
+
+// NetDevFromUnit returns the virtual network device u describes. The
+// error is a *systemdconfig.ValueError for an option whose value is
+// malformed.
+func NetDevFromUnit(u *systemdconfig.Unit) (*NetDev, error) {
+	return fromUnit[NetDev](u)
+}
+
+// ToUnit returns the unit describing d.
+func (d *NetDev) ToUnit() (*systemdconfig.Unit, error) {
+	return systemdconfig.Marshal(d)
+}
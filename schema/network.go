@@ -0,0 +1,265 @@
+package schema
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+)
+
+// MatchSection is the [Match] section of .network, .netdev and .link
+// files, which selects the links they apply to, see systemd.network(5).
+// Lists hold shell-style globs, and a leading "!" negates one.
+type MatchSection struct {
+	Name                []string `systemd:"Name,words"`
+	OriginalName        []string `systemd:"OriginalName,words"`
+	MACAddress          []string `systemd:"MACAddress,words"`
+	PermanentMACAddress []string `systemd:"PermanentMACAddress,words"`
+	Path                []string `systemd:"Path,words"`
+	Driver              []string `systemd:"Driver,words"`
+	Type                []string `systemd:"Type,words"`
+	Kind                []string `systemd:"Kind,words"`
+	// Host, Virtualization, KernelCommandLine and Architecture are
+	// conditions, one per assignment, which must all hold.
+	Host              []string `systemd:"Host"`
+	Virtualization    []string `systemd:"Virtualization"`
+	KernelCommandLine []string `systemd:"KernelCommandLine"`
+	Architecture      []string `systemd:"Architecture"`
+
+	Extra []*systemdconfig.OptionValue `systemd:",extra"`
+}
+
+// NetworkSection is the [Network] section of a .network file.
+type NetworkSection struct {
+	Description string `systemd:"Description,omitempty"`
+	// DHCP is "yes", "no", "ipv4" or "ipv6".
+	DHCP                string `systemd:"DHCP,omitempty"`
+	LinkLocalAddressing string `systemd:"LinkLocalAddressing,omitempty"`
+	IPv6AcceptRA        *bool  `systemd:"IPv6AcceptRA"`
+	// Address and Gateway are shorthands for [Address] and [Route]
+	// sections with just that option.
+	Address      []Address   `systemd:"Address"`
+	Gateway      []Gateway   `systemd:"Gateway"`
+	DNS          []DNSServer `systemd:"DNS,words"`
+	Domains      []string    `systemd:"Domains,words"`
+	NTP          []string    `systemd:"NTP,words"`
+	LLMNR        string      `systemd:"LLMNR,omitempty"`
+	MulticastDNS string      `systemd:"MulticastDNS,omitempty"`
+
+	Bridge string   `systemd:"Bridge,omitempty"`
+	Bond   string   `systemd:"Bond,omitempty"`
+	VRF    string   `systemd:"VRF,omitempty"`
+	VLAN   []string `systemd:"VLAN,words"`
+	Tunnel []string `systemd:"Tunnel,words"`
+
+	ConfigureWithoutCarrier *bool `systemd:"ConfigureWithoutCarrier"`
+
+	Extra []*systemdconfig.OptionValue `systemd:",extra"`
+}
+
+// AddressSection is an [Address] section of a .network file; a file may
+// have any number of them.
+type AddressSection struct {
+	Address           Address    `systemd:"Address,omitempty"`
+	Peer              Address    `systemd:"Peer,omitempty"`
+	Broadcast         netip.Addr `systemd:"Broadcast,omitempty"`
+	Label             string     `systemd:"Label,omitempty"`
+	PreferredLifetime string     `systemd:"PreferredLifetime,omitempty"`
+	Scope             string     `systemd:"Scope,omitempty"`
+	RouteMetric       *uint32    `systemd:"RouteMetric"`
+
+	Extra []*systemdconfig.OptionValue `systemd:",extra"`
+}
+
+// RouteSection is a [Route] section of a .network file; a file may have
+// any number of them.
+type RouteSection struct {
+	Destination     netip.Prefix `systemd:"Destination,omitempty"`
+	Source          netip.Prefix `systemd:"Source,omitempty"`
+	Gateway         Gateway      `systemd:"Gateway,omitempty"`
+	GatewayOnLink   *bool        `systemd:"GatewayOnLink"`
+	PreferredSource netip.Addr   `systemd:"PreferredSource,omitempty"`
+	Metric          *uint32      `systemd:"Metric"`
+	Scope           string       `systemd:"Scope,omitempty"`
+	Type            string       `systemd:"Type,omitempty"`
+	Table           string       `systemd:"Table,omitempty"`
+	MTUBytes        string       `systemd:"MTUBytes,omitempty"`
+
+	Extra []*systemdconfig.OptionValue `systemd:",extra"`
+}
+
+// Address is an address with an optional prefix length, as Address=
+// and Peer= take it, e.g. "192.168.0.15/24" or "fe80::1". It keeps the
+// form it was written in.
+type Address string
+
+// Prefix returns the prefix of a, with the length of a single address,
+// /32 or /128, if a has none, and false if a is not valid.
+func (a Address) Prefix() (netip.Prefix, bool) {
+	if strings.Contains(string(a), "/") {
+		prefix, err := netip.ParsePrefix(string(a))
+		return prefix, err == nil
+	}
+	addr, err := netip.ParseAddr(string(a))
+	if err != nil || addr.Zone() != "" {
+		return netip.Prefix{}, false
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), true
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts an
+// address with or without a prefix length.
+func (a *Address) UnmarshalText(text []byte) error {
+	addr := Address(text)
+	if _, ok := addr.Prefix(); !ok {
+		return fmt.Errorf("invalid address %q", text)
+	}
+	*a = addr
+	return nil
+}
+
+// Gateway is the gateway of a route: an address, or GatewayDHCPv4 or
+// GatewayIPv6RA.
+type Gateway string
+
+// The special gateways of systemd.network(5).
+const (
+	// GatewayDHCPv4 is the gateway the DHCPv4 server provides.
+	GatewayDHCPv4 Gateway = "_dhcp4"
+	// GatewayIPv6RA is the router of an IPv6 router advertisement.
+	GatewayIPv6RA Gateway = "_ipv6ra"
+)
+
+// Addr returns the address of g, and false for a special gateway.
+func (g Gateway) Addr() (netip.Addr, bool) {
+	addr, err := netip.ParseAddr(string(g))
+	return addr, err == nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (g Gateway) MarshalText() ([]byte, error) {
+	return []byte(g), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts an
+// address or a special gateway.
+func (g *Gateway) UnmarshalText(text []byte) error {
+	gw := Gateway(text)
+	if _, ok := gw.Addr(); !ok && gw != GatewayDHCPv4 && gw != GatewayIPv6RA {
+		return fmt.Errorf("invalid gateway %q", text)
+	}
+	*g = gw
+	return nil
+}
+
+// DNSServer is a DNS server of DNS=: an address with an optional port,
+// interface and server name for DNS-over-TLS, written as
+// "address[:port][%interface][#name]", e.g. "1.1.1.1#cloudflare-dns.com",
+// "[2001:db8::1]:853" or "fe80::1%eth0".
+type DNSServer struct {
+	Addr netip.Addr
+	// Port is 0 for the default port.
+	Port uint16
+	// Interface is the name or index of the interface, or "".
+	Interface string
+	// Name is the server name for DNS-over-TLS, or "".
+	Name string
+}
+
+// String returns s in the form DNS= takes.
+func (s DNSServer) String() string {
+	var b strings.Builder
+	if s.Port != 0 {
+		b.WriteString(netip.AddrPortFrom(s.Addr, s.Port).String())
+	} else {
+		b.WriteString(s.Addr.String())
+	}
+	if s.Interface != "" {
+		b.WriteString("%" + s.Interface)
+	}
+	if s.Name != "" {
+		b.WriteString("#" + s.Name)
+	}
+	return b.String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s DNSServer) MarshalText() ([]byte, error) {
+	if !s.Addr.IsValid() || s.Addr.Zone() != "" {
+		return nil, fmt.Errorf("invalid DNS server address %v", s.Addr)
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *DNSServer) UnmarshalText(text []byte) error {
+	invalid := func() error {
+		return fmt.Errorf("invalid DNS server %q", text)
+	}
+	var server DNSServer
+	rest, name, hasName := strings.Cut(string(text), "#")
+	rest, iface, hasIface := strings.Cut(rest, "%")
+	if hasName && name == "" || hasIface && iface == "" {
+		return invalid()
+	}
+	server.Name, server.Interface = name, iface
+	if addr, err := netip.ParseAddr(rest); err == nil {
+		server.Addr = addr
+	} else if ap, err := netip.ParseAddrPort(rest); err == nil && ap.Port() != 0 {
+		server.Addr, server.Port = ap.Addr(), ap.Port()
+	} else {
+		return invalid()
+	}
+	*s = server
+	return nil
+}
+
+// DHCPv4Section is the [DHCPv4] section of a .network file, which
+// configures the DHCPv4 client.
+type DHCPv4Section struct {
+	ClientIdentifier      string  `systemd:"ClientIdentifier,omitempty"`
+	VendorClassIdentifier string  `systemd:"VendorClassIdentifier,omitempty"`
+	SendHostname          *bool   `systemd:"SendHostname"`
+	Hostname              string  `systemd:"Hostname,omitempty"`
+	UseDNS                *bool   `systemd:"UseDNS"`
+	UseNTP                *bool   `systemd:"UseNTP"`
+	UseHostname           *bool   `systemd:"UseHostname"`
+	UseDomains            string  `systemd:"UseDomains,omitempty"`
+	UseRoutes             *bool   `systemd:"UseRoutes"`
+	RouteMetric           *uint32 `systemd:"RouteMetric"`
+	RouteTable            string  `systemd:"RouteTable,omitempty"`
+
+	Extra []*systemdconfig.OptionValue `systemd:",extra"`
+}
+
+// Network is a .network file of systemd-networkd, see
+// systemd.network(5).
+type Network struct {
+	Match     MatchSection     `systemd:"Match,omitempty"`
+	Network   NetworkSection   `systemd:"Network,omitempty"`
+	Addresses []AddressSection `systemd:"Address"`
+	Routes    []RouteSection   `systemd:"Route"`
+	DHCPv4    DHCPv4Section    `systemd:"DHCPv4,omitempty"`
+
+	// Extra holds the other sections, such as [Link] and [DHCPv6].
+	Extra []*systemdconfig.Section `systemd:",extra"`
+}
+
+// NetworkFromUnit returns the network configuration u describes. The
+// error is a *systemdconfig.ValueError for an option whose value is
+// malformed, e.g. an Address= that is not an address with a prefix
+// length.
+func NetworkFromUnit(u *systemdconfig.Unit) (*Network, error) {
+	return fromUnit[Network](u)
+}
+
+// ToUnit returns the unit describing n.
+func (n *Network) ToUnit() (*systemdconfig.Unit, error) {
+	return systemdconfig.Marshal(n)
+}
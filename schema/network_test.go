@@ -0,0 +1,303 @@
+package schema
+
+import (
+	"errors"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+)
+
+func TestNetwork_Golden(t *testing.T) {
+	for _, name := range []string{"example.network", "static.network"} {
+		t.Run(name, func(t *testing.T) {
+			network, err := NetworkFromUnit(readUnit(t, name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			u, err := network.ToUnit()
+			if err != nil {
+				t.Fatal(err)
+			}
+			golden, err := os.ReadFile(filepath.Join("..", "testdata", name+".golden"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := u.String(); got != string(golden) {
+				t.Errorf("ToUnit() =\n%s\nwant:\n%s", got, golden)
+			}
+		})
+	}
+}
+
+func TestNetworkFromUnit(t *testing.T) {
+	network, err := NetworkFromUnit(readUnit(t, "static.network"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(network.Match.Name, []string{"enp2s0"}) {
+		t.Errorf("Match.Name = %q", network.Match.Name)
+	}
+	wantDNS := []DNSServer{{Addr: netip.MustParseAddr("192.168.0.1")}, {Addr: netip.MustParseAddr("2001:4860:4860::8888")}}
+	if !reflect.DeepEqual(network.Network.DNS, wantDNS) {
+		t.Errorf("DNS = %v, want %v", network.Network.DNS, wantDNS)
+	}
+	if len(network.Addresses) != 2 {
+		t.Fatalf("got %d addresses, want 2", len(network.Addresses))
+	}
+	first := network.Addresses[0]
+	if first.Address != "192.168.0.15/24" || first.Broadcast != netip.MustParseAddr("192.168.0.255") || first.Label != "uplink" {
+		t.Errorf("Addresses[0] = %+v", first)
+	}
+	if len(network.Routes) != 2 {
+		t.Fatalf("got %d routes, want 2", len(network.Routes))
+	}
+	if r := network.Routes[0]; r.Gateway != "192.168.0.1" || r.Metric == nil || *r.Metric != 100 {
+		t.Errorf("Routes[0] = %+v", r)
+	}
+	if r := network.Routes[1]; r.Destination != netip.MustParsePrefix("10.0.0.0/8") || r.GatewayOnLink == nil || !*r.GatewayOnLink {
+		t.Errorf("Routes[1] = %+v", r)
+	}
+}
+
+func TestNetwork_Gateways(t *testing.T) {
+	in := `[Match]
+Name=eth0
+
+[Network]
+Gateway=_ipv6ra
+
+[Route]
+Gateway=_dhcp4
+Destination=10.0.0.0/8
+
+[Route]
+Gateway=fe80::1
+`
+	u, err := systemdconfig.Deserialize(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	network, err := NetworkFromUnit(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(network.Network.Gateway, []Gateway{GatewayIPv6RA}) {
+		t.Errorf("Network.Gateway = %q", network.Network.Gateway)
+	}
+	if len(network.Routes) != 2 || network.Routes[0].Gateway != GatewayDHCPv4 {
+		t.Fatalf("Routes = %+v", network.Routes)
+	}
+	if addr, ok := network.Routes[1].Gateway.Addr(); !ok || addr != netip.MustParseAddr("fe80::1") {
+		t.Errorf("Routes[1].Gateway.Addr() = %v, %v", addr, ok)
+	}
+	if _, ok := GatewayDHCPv4.Addr(); ok {
+		t.Errorf("GatewayDHCPv4.Addr() reports an address")
+	}
+
+	out, err := network.ToUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := assignments(out), assignments(u); !reflect.DeepEqual(got, want) {
+		t.Errorf("ToUnit() assignments =\n%q\nwant\n%q", got, want)
+	}
+
+	u, err = systemdconfig.Deserialize(strings.NewReader("[Route]\nGateway=_dhcp6\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NetworkFromUnit(u); err == nil {
+		t.Errorf("NetworkFromUnit() with Gateway=_dhcp6 succeeded")
+	}
+}
+
+func TestNetwork_Addresses(t *testing.T) {
+	in := `[Network]
+Address=192.168.0.15
+Address=10.0.0.1/8
+
+[Address]
+Address=fe80::1
+Peer=fe80::2
+`
+	u, err := systemdconfig.Deserialize(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	network, err := NetworkFromUnit(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		addr Address
+		want netip.Prefix
+	}{
+		{network.Network.Address[0], netip.MustParsePrefix("192.168.0.15/32")},
+		{network.Network.Address[1], netip.MustParsePrefix("10.0.0.1/8")},
+		{network.Addresses[0].Address, netip.MustParsePrefix("fe80::1/128")},
+		{network.Addresses[0].Peer, netip.MustParsePrefix("fe80::2/128")},
+	} {
+		if got, ok := tt.addr.Prefix(); !ok || got != tt.want {
+			t.Errorf("Address(%q).Prefix() = %v, %v, want %v", tt.addr, got, ok, tt.want)
+		}
+	}
+	out, err := network.ToUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); got != in {
+		t.Errorf("ToUnit() =\n%s\nwant:\n%s", got, in)
+	}
+
+	for _, addr := range []Address{"", "fe80::1%eth0", "10.0.0.1/33", "example.com"} {
+		if p, ok := addr.Prefix(); ok {
+			t.Errorf("Address(%q).Prefix() = %v, want invalid", addr, p)
+		}
+	}
+}
+
+func TestNetwork_DNSServers(t *testing.T) {
+	in := `[Network]
+DNS=1.1.1.1#cloudflare-dns.com
+DNS=[2001:db8::1]:853
+DNS=fe80::1%eth0#dns.example
+DNS=192.168.0.1:5353%2
+`
+	u, err := systemdconfig.Deserialize(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	network, err := NetworkFromUnit(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []DNSServer{
+		{Addr: netip.MustParseAddr("1.1.1.1"), Name: "cloudflare-dns.com"},
+		{Addr: netip.MustParseAddr("2001:db8::1"), Port: 853},
+		{Addr: netip.MustParseAddr("fe80::1"), Interface: "eth0", Name: "dns.example"},
+		{Addr: netip.MustParseAddr("192.168.0.1"), Port: 5353, Interface: "2"},
+	}
+	if !reflect.DeepEqual(network.Network.DNS, want) {
+		t.Errorf("DNS = %v, want %v", network.Network.DNS, want)
+	}
+	out, err := network.ToUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); got != in {
+		t.Errorf("ToUnit() =\n%s\nwant:\n%s", got, in)
+	}
+
+	for _, in := range []string{"", "dns.example", "1.1.1.1#", "1.1.1.1%", "1.1.1.1:0", "2001:db8::1:853:x", "[fe80::1%eth0]:53"} {
+		var s DNSServer
+		if err := s.UnmarshalText([]byte(in)); err == nil {
+			t.Errorf("UnmarshalText(%q) = %v, want an error", in, s)
+		}
+	}
+}
+
+func TestNetwork_MatchConditions(t *testing.T) {
+	in := `[Match]
+Name=eth0
+Host=web1
+Host=!web2
+Virtualization=!container
+KernelCommandLine=!nonet
+Architecture=x86-64
+Architecture=arm64
+`
+	u, err := systemdconfig.Deserialize(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	network, err := NetworkFromUnit(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(network.Match.Host, []string{"web1", "!web2"}) {
+		t.Errorf("Match.Host = %q", network.Match.Host)
+	}
+	if !reflect.DeepEqual(network.Match.Architecture, []string{"x86-64", "arm64"}) {
+		t.Errorf("Match.Architecture = %q", network.Match.Architecture)
+	}
+	out, err := network.ToUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); got != in {
+		t.Errorf("ToUnit() =\n%s\nwant:\n%s", got, in)
+	}
+}
+
+func TestNetDevFromUnit(t *testing.T) {
+	netdev, err := NetDevFromUnit(readUnit(t, "wg0.netdev"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if netdev.NetDev.Name != "wg0" || netdev.NetDev.Kind != "wireguard" || netdev.WireGuard.ListenPort != "51820" {
+		t.Errorf("NetDev, WireGuard = %+v, %+v", netdev.NetDev, netdev.WireGuard)
+	}
+	if len(netdev.WireGuardPeers) != 2 {
+		t.Fatalf("got %d peers, want 2", len(netdev.WireGuardPeers))
+	}
+	wantIPs := []netip.Prefix{netip.MustParsePrefix("10.192.122.3/32"), netip.MustParsePrefix("10.192.124.0/24")}
+	if peer := netdev.WireGuardPeers[0]; !reflect.DeepEqual(peer.AllowedIPs, wantIPs) || peer.PersistentKeepalive != "25" {
+		t.Errorf("WireGuardPeers[0] = %+v", peer)
+	}
+	if peer := netdev.WireGuardPeers[1]; peer.Endpoint != "[2607:5300:60:6b0::c05f:543]:2468" {
+		t.Errorf("WireGuardPeers[1].Endpoint = %q", peer.Endpoint)
+	}
+
+	u, err := netdev.ToUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(u.String(), "AllowedIPs=10.192.122.3/32\nAllowedIPs=10.192.124.0/24\n") {
+		t.Errorf("ToUnit() =\n%s\nwant one AllowedIPs= per prefix", u)
+	}
+	back, err := NetDevFromUnit(u)
+	if err != nil || !reflect.DeepEqual(back, netdev) {
+		t.Errorf("NetDevFromUnit(ToUnit()) = %+v, %v, want %+v", back, err, netdev)
+	}
+}
+
+func TestLink_RoundTrip(t *testing.T) {
+	no := false
+	link := &Link{
+		Match: MatchSection{OriginalName: []string{"enx*"}},
+		Link: LinkSection{
+			NamePolicy:       []string{"kernel", "database", "onboard"},
+			MACAddressPolicy: "persistent",
+			AutoNegotiation:  &no,
+		},
+	}
+	u, err := link.ToUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "[Match]\nOriginalName=enx*\n\n[Link]\nMACAddressPolicy=persistent\nNamePolicy=kernel\nNamePolicy=database\nNamePolicy=onboard\nAutoNegotiation=no\n"
+	if got := u.String(); got != want {
+		t.Errorf("ToUnit() =\n%s\nwant:\n%s", got, want)
+	}
+	back, err := LinkFromUnit(u)
+	if err != nil || !reflect.DeepEqual(back, link) {
+		t.Errorf("LinkFromUnit(ToUnit()) = %+v, %v, want %+v", back, err, link)
+	}
+}
+
+func TestNetworkFromUnit_Invalid(t *testing.T) {
+	u, err := systemdconfig.Deserialize(strings.NewReader("[Address]\nAddress=10.0.0.300/24\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = NetworkFromUnit(u)
+	var verr *systemdconfig.ValueError
+	if !errors.As(err, &verr) || verr.Section != "Address" || verr.Option != "Address" {
+		t.Errorf("NetworkFromUnit() error = %v, want a *ValueError for Address.Address", err)
+	}
+}
@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"time"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+)
+
+// PathSection is the [Path] section of a .path unit, see
+// systemd.path(5). Each kind of path may be given any number of times.
+type PathSection struct {
+	PathExists        []string `systemd:"PathExists"`
+	PathExistsGlob    []string `systemd:"PathExistsGlob"`
+	PathChanged       []string `systemd:"PathChanged"`
+	PathModified      []string `systemd:"PathModified"`
+	DirectoryNotEmpty []string `systemd:"DirectoryNotEmpty"`
+
+	// Unit is the unit to activate, by default the service with the
+	// name of the path unit.
+	Unit                    string         `systemd:"Unit,omitempty"`
+	MakeDirectory           *bool          `systemd:"MakeDirectory"`
+	DirectoryMode           string         `systemd:"DirectoryMode,omitempty"`
+	TriggerLimitIntervalSec *time.Duration `systemd:"TriggerLimitIntervalSec"`
+	TriggerLimitBurst       *uint          `systemd:"TriggerLimitBurst"`
+
+	Extra []*systemdconfig.OptionValue `systemd:",extra"`
+}
+
+// Path is a .path unit.
+type Path struct {
+	Unit    UnitSection    `systemd:"Unit,omitempty"`
+	Path    PathSection    `systemd:"Path,omitempty"`
+	Install InstallSection `systemd:"Install,omitempty"`
+
+	Extra []*systemdconfig.Section `systemd:",extra"`
+}
+
+// PathFromUnit returns the path unit u describes. The error is a
+// *systemdconfig.ValueError for an option whose value is malformed.
+func PathFromUnit(u *systemdconfig.Unit) (*Path, error) {
+	return fromUnit[Path](u)
+}
+
+// ToUnit returns the unit describing p.
+func (p *Path) ToUnit() (*systemdconfig.Unit, error) {
+	return systemdconfig.Marshal(p)
+}
@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"reflect"
+	"testing"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+)
+
+func TestPath_RoundTrip(t *testing.T) {
+	u := systemdconfig.NewUnit()
+	u.AddSection("Unit").AddOption("Description", "Watch the spool")
+	s := u.AddSection("Path")
+	s.AddOption("PathExistsGlob", "/var/spool/jobs/*.job")
+	s.AddOption("DirectoryNotEmpty", "/var/spool/jobs")
+	s.AddOption("DirectoryNotEmpty", "/var/spool/urgent")
+	s.AddOption("Unit", "jobs.service")
+	s.AddOption("MakeDirectory", "yes")
+	s.AddOption("DirectoryMode", "0750")
+	u.AddSection("Install").AddOption("WantedBy", "paths.target")
+
+	path, err := PathFromUnit(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"/var/spool/jobs", "/var/spool/urgent"}; !reflect.DeepEqual(path.Path.DirectoryNotEmpty, want) {
+		t.Errorf("DirectoryNotEmpty = %q, want %q", path.Path.DirectoryNotEmpty, want)
+	}
+	if path.Path.Unit != "jobs.service" || path.Path.MakeDirectory == nil || !*path.Path.MakeDirectory {
+		t.Errorf("Path = %+v", path.Path)
+	}
+
+	out, err := path.ToUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); got != u.String() {
+		t.Errorf("ToUnit() =\n%s\nwant:\n%s", got, u)
+	}
+}
@@ -0,0 +1,94 @@
+package schema
+
+import (
+	"time"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+)
+
+// ServiceType is the Type= of a service, how systemd tells that it has
+// started.
+type ServiceType string
+
+// The service types of systemd.service(5).
+const (
+	ServiceSimple       ServiceType = "simple"
+	ServiceExec         ServiceType = "exec"
+	ServiceForking      ServiceType = "forking"
+	ServiceOneshot      ServiceType = "oneshot"
+	ServiceDBus         ServiceType = "dbus"
+	ServiceNotify       ServiceType = "notify"
+	ServiceNotifyReload ServiceType = "notify-reload"
+	ServiceIdle         ServiceType = "idle"
+)
+
+// RestartPolicy is the Restart= of a service, when systemd restarts it.
+type RestartPolicy string
+
+// The restart policies of systemd.service(5).
+const (
+	RestartNo         RestartPolicy = "no"
+	RestartOnSuccess  RestartPolicy = "on-success"
+	RestartOnFailure  RestartPolicy = "on-failure"
+	RestartOnAbnormal RestartPolicy = "on-abnormal"
+	RestartOnWatchdog RestartPolicy = "on-watchdog"
+	RestartOnAbort    RestartPolicy = "on-abort"
+	RestartAlways     RestartPolicy = "always"
+)
+
+// ServiceSection is the [Service] section of a .service unit, see
+// systemd.service(5).
+type ServiceSection struct {
+	Type            ServiceType `systemd:"Type,omitempty"`
+	RemainAfterExit *bool       `systemd:"RemainAfterExit"`
+	PIDFile         string      `systemd:"PIDFile,omitempty"`
+	BusName         string      `systemd:"BusName,omitempty"`
+	NotifyAccess    string      `systemd:"NotifyAccess,omitempty"`
+
+	ExecCondition []Command `systemd:"ExecCondition"`
+	ExecStartPre  []Command `systemd:"ExecStartPre"`
+	ExecStart     []Command `systemd:"ExecStart"`
+	ExecStartPost []Command `systemd:"ExecStartPost"`
+	ExecReload    []Command `systemd:"ExecReload"`
+	ExecStop      []Command `systemd:"ExecStop"`
+	ExecStopPost  []Command `systemd:"ExecStopPost"`
+
+	Restart                  RestartPolicy  `systemd:"Restart,omitempty"`
+	RestartSec               *time.Duration `systemd:"RestartSec"`
+	SuccessExitStatus        []string       `systemd:"SuccessExitStatus,words"`
+	RestartPreventExitStatus []string       `systemd:"RestartPreventExitStatus,words"`
+	RestartForceExitStatus   []string       `systemd:"RestartForceExitStatus,words"`
+
+	TimeoutSec      *time.Duration `systemd:"TimeoutSec"`
+	TimeoutStartSec *time.Duration `systemd:"TimeoutStartSec"`
+	TimeoutStopSec  *time.Duration `systemd:"TimeoutStopSec"`
+	RuntimeMaxSec   *time.Duration `systemd:"RuntimeMaxSec"`
+	WatchdogSec     *time.Duration `systemd:"WatchdogSec"`
+
+	ExecSettings
+	KillSettings
+
+	Extra []*systemdconfig.OptionValue `systemd:",extra"`
+}
+
+// Service is a .service unit.
+type Service struct {
+	Unit    UnitSection    `systemd:"Unit,omitempty"`
+	Service ServiceSection `systemd:"Service,omitempty"`
+	Install InstallSection `systemd:"Install,omitempty"`
+
+	// Extra holds the sections other than [Unit], [Service] and
+	// [Install], such as those of drop-ins for other unit types.
+	Extra []*systemdconfig.Section `systemd:",extra"`
+}
+
+// ServiceFromUnit returns the service u describes. The error is a
+// *systemdconfig.ValueError for an option whose value is malformed.
+func ServiceFromUnit(u *systemdconfig.Unit) (*Service, error) {
+	return fromUnit[Service](u)
+}
+
+// ToUnit returns the unit describing s.
+func (s *Service) ToUnit() (*systemdconfig.Unit, error) {
+	return systemdconfig.Marshal(s)
+}
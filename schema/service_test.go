@@ -0,0 +1,215 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+)
+
+func readUnit(t *testing.T, name string) *systemdconfig.Unit {
+	t.Helper()
+	f, err := os.Open(filepath.Join("..", "testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	u, err := systemdconfig.Deserialize(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+// assignments returns the assignments of u as "[Section] Option=Value"
+// lines, sorted.
+func assignments(u *systemdconfig.Unit) []string {
+	var lines []string
+	for _, s := range u.Sections {
+		for _, o := range s.Options {
+			lines = append(lines, "["+s.Name+"] "+o.Option+"="+o.Value)
+		}
+	}
+	slices.Sort(lines)
+	return lines
+}
+
+func TestServiceFromUnit(t *testing.T) {
+	svc, err := ServiceFromUnit(readUnit(t, "docker.service"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if svc.Service.Type != ServiceNotify || svc.Service.Restart != RestartAlways {
+		t.Errorf("Type, Restart = %q, %q", svc.Service.Type, svc.Service.Restart)
+	}
+	wantAfter := []string{"network-online.target", "docker.socket", "firewalld.service", "containerd.service", "time-set.target"}
+	if !reflect.DeepEqual(svc.Unit.After, wantAfter) {
+		t.Errorf("After = %q, want %q", svc.Unit.After, wantAfter)
+	}
+	wantExec := []Command{{Argv: []string{"/usr/bin/dockerd", "-H", "fd://", "--containerd=/run/containerd/containerd.sock"}}}
+	if !reflect.DeepEqual(svc.Service.ExecStart, wantExec) {
+		t.Errorf("ExecStart = %q, want %q", svc.Service.ExecStart, wantExec)
+	}
+	if d := svc.Service.TimeoutStartSec; d == nil || *d != 0 {
+		t.Errorf("TimeoutStartSec = %v, want 0", d)
+	}
+	if d := svc.Service.RestartSec; d == nil || *d != 2*time.Second {
+		t.Errorf("RestartSec = %v, want 2s", d)
+	}
+	if svc.Service.KillMode != "process" || svc.Service.LimitNPROC != "infinity" || *svc.Service.OOMScoreAdjust != -500 {
+		t.Errorf("KillMode, LimitNPROC, OOMScoreAdjust = %q, %q, %d", svc.Service.KillMode, svc.Service.LimitNPROC, *svc.Service.OOMScoreAdjust)
+	}
+	var extra []string
+	for _, o := range svc.Service.Extra {
+		extra = append(extra, o.Option)
+	}
+	if want := []string{"StartLimitBurst", "StartLimitInterval", "TasksMax", "Delegate"}; !reflect.DeepEqual(extra, want) {
+		t.Errorf("Service.Extra = %q, want %q", extra, want)
+	}
+	if !reflect.DeepEqual(svc.Install.WantedBy, []string{"multi-user.target"}) {
+		t.Errorf("WantedBy = %q", svc.Install.WantedBy)
+	}
+}
+
+func TestService_RoundTrip(t *testing.T) {
+	files, err := filepath.Glob("../testdata/*.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			u := readUnit(t, filepath.Base(file))
+			svc, err := ServiceFromUnit(u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, err := svc.ToUnit()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// every option survives, written the way systemd reads it
+			again, err := ServiceFromUnit(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out2, err := again.ToUnit()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if out.String() != out2.String() {
+				t.Errorf("second round trip =\n%s\nwant:\n%s", out2, out)
+			}
+			if got, want := len(assignments(out)), len(assignments(u)); got < want {
+				t.Errorf("ToUnit() has %d assignments, want at least %d:\n%s", got, want, out)
+			}
+		})
+	}
+}
+
+func TestService_ToUnit(t *testing.T) {
+	yes := true
+	timeout := 90 * time.Second
+	svc := &Service{
+		Unit: UnitSection{Description: "My app", After: []string{"network-online.target"}, Wants: []string{"network-online.target"}},
+		Service: ServiceSection{
+			Type:            ServiceExec,
+			ExecStartPre:    []Command{{Prefix: "-", Argv: []string{"/bin/mkdir", "-p", "/run/my app"}}},
+			ExecStart:       []Command{{Argv: []string{"/usr/bin/app", "--name", "my app"}}},
+			Restart:         RestartOnFailure,
+			TimeoutStartSec: &timeout,
+			ExecSettings: ExecSettings{
+				User:            "app",
+				Environment:     []string{"GOMAXPROCS=2", "GREETING=hello world"},
+				NoNewPrivileges: &yes,
+			},
+		},
+		Install: InstallSection{WantedBy: []string{"multi-user.target"}},
+	}
+	u, err := svc.ToUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[Unit]
+Description=My app
+Wants=network-online.target
+After=network-online.target
+
+[Service]
+Type=exec
+ExecStartPre=-/bin/mkdir -p "/run/my app"
+ExecStart=/usr/bin/app --name "my app"
+Restart=on-failure
+TimeoutStartSec=1min 30s
+User=app
+Environment=GOMAXPROCS=2
+Environment="GREETING=hello world"
+NoNewPrivileges=yes
+
+[Install]
+WantedBy=multi-user.target
+`
+	if got := u.String(); got != want {
+		t.Errorf("ToUnit() =\n%s\nwant:\n%s", got, want)
+	}
+
+	back, err := ServiceFromUnit(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back, svc) {
+		t.Errorf("ServiceFromUnit(ToUnit()) = %+v, want %+v", back, svc)
+	}
+}
+
+func TestService_Capabilities(t *testing.T) {
+	in := `[Service]
+CapabilityBoundingSet=~CAP_SYS_ADMIN CAP_NET_RAW
+CapabilityBoundingSet=CAP_CHOWN
+AmbientCapabilities=CAP_NET_BIND_SERVICE CAP_NET_ADMIN
+`
+	u, err := systemdconfig.Deserialize(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc, err := ServiceFromUnit(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []CapabilitySet{
+		{Invert: true, Capabilities: []string{"CAP_SYS_ADMIN", "CAP_NET_RAW"}},
+		{Capabilities: []string{"CAP_CHOWN"}},
+	}
+	if got := svc.Service.CapabilityBoundingSet; !reflect.DeepEqual(got, want) {
+		t.Errorf("CapabilityBoundingSet = %+v, want %+v", got, want)
+	}
+	out, err := svc.ToUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); got != in {
+		t.Errorf("ToUnit() =\n%s\nwant:\n%s", got, in)
+	}
+}
+
+func TestServiceFromUnit_Invalid(t *testing.T) {
+	for _, input := range []string{
+		"[Service]\nRestartSec=soon\n",
+		"[Service]\nExecStart=-\n",
+		"[Service]\nExecStart=/bin/echo \"unterminated\n",
+		"[Unit]\nDefaultDependencies=perhaps\n",
+	} {
+		u, err := systemdconfig.Deserialize(strings.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ServiceFromUnit(u); err == nil {
+			t.Errorf("ServiceFromUnit(%q) succeeded", input)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+)
+
+// ErrInvalidAddress is wrapped by the error ParseSocketAddress and
+// ParseNetlinkAddress return for a malformed address.
+var ErrInvalidAddress = errors.New("invalid socket address")
+
+// SocketFamily is the kind of a SocketAddress.
+type SocketFamily int
+
+// The kinds of socket addresses of systemd.socket(5).
+const (
+	// SocketUnix is a Unix socket in the file system, e.g.
+	// "/run/docker.sock".
+	SocketUnix SocketFamily = iota + 1
+	// SocketAbstract is a Unix socket in the abstract namespace, written
+	// with a leading "@".
+	SocketAbstract
+	// SocketInet is an IPv4 or IPv6 address and port, e.g.
+	// "127.0.0.1:80" or "[::1]:80", or a port alone, which listens on
+	// every address.
+	SocketInet
+	// SocketVsock is an AF_VSOCK address, e.g. "vsock:2:1234".
+	SocketVsock
+	// SocketUnexpanded is an address with specifiers, e.g.
+	// "%t/podman/podman.sock", kept verbatim in Path. Expand them with
+	// systemdconfig.ExpandSpecifiers to parse the address.
+	SocketUnexpanded
+)
+
+// VsockCIDAny is the CID of a vsock address without one, "vsock::1234",
+// which listens for any CID.
+const VsockCIDAny = math.MaxUint32
+
+// SocketAddress is an address of ListenStream=, ListenDatagram= or
+// ListenSequentialPacket=.
+type SocketAddress struct {
+	Family SocketFamily
+	// Path is the path of a SocketUnix, the name of a SocketAbstract,
+	// without the "@", or the address of a SocketUnexpanded.
+	Path string
+	// Addr is the address of a SocketInet, with its zone; it is invalid
+	// for a port alone.
+	Addr netip.Addr
+	// Port is the port of a SocketInet or SocketVsock.
+	Port uint32
+	// CID is the context identifier of a SocketVsock.
+	CID uint32
+}
+
+// ParseSocketAddress parses a socket address in the syntax of
+// systemd.socket(5). The path of a Unix socket may contain specifiers,
+// e.g. "/run/%p.sock"; an address that is only one once its specifiers
+// are expanded, e.g. "%t/podman/podman.sock", is a SocketUnexpanded.
+func ParseSocketAddress(s string) (SocketAddress, error) {
+	invalid := func() (SocketAddress, error) {
+		if strings.Contains(s, "%") {
+			return SocketAddress{Family: SocketUnexpanded, Path: s}, nil
+		}
+		return SocketAddress{}, fmt.Errorf("%w %q", ErrInvalidAddress, s)
+	}
+	switch {
+	case strings.HasPrefix(s, "/"):
+		return SocketAddress{Family: SocketUnix, Path: s}, nil
+	case strings.HasPrefix(s, "@") && len(s) > 1:
+		return SocketAddress{Family: SocketAbstract, Path: s[1:]}, nil
+	case strings.HasPrefix(s, "vsock:"):
+		cid, port, ok := strings.Cut(strings.TrimPrefix(s, "vsock:"), ":")
+		if !ok {
+			return invalid()
+		}
+		a := SocketAddress{Family: SocketVsock, CID: VsockCIDAny}
+		if cid != "" {
+			n, err := strconv.ParseUint(cid, 10, 32)
+			if err != nil {
+				return invalid()
+			}
+			a.CID = uint32(n)
+		}
+		n, err := strconv.ParseUint(port, 10, 32)
+		if err != nil {
+			return invalid()
+		}
+		a.Port = uint32(n)
+		return a, nil
+	}
+
+	if n, err := strconv.ParseUint(s, 10, 16); err == nil && n > 0 {
+		return SocketAddress{Family: SocketInet, Port: uint32(n)}, nil
+	}
+	ap, err := netip.ParseAddrPort(s)
+	if err != nil || ap.Port() == 0 {
+		return invalid()
+	}
+	return SocketAddress{Family: SocketInet, Addr: ap.Addr(), Port: uint32(ap.Port())}, nil
+}
+
+// String returns the address in the syntax of systemd.socket(5).
+func (a SocketAddress) String() string {
+	switch a.Family {
+	case SocketUnix, SocketUnexpanded:
+		return a.Path
+	case SocketAbstract:
+		return "@" + a.Path
+	case SocketInet:
+		if !a.Addr.IsValid() {
+			return strconv.FormatUint(uint64(a.Port), 10)
+		}
+		return netip.AddrPortFrom(a.Addr, uint16(a.Port)).String()
+	case SocketVsock:
+		if a.CID == VsockCIDAny {
+			return fmt.Sprintf("vsock::%d", a.Port)
+		}
+		return fmt.Sprintf("vsock:%d:%d", a.CID, a.Port)
+	}
+	return ""
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (a SocketAddress) MarshalText() ([]byte, error) {
+	if a.Family < SocketUnix || a.Family > SocketUnexpanded {
+		return nil, fmt.Errorf("%w: unknown family %d", ErrInvalidAddress, a.Family)
+	}
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (a *SocketAddress) UnmarshalText(text []byte) error {
+	parsed, err := ParseSocketAddress(string(text))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// NetlinkAddress is an address of ListenNetlink=: a netlink family and
+// an optional multicast group, e.g. "kobject-uevent 1".
+type NetlinkAddress struct {
+	Family string
+	Group  uint32
+}
+
+// ParseNetlinkAddress parses the value of ListenNetlink=.
+func ParseNetlinkAddress(s string) (NetlinkAddress, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return NetlinkAddress{}, fmt.Errorf("%w %q", ErrInvalidAddress, s)
+	}
+	a := NetlinkAddress{Family: fields[0]}
+	if len(fields) == 2 {
+		n, err := strconv.ParseUint(fields[1], 10, 32)
+		if err != nil {
+			return NetlinkAddress{}, fmt.Errorf("%w %q", ErrInvalidAddress, s)
+		}
+		a.Group = uint32(n)
+	}
+	return a, nil
+}
+
+// String returns the address as ListenNetlink= takes it.
+func (a NetlinkAddress) String() string {
+	if a.Group == 0 {
+		return a.Family
+	}
+	return fmt.Sprintf("%s %d", a.Family, a.Group)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (a NetlinkAddress) MarshalText() ([]byte, error) {
+	if a.Family == "" {
+		return nil, fmt.Errorf("%w: no netlink family", ErrInvalidAddress)
+	}
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (a *NetlinkAddress) UnmarshalText(text []byte) error {
+	parsed, err := ParseNetlinkAddress(string(text))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// SocketSection is the [Socket] section of a .socket unit, see
+// systemd.socket(5).
+type SocketSection struct {
+	ListenStream           []SocketAddress  `systemd:"ListenStream"`
+	ListenDatagram         []SocketAddress  `systemd:"ListenDatagram"`
+	ListenSequentialPacket []SocketAddress  `systemd:"ListenSequentialPacket"`
+	ListenFIFO             []string         `systemd:"ListenFIFO"`
+	ListenSpecial          []string         `systemd:"ListenSpecial"`
+	ListenNetlink          []NetlinkAddress `systemd:"ListenNetlink"`
+	ListenMessageQueue     []string         `systemd:"ListenMessageQueue"`
+
+	// BindIPv6Only is "default", "both" or "ipv6-only".
+	BindIPv6Only string `systemd:"BindIPv6Only,omitempty"`
+	Backlog      *uint  `systemd:"Backlog"`
+	BindToDevice string `systemd:"BindToDevice,omitempty"`
+	// SocketMode and DirectoryMode are octal, e.g. "0660".
+	SocketMode    string   `systemd:"SocketMode,omitempty"`
+	DirectoryMode string   `systemd:"DirectoryMode,omitempty"`
+	SocketUser    string   `systemd:"SocketUser,omitempty"`
+	SocketGroup   string   `systemd:"SocketGroup,omitempty"`
+	Symlinks      []string `systemd:"Symlinks,words"`
+
+	Accept                  *bool `systemd:"Accept"`
+	Writable                *bool `systemd:"Writable"`
+	MaxConnections          *uint `systemd:"MaxConnections"`
+	MaxConnectionsPerSource *uint `systemd:"MaxConnectionsPerSource"`
+	KeepAlive               *bool `systemd:"KeepAlive"`
+	NoDelay                 *bool `systemd:"NoDelay"`
+	ReusePort               *bool `systemd:"ReusePort"`
+	FreeBind                *bool `systemd:"FreeBind"`
+	Transparent             *bool `systemd:"Transparent"`
+	PassCredentials         *bool `systemd:"PassCredentials"`
+	RemoveOnStop            *bool `systemd:"RemoveOnStop"`
+
+	Service                 string         `systemd:"Service,omitempty"`
+	FileDescriptorName      string         `systemd:"FileDescriptorName,omitempty"`
+	TriggerLimitIntervalSec *time.Duration `systemd:"TriggerLimitIntervalSec"`
+	TriggerLimitBurst       *uint          `systemd:"TriggerLimitBurst"`
+
+	ExecStartPre  []Command      `systemd:"ExecStartPre"`
+	ExecStartPost []Command      `systemd:"ExecStartPost"`
+	ExecStopPre   []Command      `systemd:"ExecStopPre"`
+	ExecStopPost  []Command      `systemd:"ExecStopPost"`
+	TimeoutSec    *time.Duration `systemd:"TimeoutSec"`
+
+	ExecSettings
+	KillSettings
+
+	Extra []*systemdconfig.OptionValue `systemd:",extra"`
+}
+
+// Socket is a .socket unit.
+type Socket struct {
+	Unit    UnitSection    `systemd:"Unit,omitempty"`
+	Socket  SocketSection  `systemd:"Socket,omitempty"`
+	Install InstallSection `systemd:"Install,omitempty"`
+
+	Extra []*systemdconfig.Section `systemd:",extra"`
+}
+
+// SocketFromUnit returns the socket u describes. The error is a
+// *systemdconfig.ValueError for an option whose value is malformed.
+func SocketFromUnit(u *systemdconfig.Unit) (*Socket, error) {
+	return fromUnit[Socket](u)
+}
+
+// ToUnit returns the unit describing s.
+func (s *Socket) ToUnit() (*systemdconfig.Unit, error) {
+	return systemdconfig.Marshal(s)
+}
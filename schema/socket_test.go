@@ -0,0 +1,158 @@
+package schema
+
+import (
+	"errors"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+)
+
+func TestParseSocketAddress(t *testing.T) {
+	tests := []struct {
+		in   string
+		want SocketAddress
+		str  string
+	}{
+		{"/run/docker.sock", SocketAddress{Family: SocketUnix, Path: "/run/docker.sock"}, ""},
+		{"@systemd/notify", SocketAddress{Family: SocketAbstract, Path: "systemd/notify"}, ""},
+		{"2375", SocketAddress{Family: SocketInet, Port: 2375}, ""},
+		{"127.0.0.1:80", SocketAddress{Family: SocketInet, Addr: netip.MustParseAddr("127.0.0.1"), Port: 80}, ""},
+		{"[::1]:8080", SocketAddress{Family: SocketInet, Addr: netip.MustParseAddr("::1"), Port: 8080}, ""},
+		{"[fe80::1%eth0]:53", SocketAddress{Family: SocketInet, Addr: netip.MustParseAddr("fe80::1%eth0"), Port: 53}, ""},
+		{"vsock:2:1234", SocketAddress{Family: SocketVsock, CID: 2, Port: 1234}, ""},
+		{"vsock::1234", SocketAddress{Family: SocketVsock, CID: VsockCIDAny, Port: 1234}, ""},
+		{"%t/podman/podman.sock", SocketAddress{Family: SocketUnexpanded, Path: "%t/podman/podman.sock"}, ""},
+		{"/run/%p.sock", SocketAddress{Family: SocketUnix, Path: "/run/%p.sock"}, ""},
+		{"vsock:%i:1", SocketAddress{Family: SocketUnexpanded, Path: "vsock:%i:1"}, ""},
+	}
+	for _, tt := range tests {
+		got, err := ParseSocketAddress(tt.in)
+		if err != nil {
+			t.Errorf("ParseSocketAddress(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSocketAddress(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+		if s := got.String(); s != tt.in {
+			t.Errorf("String() = %q, want %q", s, tt.in)
+		}
+	}
+
+	for _, in := range []string{"", "@", "0", "65536", "localhost:80", "127.0.0.1", "127.0.0.1:0", "vsock:2", "vsock:x:1", "run/docker.sock"} {
+		if _, err := ParseSocketAddress(in); !errors.Is(err, ErrInvalidAddress) {
+			t.Errorf("ParseSocketAddress(%q) error = %v, want ErrInvalidAddress", in, err)
+		}
+	}
+}
+
+func TestParseNetlinkAddress(t *testing.T) {
+	a, err := ParseNetlinkAddress("kobject-uevent 1")
+	if err != nil || a != (NetlinkAddress{Family: "kobject-uevent", Group: 1}) {
+		t.Errorf("ParseNetlinkAddress = %+v, %v", a, err)
+	}
+	if a.String() != "kobject-uevent 1" {
+		t.Errorf("String() = %q", a.String())
+	}
+	for _, in := range []string{"", "route x", "route 1 2"} {
+		if _, err := ParseNetlinkAddress(in); !errors.Is(err, ErrInvalidAddress) {
+			t.Errorf("ParseNetlinkAddress(%q) error = %v, want ErrInvalidAddress", in, err)
+		}
+	}
+}
+
+func TestSocket_Golden(t *testing.T) {
+	socket, err := SocketFromUnit(readUnit(t, "docker.socket"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []SocketAddress{{Family: SocketUnix, Path: "/run/docker.sock"}}
+	if !reflect.DeepEqual(socket.Socket.ListenStream, want) {
+		t.Errorf("ListenStream = %v, want %v", socket.Socket.ListenStream, want)
+	}
+	if socket.Socket.SocketMode != "0660" || socket.Socket.SocketGroup != "docker" {
+		t.Errorf("SocketMode, SocketGroup = %q, %q", socket.Socket.SocketMode, socket.Socket.SocketGroup)
+	}
+
+	u, err := socket.ToUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden, err := os.ReadFile(filepath.Join("..", "testdata", "docker.socket.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := u.String(); got != string(golden) {
+		t.Errorf("ToUnit() =\n%s\nwant:\n%s", got, golden)
+	}
+}
+
+func TestSocket_RoundTrip(t *testing.T) {
+	u := systemdconfig.NewUnit()
+	s := u.AddSection("Socket")
+	s.AddOption("ListenStream", "[::]:22")
+	s.AddOption("ListenDatagram", "@journal")
+	s.AddOption("ListenFIFO", "/run/initctl")
+	s.AddOption("ListenNetlink", "audit 1")
+	s.AddOption("Accept", "yes")
+	s.AddOption("ExecStartPre", "-/bin/true")
+	s.AddOption("MaxConnections", "64")
+
+	socket, err := SocketFromUnit(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a := socket.Socket.ListenStream; len(a) != 1 || a[0].Addr != netip.IPv6Unspecified() || a[0].Port != 22 {
+		t.Errorf("ListenStream = %+v", a)
+	}
+	if got := socket.Socket.ListenNetlink; !reflect.DeepEqual(got, []NetlinkAddress{{Family: "audit", Group: 1}}) {
+		t.Errorf("ListenNetlink = %+v", got)
+	}
+	out, err := socket.ToUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := assignments(out), assignments(u); !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestSocketFromUnit_Specifiers(t *testing.T) {
+	u, err := systemdconfig.Deserialize(strings.NewReader(`[Socket]
+ListenStream=%t/podman/podman.sock
+SocketMode=0660
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sock, err := SocketFromUnit(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []SocketAddress{{Family: SocketUnexpanded, Path: "%t/podman/podman.sock"}}
+	if !reflect.DeepEqual(sock.Socket.ListenStream, want) {
+		t.Errorf("ListenStream = %+v, want %+v", sock.Socket.ListenStream, want)
+	}
+	out, err := sock.ToUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := out.Value("Socket", "ListenStream"); v != "%t/podman/podman.sock" {
+		t.Errorf("ToUnit() ListenStream = %q", v)
+	}
+}
+
+func TestSocketFromUnit_Invalid(t *testing.T) {
+	u := systemdconfig.NewUnit()
+	u.AddSection("Socket").AddOption("ListenStream", "localhost:80")
+	_, err := SocketFromUnit(u)
+	var ve *systemdconfig.ValueError
+	if !errors.As(err, &ve) || ve.Option != "ListenStream" || !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("SocketFromUnit() error = %v", err)
+	}
+}
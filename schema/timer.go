@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"time"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+	"github.com/javadh75/systemd-config/calendar"
+)
+
+// TimerSection is the [Timer] section of a .timer unit, see
+// systemd.timer(5). The monotonic timers and OnCalendar= may be given
+// any number of times.
+type TimerSection struct {
+	OnActiveSec       []time.Duration `systemd:"OnActiveSec"`
+	OnBootSec         []time.Duration `systemd:"OnBootSec"`
+	OnStartupSec      []time.Duration `systemd:"OnStartupSec"`
+	OnUnitActiveSec   []time.Duration `systemd:"OnUnitActiveSec"`
+	OnUnitInactiveSec []time.Duration `systemd:"OnUnitInactiveSec"`
+	OnCalendar        []calendar.Spec `systemd:"OnCalendar"`
+	OnClockChange     *bool           `systemd:"OnClockChange"`
+	OnTimezoneChange  *bool           `systemd:"OnTimezoneChange"`
+
+	// Unit is the unit to activate, by default the service with the
+	// name of the timer.
+	Unit               string         `systemd:"Unit,omitempty"`
+	RandomizedDelaySec *time.Duration `systemd:"RandomizedDelaySec"`
+	FixedRandomDelay   *bool          `systemd:"FixedRandomDelay"`
+	Persistent         *bool          `systemd:"Persistent"`
+	WakeSystem         *bool          `systemd:"WakeSystem"`
+	RemainAfterElapse  *bool          `systemd:"RemainAfterElapse"`
+	AccuracySec        *time.Duration `systemd:"AccuracySec"`
+
+	Extra []*systemdconfig.OptionValue `systemd:",extra"`
+}
+
+// Timer is a .timer unit.
+type Timer struct {
+	Unit    UnitSection    `systemd:"Unit,omitempty"`
+	Timer   TimerSection   `systemd:"Timer,omitempty"`
+	Install InstallSection `systemd:"Install,omitempty"`
+
+	Extra []*systemdconfig.Section `systemd:",extra"`
+}
+
+// TimerFromUnit returns the timer u describes. The error is a
+// *systemdconfig.ValueError for an option whose value is malformed, such
+// as an OnCalendar= calendar.Parse rejects.
+func TimerFromUnit(u *systemdconfig.Unit) (*Timer, error) {
+	return fromUnit[Timer](u)
+}
+
+// ToUnit returns the unit describing t. Calendar events are written in
+// their normalized form.
+func (t *Timer) ToUnit() (*systemdconfig.Unit, error) {
+	return systemdconfig.Marshal(t)
+}
@@ -0,0 +1,100 @@
+package schema
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+	"github.com/javadh75/systemd-config/calendar"
+)
+
+func TestTimerFromUnit(t *testing.T) {
+	timer, err := TimerFromUnit(readUnit(t, "backup.timer"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timer.Timer.OnCalendar) != 1 {
+		t.Fatalf("got %d OnCalendar, want 1", len(timer.Timer.OnCalendar))
+	}
+	after := time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local)
+	if next, ok := timer.Timer.OnCalendar[0].Next(after); !ok || !next.Equal(time.Date(2024, 3, 2, 2, 0, 0, 0, time.Local)) {
+		t.Errorf("OnCalendar.Next(%v) = %v, %v", after, next, ok)
+	}
+	if d := timer.Timer.RandomizedDelaySec; d == nil || *d != 30*time.Minute {
+		t.Errorf("RandomizedDelaySec = %v, want 30m", d)
+	}
+	if d := timer.Timer.AccuracySec; d == nil || *d != time.Hour {
+		t.Errorf("AccuracySec = %v, want 1h", d)
+	}
+	if p := timer.Timer.Persistent; p == nil || !*p {
+		t.Errorf("Persistent = %v, want true", p)
+	}
+
+	u, err := timer.ToUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// booleans come out as systemd prints them
+	want := `[Unit]
+Description=Daily backup of /srv
+Requires=backup.service
+
+[Timer]
+OnCalendar=*-*-* 02:00:00
+RandomizedDelaySec=30min
+Persistent=yes
+AccuracySec=1h
+
+[Install]
+WantedBy=timers.target
+`
+	if got := u.String(); got != want {
+		t.Errorf("ToUnit() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTimer_ToUnit(t *testing.T) {
+	spec, err := calendar.Parse("Mon..Fri 9:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	timer := &Timer{Timer: TimerSection{
+		OnBootSec:       []time.Duration{15 * time.Minute},
+		OnUnitActiveSec: []time.Duration{time.Hour, 90 * time.Minute},
+		OnCalendar:      []calendar.Spec{*spec},
+		Unit:            "report.service",
+	}}
+	u, err := timer.ToUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[Timer]
+OnBootSec=15min
+OnUnitActiveSec=1h
+OnUnitActiveSec=1h 30min
+OnCalendar=Mon..Fri *-*-* 09:30:00
+Unit=report.service
+`
+	if got := u.String(); got != want {
+		t.Errorf("ToUnit() =\n%s\nwant:\n%s", got, want)
+	}
+
+	back, err := TimerFromUnit(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(back.Timer.OnCalendar) != 1 || back.Timer.OnCalendar[0].String() != spec.String() {
+		t.Errorf("OnCalendar = %v, want %v", back.Timer.OnCalendar, spec)
+	}
+}
+
+func TestTimerFromUnit_Invalid(t *testing.T) {
+	u := systemdconfig.NewUnit()
+	u.AddSection("Timer").AddOption("OnCalendar", "Funday")
+	_, err := TimerFromUnit(u)
+	var ve *systemdconfig.ValueError
+	if !errors.As(err, &ve) || ve.Option != "OnCalendar" || !errors.Is(err, calendar.ErrInvalidSpec) {
+		t.Errorf("TimerFromUnit() error = %v", err)
+	}
+}
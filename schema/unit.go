@@ -0,0 +1,75 @@
+// Package schema provides Go types for the sections of systemd unit
+// files, such as the [Unit], [Service] and [Install] sections of a
+// .service unit, converted to and from a *systemdconfig.Unit with
+// systemdconfig.Marshal and systemdconfig.Unmarshal.
+//
+// Fields hold the options as systemd reads them: lists such as After=
+// are split into words, time spans are time.Durations and command lines
+// are Commands. Options that are unset are the zero value; pointers tell
+// an unset option from one set to a zero value, e.g. TimeoutStartSec=0.
+// Options without a field are kept in the Extra field of their section,
+// and sections without a field in the Extra field of the unit, so that
+// converting a unit to a schema type and back loses nothing but comments
+// and the order of options.
+package schema
+
+import (
+	"time"
+
+	systemdconfig "github.com/javadh75/systemd-config"
+)
+
+// UnitSection is the [Unit] section every unit has, see
+// systemd.unit(5).
+type UnitSection struct {
+	Description   string   `systemd:"Description,omitempty"`
+	Documentation []string `systemd:"Documentation,words"`
+
+	Wants     []string `systemd:"Wants,words"`
+	Requires  []string `systemd:"Requires,words"`
+	Requisite []string `systemd:"Requisite,words"`
+	BindsTo   []string `systemd:"BindsTo,words"`
+	PartOf    []string `systemd:"PartOf,words"`
+	Upholds   []string `systemd:"Upholds,words"`
+	Conflicts []string `systemd:"Conflicts,words"`
+	Before    []string `systemd:"Before,words"`
+	After     []string `systemd:"After,words"`
+	OnFailure []string `systemd:"OnFailure,words"`
+	OnSuccess []string `systemd:"OnSuccess,words"`
+
+	DefaultDependencies   *bool          `systemd:"DefaultDependencies"`
+	StopWhenUnneeded      *bool          `systemd:"StopWhenUnneeded"`
+	RefuseManualStart     *bool          `systemd:"RefuseManualStart"`
+	RefuseManualStop      *bool          `systemd:"RefuseManualStop"`
+	StartLimitIntervalSec *time.Duration `systemd:"StartLimitIntervalSec"`
+	StartLimitBurst       *int           `systemd:"StartLimitBurst"`
+
+	// Conditions and assertions, e.g. "!/etc/ssh/sshd_not_to_be_run";
+	// the others are kept in Extra.
+	ConditionPathExists []string `systemd:"ConditionPathExists"`
+	AssertPathExists    []string `systemd:"AssertPathExists"`
+
+	Extra []*systemdconfig.OptionValue `systemd:",extra"`
+}
+
+// InstallSection is the [Install] section of units that can be enabled,
+// see systemd.unit(5).
+type InstallSection struct {
+	Alias           []string `systemd:"Alias,words"`
+	WantedBy        []string `systemd:"WantedBy,words"`
+	RequiredBy      []string `systemd:"RequiredBy,words"`
+	UpheldBy        []string `systemd:"UpheldBy,words"`
+	Also            []string `systemd:"Also,words"`
+	DefaultInstance string   `systemd:"DefaultInstance,omitempty"`
+
+	Extra []*systemdconfig.OptionValue `systemd:",extra"`
+}
+
+// fromUnit returns the schema type T holding the options of u.
+func fromUnit[T any](u *systemdconfig.Unit) (*T, error) {
+	v := new(T)
+	if err := systemdconfig.Unmarshal(u, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
@@ -4,6 +4,10 @@ package systemdconfig
 type Section struct {
 	Name    string
 	Options []*OptionValue
+
+	pos, end Position
+	src      *source
+	comments []string
 }
 
 // NewSection returns a new section with pre-set name and empty options.
@@ -11,6 +15,24 @@ func NewSection(name string) *Section {
 	return &Section{Name: name, Options: []*OptionValue{}}
 }
 
+// Pos returns the position of the opening bracket of the section header,
+// or an invalid Position when s was not produced by the parser.
+func (s *Section) Pos() Position {
+	return s.pos
+}
+
+// End returns the position immediately after the closing bracket of the
+// section header.
+func (s *Section) End() Position {
+	return s.end
+}
+
+// modified reports whether the name of s differs from the section header
+// it was parsed from in lossless mode.
+func (s *Section) modified() bool {
+	return s.Name != s.src.name
+}
+
 // AddOption appends a new option with the given name and value and
 // returns it.
 func (s *Section) AddOption(option, value string) *OptionValue {
@@ -70,3 +92,19 @@ func (s *Section) Match(other *Section) bool {
 	}
 	return true
 }
+
+// Comments returns the text of the comment block directly above the
+// section header, as parsed by DeserializeLossless or set by SetComments. Each
+// comment is one line without its comment marker ('#' or ';') and the
+// single space following it. Comments separated from the section header by a
+// blank line are not attached to it.
+func (s *Section) Comments() []string {
+	return s.comments
+}
+
+// SetComments replaces the comment block directly above the section header; call
+// it without arguments to remove it. Comments are written as '#' comment
+// lines; a comment containing newlines is written as several lines.
+func (s *Section) SetComments(comments ...string) {
+	s.comments = splitLines(comments)
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"slices"
 )
 
-// Serialize serializes the given systemd config unit file.
+// Serialize serializes the given systemd config unit file. It returns an
+// empty reader when WriteTo fails.
 func Serialize(unit *Unit) io.Reader {
 	var buf bytes.Buffer
 	_, _ = unit.WriteTo(&buf)
@@ -14,43 +16,195 @@ func Serialize(unit *Unit) io.Reader {
 }
 
 // WriteTo writes the serialized unit to w, implementing io.WriterTo.
+// Sections and options parsed by DeserializeLossless and not modified
+// since are written exactly as they were read, preceded by the comments
+// and blank lines that preceded them; everything else is written in
+// canonical form.
+//
+// WriteTo writes nothing and returns a *ValidationError when a section,
+// option or comment cannot be written so that it parses back unchanged,
+// e.g. a section name containing ']' or a value containing a newline.
+// Validate checks for these and for systemd's stricter grammar.
+//
+// Values written in canonical form that are too long for a line are
+// wrapped with backslash continuations. Use an Encoder to change the
+// style of the output, wrap values at a narrower width or escape values
+// that cannot be written verbatim.
 func (u *Unit) WriteTo(w io.Writer) (int64, error) {
+	return u.writeTo(w, newEncoderConfig(nil))
+}
+
+// writeTo writes the serialized unit to w with the given output options.
+func (u *Unit) writeTo(w io.Writer, cfg encoderConfig) (int64, error) {
+	if err := u.check(cfg); err != nil {
+		return 0, err
+	}
+
 	var buf bytes.Buffer
 
+	header := 0
+	if len(cfg.header) > 0 {
+		writeComments(&buf, cfg.header, cfg)
+		writeNewLine(&buf, cfg)
+		header = buf.Len()
+	}
 	for i, section := range u.Sections {
-		writeSectionHeader(&buf, section)
-		for _, option := range section.Options {
-			writeOptionValue(&buf, option)
+		if section.src != nil {
+			writeSource(&buf, section.src, section.comments, section.modified(), cfg, func() { writeSectionHeader(&buf, section, cfg) })
+		} else {
+			if i > 0 {
+				ensureNewLine(&buf, cfg)
+				for range cfg.sectionSpacing {
+					writeNewLine(&buf, cfg)
+				}
+			}
+			writeComments(&buf, section.comments, cfg)
+			writeSectionHeader(&buf, section, cfg)
 		}
-		if i < len(u.Sections)-1 {
-			writeNewLine(&buf)
+		keyWidth := cfg.keyWidth(section)
+		for _, option := range section.Options {
+			if option.src != nil {
+				writeSource(&buf, option.src, option.comments, option.modified(), cfg, func() { writeOptionValue(&buf, option, keyWidth, cfg) })
+				continue
+			}
+			ensureNewLine(&buf, cfg)
+			writeComments(&buf, option.comments, cfg)
+			writeOptionValue(&buf, option, keyWidth, cfg)
 		}
 	}
+	writeTrailingComments(&buf, u, cfg)
+
+	switch {
+	case header > 0 && buf.Len() == header:
+		// the blank line after the header comment separates it from
+		// nothing
+		buf.Truncate(max(header-len(cfg.newline()), 0))
+	case cfg.finalNewline == addFinalNewline:
+		ensureNewLine(&buf, cfg)
+	case cfg.finalNewline == stripFinalNewline:
+		out := bytes.TrimSuffix(buf.Bytes(), []byte{'\n'})
+		buf.Truncate(len(bytes.TrimSuffix(out, []byte{'\r'})))
+	}
 
-	n, err := buf.WriteTo(w)
+	var n int64
+	if u.bom {
+		// the byte order mark is not part of the text of the first line
+		m, err := io.WriteString(w, "\ufeff")
+		n += int64(m)
+		if err != nil {
+			return n, fmt.Errorf("writing unit: %w", err)
+		}
+	}
+	m, err := buf.WriteTo(w)
+	n += m
 	if err != nil {
 		return n, fmt.Errorf("writing unit: %w", err)
 	}
 	return n, nil
 }
 
+// check returns the first section, option or comment of u that cannot be
+// written with the given output options so that it parses back unchanged.
+func (u *Unit) check(cfg encoderConfig) error {
+	if reason := commentsProblem(cfg.header, false); reason != "" {
+		return &ValidationError{Reason: reason, Err: ErrInvalidComment}
+	}
+	for _, section := range u.Sections {
+		if err := section.check(false); err != nil {
+			return err
+		}
+		keyWidth := cfg.keyWidth(section)
+		for _, option := range section.Options {
+			if err := option.check(false, assignmentPrefix(option, keyWidth, cfg), cfg); err != nil {
+				err.Section = section.Name
+				return err
+			}
+		}
+	}
+	if reason := commentsProblem(u.comments, false); reason != "" {
+		return &ValidationError{Reason: reason, Err: ErrInvalidComment}
+	}
+	return nil
+}
+
 // writeNewLine writes a new line to given buffer.
-func writeNewLine(buf *bytes.Buffer) {
-	buf.WriteRune('\n')
+func writeNewLine(buf *bytes.Buffer, cfg encoderConfig) {
+	buf.WriteString(cfg.newline())
+}
+
+// ensureNewLine terminates the last line in the given buffer, which lacks
+// a line ending when it was the last line of a losslessly parsed input.
+func ensureNewLine(buf *bytes.Buffer, cfg encoderConfig) {
+	if buf.Len() > 0 && buf.Bytes()[buf.Len()-1] != '\n' {
+		writeNewLine(buf, cfg)
+	}
+}
+
+// writeSource writes the concrete syntax of a node to given buffer: its
+// leading lines, its comments as they were read unless they have been
+// replaced, and either its original text or, when modified, the canonical
+// form written by canonical.
+func writeSource(buf *bytes.Buffer, src *source, comments []string, modified bool, cfg encoderConfig, canonical func()) {
+	ensureNewLine(buf, cfg)
+	buf.Write(src.leading)
+	if slices.Equal(comments, src.commentLines) {
+		buf.Write(src.comments)
+	} else {
+		writeComments(buf, comments, cfg)
+	}
+	if modified {
+		canonical()
+		return
+	}
+	buf.Write(src.text)
+}
+
+// writeTrailingComments writes the text after the last section of the
+// unit to given buffer: the original text of a losslessly parsed unit
+// unless its comments have been replaced, or else the trailing comments
+// following a blank line.
+func writeTrailingComments(buf *bytes.Buffer, u *Unit, cfg encoderConfig) {
+	if u.tail != nil && slices.Equal(u.comments, u.tail.commentLines) {
+		if len(u.tail.leading) > 0 {
+			ensureNewLine(buf, cfg)
+			buf.Write(u.tail.leading)
+		}
+		return
+	}
+	if len(u.comments) == 0 {
+		return
+	}
+	ensureNewLine(buf, cfg)
+	if buf.Len() > 0 {
+		writeNewLine(buf, cfg)
+	}
+	writeComments(buf, u.comments, cfg)
 }
 
 // writeSectionHeader writes a section header to given buffer.
-func writeSectionHeader(buf *bytes.Buffer, section *Section) {
+func writeSectionHeader(buf *bytes.Buffer, section *Section, cfg encoderConfig) {
 	buf.WriteRune('[')
 	buf.WriteString(section.Name)
 	buf.WriteRune(']')
-	writeNewLine(buf)
+	writeNewLine(buf, cfg)
+}
+
+// assignmentPrefix returns the length of the text written before the
+// value of option, whose name is padded to keyWidth.
+func assignmentPrefix(option *OptionValue, keyWidth int, cfg encoderConfig) int {
+	return max(len(option.Option), keyWidth) + len(cfg.separator())
 }
 
-// writeOptionValue writes an option and value to given buffer.
-func writeOptionValue(buf *bytes.Buffer, option *OptionValue) {
+// writeOptionValue writes an option and value to given buffer, padding
+// the option name to keyWidth and encoding the value with the given
+// output options.
+func writeOptionValue(buf *bytes.Buffer, option *OptionValue, keyWidth int, cfg encoderConfig) {
+	value, _ := encodeValue(assignmentPrefix(option, keyWidth, cfg), option.Value, cfg)
 	buf.WriteString(option.Option)
-	buf.WriteRune('=')
-	buf.WriteString(option.Value)
-	writeNewLine(buf)
+	for range keyWidth - len(option.Option) {
+		buf.WriteRune(' ')
+	}
+	buf.WriteString(cfg.separator())
+	buf.WriteString(value)
+	writeNewLine(buf, cfg)
 }
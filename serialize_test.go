@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -168,11 +169,77 @@ func TestUnit_WriteTo(t *testing.T) {
 	}
 }
 
+func TestUnit_WriteToLossless(t *testing.T) {
+	const in = "# vendor header\n\n[Unit]\nDescription = Test  ; not a comment\n\n[Service]\n# how to start\n  ExecStart=/bin/foo \\\r\n    --bar\r\nRestart=always\n\n# trailing\n"
+	tests := []struct {
+		name string
+		edit func(u *Unit)
+		want string
+	}{
+		{
+			name: "Unmodified",
+			edit: func(*Unit) {},
+			want: in,
+		},
+		{
+			name: "ModifiedValue",
+			edit: func(u *Unit) { u.Sections[1].Options[1].Value = "on-failure" },
+			want: "# vendor header\n\n[Unit]\nDescription = Test  ; not a comment\n\n[Service]\n# how to start\n  ExecStart=/bin/foo \\\r\n    --bar\r\nRestart=on-failure\n\n# trailing\n",
+		},
+		{
+			name: "ModifiedContinuedValueKeepsComment",
+			edit: func(u *Unit) { u.Sections[1].Options[0].Value = "/bin/foo --baz" },
+			want: "# vendor header\n\n[Unit]\nDescription = Test  ; not a comment\n\n[Service]\n# how to start\nExecStart=/bin/foo --baz\nRestart=always\n\n# trailing\n",
+		},
+		{
+			name: "RenamedSection",
+			edit: func(u *Unit) { u.Sections[0].Name = "Install" },
+			want: "# vendor header\n\n[Install]\nDescription = Test  ; not a comment\n\n[Service]\n# how to start\n  ExecStart=/bin/foo \\\r\n    --bar\r\nRestart=always\n\n# trailing\n",
+		},
+		{
+			name: "RemovedOptionTakesItsComment",
+			edit: func(u *Unit) { u.Sections[1].Options = u.Sections[1].Options[1:] },
+			want: "# vendor header\n\n[Unit]\nDescription = Test  ; not a comment\n\n[Service]\nRestart=always\n\n# trailing\n",
+		},
+		{
+			name: "AddedOptionAndSection",
+			edit: func(u *Unit) {
+				u.Sections[0].AddOption("After", "network.target")
+				u.AddSection("Install").AddOption("WantedBy", "multi-user.target")
+			},
+			want: "# vendor header\n\n[Unit]\nDescription = Test  ; not a comment\nAfter=network.target\n\n[Service]\n# how to start\n  ExecStart=/bin/foo \\\r\n    --bar\r\nRestart=always\n\n[Install]\nWantedBy=multi-user.target\n\n# trailing\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, err := DeserializeLossless(strings.NewReader(in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.edit(unit)
+			if got := unit.String(); got != tt.want {
+				t.Errorf("Unit.WriteTo() wrote\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnit_WriteToLosslessNoFinalNewLine(t *testing.T) {
+	unit, err := DeserializeLossless(strings.NewReader("[Unit]\nDescription=Test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	unit.Sections[0].AddOption("After", "network.target")
+	if got, want := unit.String(), "[Unit]\nDescription=Test\nAfter=network.target\n"; got != want {
+		t.Errorf("Unit.WriteTo() wrote %q, want %q", got, want)
+	}
+}
+
 func TestWriteNewLine(t *testing.T) {
 	var buf, want bytes.Buffer
 	want.WriteRune('\n')
 	t.Run("SimpleWriteNewLine", func(t *testing.T) {
-		if writeNewLine(&buf); !reflect.DeepEqual(buf, want) {
+		if writeNewLine(&buf, encoderConfig{}); !reflect.DeepEqual(buf, want) {
 			t.Errorf("WriteNewLine() buf %v, want %v", buf, want)
 		}
 	})
@@ -215,8 +282,8 @@ func TestWriteSectionHeader(t *testing.T) {
 			buf.WriteRune('[')
 			buf.WriteString(tt.args.section.Name)
 			buf.WriteRune(']')
-			writeNewLine(&buf)
-			if writeSectionHeader(tt.args.buf, tt.args.section); !reflect.DeepEqual(tt.args.buf.Bytes(), buf.Bytes()) {
+			writeNewLine(&buf, encoderConfig{})
+			if writeSectionHeader(tt.args.buf, tt.args.section, encoderConfig{}); !reflect.DeepEqual(tt.args.buf.Bytes(), buf.Bytes()) {
 				t.Errorf("WriteSectionHeader() given buffer %v, buf %v", tt.args.buf.String(), buf.String())
 			}
 		})
@@ -280,8 +347,8 @@ func TestWriteOptionValue(t *testing.T) {
 			buf.WriteString(tt.args.option.Option)
 			buf.WriteRune('=')
 			buf.WriteString(tt.args.option.Value)
-			writeNewLine(&buf)
-			if writeOptionValue(tt.args.buf, tt.args.option); !reflect.DeepEqual(tt.args.buf.Bytes(), buf.Bytes()) {
+			writeNewLine(&buf, encoderConfig{})
+			if writeOptionValue(tt.args.buf, tt.args.option, 0, encoderConfig{}); !reflect.DeepEqual(tt.args.buf.Bytes(), buf.Bytes()) {
 				t.Errorf("WriteOptionValue() given buffer %v, buf %v", tt.args.buf.String(), buf.String())
 			}
 		})
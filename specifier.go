@@ -0,0 +1,325 @@
+package systemdconfig
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ErrUnknownSpecifier is wrapped by the error ExpandSpecifiers returns
+// for a "%" followed by a character that is no specifier, or by nothing.
+var ErrUnknownSpecifier = errors.New("unknown specifier")
+
+// SpecifierContext is what specifiers other than those of the unit name
+// expand to: facts about the host, the user the service manager runs as
+// and its directories. A field left empty makes its specifier expand to
+// nothing.
+type SpecifierContext struct {
+	// Hostname is %H, and its part before the first "." %l;
+	// PrettyHostname is %q.
+	Hostname, PrettyHostname string
+	// MachineID is %m and BootID %b, 32 lowercase hexadecimal digits
+	// each.
+	MachineID, BootID string
+	// Architecture is %a, e.g. "x86-64", and KernelRelease %v, e.g.
+	// "6.1.0-18-amd64".
+	Architecture, KernelRelease string
+	// The fields of os-release(5): ID is %o, VERSION_ID %w, BUILD_ID %B,
+	// VARIANT_ID %W, IMAGE_ID %M and IMAGE_VERSION %A.
+	OSID, OSVersionID, OSBuildID, OSVariantID, OSImageID, OSImageVersion string
+	// The user the service manager runs as: UserName is %u, UID %U,
+	// GroupName %g, GID %G, HomeDir %h and Shell %s.
+	UserName, UID, GroupName, GID, HomeDir, Shell string
+	// RuntimeDir is %t, StateDir %S, CacheDir %C, LogDir %L, ConfigDir
+	// %E and DataDir %D, e.g. "/run", "/var/lib", "/var/cache",
+	// "/var/log", "/etc" and "/usr/share" for the system manager. The
+	// credentials directory %d is under RuntimeDir.
+	RuntimeDir, StateDir, CacheDir, LogDir, ConfigDir, DataDir string
+	// TempDir is %T and VarTempDir %V, e.g. "/tmp" and "/var/tmp".
+	TempDir, VarTempDir string
+	// FragmentPath is %y, the unit file of the unit, e.g. the Path of
+	// the UnitFiles a Loader finds, and its directory %Y.
+	FragmentPath string
+}
+
+// goArchitectures are the names systemd gives the architectures Go
+// knows by another name, see uname_architecture().
+var goArchitectures = map[string]string{
+	"386":      "x86",
+	"amd64":    "x86-64",
+	"loong64":  "loongarch64",
+	"mips64le": "mips64-le",
+	"mipsle":   "mips-le",
+	"ppc64le":  "ppc64-le",
+}
+
+// NewSpecifierContext returns the context of the service manager of the
+// given scope on the running host, as far as it can find out: fields it
+// cannot read are left empty, as is FragmentPath, which depends on the
+// unit. As in systemd, the user of the system
+// manager is root, with home /root and shell /bin/sh, and the user of a
+// user's manager is the user running the program, whose $HOME and
+// $SHELL win over the user database.
+func NewSpecifierContext(scope Scope) *SpecifierContext {
+	c := &SpecifierContext{
+		PrettyHostname: readEnvFile("/etc/machine-info")["PRETTY_HOSTNAME"],
+		MachineID:      readLine("/etc/machine-id"),
+		BootID:         strings.ReplaceAll(readLine("/proc/sys/kernel/random/boot_id"), "-", ""),
+		Architecture:   runtime.GOARCH,
+		KernelRelease:  readLine("/proc/sys/kernel/osrelease"),
+		TempDir:        tempDir("/tmp"),
+		VarTempDir:     tempDir("/var/tmp"),
+	}
+	c.Hostname, _ = os.Hostname()
+	if arch, ok := goArchitectures[runtime.GOARCH]; ok {
+		c.Architecture = arch
+	}
+	osRelease := readEnvFile("/etc/os-release")
+	if osRelease == nil {
+		osRelease = readEnvFile("/usr/lib/os-release")
+	}
+	c.OSID, c.OSVersionID, c.OSBuildID = osRelease["ID"], osRelease["VERSION_ID"], osRelease["BUILD_ID"]
+	c.OSVariantID, c.OSImageID, c.OSImageVersion = osRelease["VARIANT_ID"], osRelease["IMAGE_ID"], osRelease["IMAGE_VERSION"]
+
+	if scope == ScopeSystem {
+		c.UserName, c.UID, c.GroupName, c.GID = "root", "0", "root", "0"
+		c.HomeDir, c.Shell = "/root", "/bin/sh"
+		c.RuntimeDir, c.StateDir, c.CacheDir, c.LogDir, c.ConfigDir = "/run", "/var/lib", "/var/cache", "/var/log", "/etc"
+		c.DataDir = "/usr/share"
+		return c
+	}
+
+	if u, err := user.Current(); err == nil {
+		c.UserName, c.UID, c.GID, c.HomeDir = u.Username, u.Uid, u.Gid, u.HomeDir
+		if g, err := user.LookupGroupId(u.Gid); err == nil {
+			c.GroupName = g.Name
+		}
+	}
+	if home := absEnv("HOME"); home != "" {
+		c.HomeDir = home
+	}
+	c.Shell = "/bin/sh"
+	if shell := absEnv("SHELL"); shell != "" {
+		c.Shell = shell
+	}
+	c.RuntimeDir = absEnv("XDG_RUNTIME_DIR")
+	c.StateDir = xdgDir("XDG_STATE_HOME", c.HomeDir, ".local/state")
+	c.CacheDir = xdgDir("XDG_CACHE_HOME", c.HomeDir, ".cache")
+	c.ConfigDir = xdgDir("XDG_CONFIG_HOME", c.HomeDir, ".config")
+	c.DataDir = xdgDir("XDG_DATA_HOME", c.HomeDir, ".local/share")
+	if c.StateDir != "" {
+		c.LogDir = filepath.Join(c.StateDir, "log")
+	}
+	return c
+}
+
+// tempDir returns the temporary directory of $TMPDIR, $TEMP or $TMP, or
+// fallback, as systemd's tmp_dir() and var_tmp_dir() do.
+func tempDir(fallback string) string {
+	for _, name := range []string{"TMPDIR", "TEMP", "TMP"} {
+		if dir := absEnv(name); dir != "" {
+			return dir
+		}
+	}
+	return fallback
+}
+
+// readLine returns the first line of the named file, or "" if it cannot
+// be read.
+func readLine(name string) string {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return ""
+	}
+	line, _, _ := strings.Cut(string(data), "\n")
+	return strings.TrimSpace(line)
+}
+
+// readEnvFile returns the assignments of an environment file such as
+// os-release(5), with the quotes and backslash escapes of values
+// removed, or nil if it cannot be read.
+func readEnvFile(name string) map[string]string {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	vars := map[string]string{}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if word, err := UnquoteValue(value); err == nil {
+			value = word
+		}
+		vars[strings.TrimSpace(key)] = value
+	}
+	return vars
+}
+
+// ExpandSpecifiers returns value with the specifiers of systemd.unit(5)
+// replaced, e.g. "--name=%i" with "--name=tty1" for the unit
+// "getty@tty1.service":
+//
+//	%n  full unit name            %N  unit name without the type
+//	%p  prefix                    %P  unescaped prefix
+//	%i  instance                  %I  unescaped instance
+//	%j  final component of the    %J  unescaped final component
+//	    prefix, after its last "-"
+//	%f  unescaped instance, or prefix, as an absolute path
+//	%d  credentials directory     %%  a single "%"
+//
+// and the specifiers of the fields of ctx, which may be nil. The error
+// wraps ErrUnknownSpecifier for an unknown specifier, which systemd
+// rejects too, or ErrInvalidEscape for a prefix or instance that cannot
+// be unescaped.
+func ExpandSpecifiers(value string, name UnitName, ctx *SpecifierContext) (string, error) {
+	if !strings.Contains(value, "%") {
+		return value, nil
+	}
+	if ctx == nil {
+		ctx = &SpecifierContext{}
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '%' {
+			b.WriteByte(value[i])
+			continue
+		}
+		if i++; i == len(value) {
+			return "", fmt.Errorf("%w %q in %q", ErrUnknownSpecifier, "%", value)
+		}
+		s, err := expandSpecifier(value[i], name, ctx)
+		if err != nil {
+			return "", fmt.Errorf("%w in %q", err, value)
+		}
+		b.WriteString(s)
+	}
+	return b.String(), nil
+}
+
+// expandSpecifier returns what the specifier c expands to.
+func expandSpecifier(c byte, name UnitName, ctx *SpecifierContext) (string, error) {
+	lastComponent := name.Prefix[strings.LastIndexByte(name.Prefix, '-')+1:]
+	switch c {
+	case '%':
+		return "%", nil
+	case 'n':
+		return name.String(), nil
+	case 'N':
+		return strings.TrimSuffix(name.String(), "."+name.Type), nil
+	case 'p':
+		return name.Prefix, nil
+	case 'P':
+		return UnescapeUnitName(name.Prefix)
+	case 'i':
+		return name.Instance, nil
+	case 'I':
+		return UnescapeUnitName(name.Instance)
+	case 'j':
+		return lastComponent, nil
+	case 'J':
+		return UnescapeUnitName(lastComponent)
+	case 'f':
+		if name.Instance != "" {
+			return UnescapeUnitPath(name.Instance)
+		}
+		return UnescapeUnitPath(name.Prefix)
+	case 'd':
+		if ctx.RuntimeDir == "" {
+			return "", nil
+		}
+		return ctx.RuntimeDir + "/credentials/" + name.String(), nil
+	case 'H':
+		return ctx.Hostname, nil
+	case 'l':
+		short, _, _ := strings.Cut(ctx.Hostname, ".")
+		return short, nil
+	case 'q':
+		return ctx.PrettyHostname, nil
+	case 'm':
+		return ctx.MachineID, nil
+	case 'b':
+		return ctx.BootID, nil
+	case 'a':
+		return ctx.Architecture, nil
+	case 'v':
+		return ctx.KernelRelease, nil
+	case 'o':
+		return ctx.OSID, nil
+	case 'w':
+		return ctx.OSVersionID, nil
+	case 'B':
+		return ctx.OSBuildID, nil
+	case 'W':
+		return ctx.OSVariantID, nil
+	case 'M':
+		return ctx.OSImageID, nil
+	case 'A':
+		return ctx.OSImageVersion, nil
+	case 'u':
+		return ctx.UserName, nil
+	case 'U':
+		return ctx.UID, nil
+	case 'g':
+		return ctx.GroupName, nil
+	case 'G':
+		return ctx.GID, nil
+	case 'h':
+		return ctx.HomeDir, nil
+	case 's':
+		return ctx.Shell, nil
+	case 't':
+		return ctx.RuntimeDir, nil
+	case 'S':
+		return ctx.StateDir, nil
+	case 'C':
+		return ctx.CacheDir, nil
+	case 'L':
+		return ctx.LogDir, nil
+	case 'E':
+		return ctx.ConfigDir, nil
+	case 'D':
+		return ctx.DataDir, nil
+	case 'y':
+		return ctx.FragmentPath, nil
+	case 'Y':
+		if ctx.FragmentPath == "" {
+			return "", nil
+		}
+		return filepath.Dir(ctx.FragmentPath), nil
+	case 'T':
+		return ctx.TempDir, nil
+	case 'V':
+		return ctx.VarTempDir, nil
+	}
+	return "", fmt.Errorf("%w %q", ErrUnknownSpecifier, "%"+string(c))
+}
+
+// ExpandSpecifiers replaces the specifiers in the values of all options
+// of u as ExpandSpecifiers does. Values it cannot expand are left
+// unchanged; it returns nil or their errors joined with errors.Join, each
+// a *ValueError. Unlike systemd, which expands specifiers only in the
+// options documented to support them, it expands them in every option.
+func (u *Unit) ExpandSpecifiers(name UnitName, ctx *SpecifierContext) error {
+	var errs []error
+	for _, s := range u.Sections {
+		for _, o := range s.Options {
+			v, err := ExpandSpecifiers(o.Value, name, ctx)
+			if err != nil {
+				errs = append(errs, &ValueError{Section: s.Name, Option: o.Option, Value: o.Value, Err: err})
+				continue
+			}
+			o.Value = v
+		}
+	}
+	return errors.Join(errs...)
+}
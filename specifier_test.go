@@ -0,0 +1,170 @@
+package systemdconfig
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestExpandSpecifiers(t *testing.T) {
+	ctx := &SpecifierContext{
+		Hostname:       "web1.example.com",
+		PrettyHostname: "Web 1",
+		MachineID:      "0123456789abcdef0123456789abcdef",
+		BootID:         "fedcba9876543210fedcba9876543210",
+		Architecture:   "x86-64",
+		KernelRelease:  "6.1.0-18-amd64",
+		OSID:           "debian",
+		OSVersionID:    "12",
+		UserName:       "root",
+		UID:            "0",
+		GroupName:      "root",
+		GID:            "0",
+		HomeDir:        "/root",
+		Shell:          "/bin/sh",
+		RuntimeDir:     "/run",
+		StateDir:       "/var/lib",
+		CacheDir:       "/var/cache",
+		LogDir:         "/var/log",
+		ConfigDir:      "/etc",
+		DataDir:        "/usr/share",
+		TempDir:        "/tmp",
+		VarTempDir:     "/var/tmp",
+		FragmentPath:   "/usr/lib/systemd/system/systemd-fsck@.service",
+	}
+	parse := func(name string) UnitName {
+		t.Helper()
+		n, err := ParseUnitName(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+	fsck := parse("systemd-fsck@dev-disk-by\\x2dlabel-data.service")
+	mount := parse("var-lib-docker.mount")
+
+	tests := []struct {
+		value string
+		name  UnitName
+		want  string
+	}{
+		{"no specifiers", fsck, "no specifiers"},
+		{"%n %N", fsck, "systemd-fsck@dev-disk-by\\x2dlabel-data.service systemd-fsck@dev-disk-by\\x2dlabel-data"},
+		{"%p %P", fsck, "systemd-fsck systemd/fsck"},
+		{"%i %I", fsck, "dev-disk-by\\x2dlabel-data dev/disk/by-label/data"},
+		{"%j %J", fsck, "fsck fsck"},
+		{"%f", fsck, "/dev/disk/by-label/data"},
+		{"%f %j %i", mount, "/var/lib/docker docker "},
+		{"%d", fsck, "/run/credentials/systemd-fsck@dev-disk-by\\x2dlabel-data.service"},
+		{"%H %l %q %m %b", mount, "web1.example.com web1 Web 1 0123456789abcdef0123456789abcdef fedcba9876543210fedcba9876543210"},
+		{"%a %v %o %w %B", mount, "x86-64 6.1.0-18-amd64 debian 12 "},
+		{"%u %U %g %G %h %s", mount, "root 0 root 0 /root /bin/sh"},
+		{"%t %S %C %L %E %D %T %V", mount, "/run /var/lib /var/cache /var/log /etc /usr/share /tmp /var/tmp"},
+		{"%y %Y", fsck, "/usr/lib/systemd/system/systemd-fsck@.service /usr/lib/systemd/system"},
+		{"100%% %%i", mount, "100% %i"},
+	}
+	for _, tt := range tests {
+		got, err := ExpandSpecifiers(tt.value, tt.name, ctx)
+		if err != nil || got != tt.want {
+			t.Errorf("ExpandSpecifiers(%q, %s) = %q, %v, want %q", tt.value, tt.name, got, err, tt.want)
+		}
+	}
+
+	if got, err := ExpandSpecifiers("%n on %H%t%y%Y", mount, nil); err != nil || got != "var-lib-docker.mount on " {
+		t.Errorf("ExpandSpecifiers with nil context = %q, %v", got, err)
+	}
+	for _, value := range []string{"%z", "50%", "%i %X"} {
+		if _, err := ExpandSpecifiers(value, fsck, ctx); !errors.Is(err, ErrUnknownSpecifier) {
+			t.Errorf("ExpandSpecifiers(%q) error = %v, want %v", value, err, ErrUnknownSpecifier)
+		}
+	}
+	if _, err := ExpandSpecifiers("%I", parse("foo@bad\\x.service"), ctx); !errors.Is(err, ErrInvalidEscape) {
+		t.Errorf("ExpandSpecifiers(%%I) with a bad escape error = %v, want %v", err, ErrInvalidEscape)
+	}
+}
+
+func TestUnit_ExpandSpecifiers(t *testing.T) {
+	u, err := DeserializeLossless(strings.NewReader(`[Unit]
+Description=Getty on %I
+# keep me
+[Service]
+ExecStart=-/sbin/agetty -o '-p -- \\u' --noclear %I $TERM
+Environment=HOST=%H
+ExecStartPost=/bin/echo 100%
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, err := ParseUnitName("getty@tty1.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = u.ExpandSpecifiers(name, &SpecifierContext{Hostname: "host"})
+
+	var verr *ValueError
+	if !errors.Is(err, ErrUnknownSpecifier) || !errors.As(err, &verr) || verr.Option != "ExecStartPost" {
+		t.Errorf("ExpandSpecifiers() error = %v, want an unknown specifier in ExecStartPost", err)
+	}
+	want := `[Unit]
+Description=Getty on tty1
+# keep me
+[Service]
+ExecStart=-/sbin/agetty -o '-p -- \\u' --noclear tty1 $TERM
+Environment=HOST=host
+ExecStartPost=/bin/echo 100%
+`
+	if got := u.String(); got != want {
+		t.Errorf("expanded unit =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestNewSpecifierContext(t *testing.T) {
+	t.Setenv("TMPDIR", "")
+	t.Setenv("TEMP", "")
+	t.Setenv("TMP", "")
+	c := NewSpecifierContext(ScopeSystem)
+	if c.UserName != "root" || c.HomeDir != "/root" || c.RuntimeDir != "/run" || c.DataDir != "/usr/share" || c.TempDir != "/tmp" || c.VarTempDir != "/var/tmp" {
+		t.Errorf("system context = %+v", c)
+	}
+	if c.Architecture == "" {
+		t.Errorf("Architecture is empty")
+	}
+
+	t.Setenv("HOME", "/home/u")
+	t.Setenv("SHELL", "/bin/zsh")
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	t.Setenv("XDG_STATE_HOME", "")
+	t.Setenv("XDG_CACHE_HOME", "/cache")
+	t.Setenv("XDG_CONFIG_HOME", "relative")
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("TMPDIR", "/scratch")
+	c = NewSpecifierContext(ScopeUser)
+	want := []string{"/home/u", "/bin/zsh", "/run/user/1000", "/home/u/.local/state", "/home/u/.local/state/log", "/cache", "/home/u/.config", "/home/u/.local/share", "/scratch", "/scratch"}
+	got := []string{c.HomeDir, c.Shell, c.RuntimeDir, c.StateDir, c.LogDir, c.CacheDir, c.ConfigDir, c.DataDir, c.TempDir, c.VarTempDir}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("user context directories = %q, want %q", got, want)
+	}
+}
+
+func TestUnit_ExpandSpecifiers_FragmentPath(t *testing.T) {
+	l := NewLoader(ScopeSystem, WithFS(fstest.MapFS{
+		"usr/lib/systemd/system/app@.service": {Data: []byte("[Service]\nExecStart=%Y/../libexec/app --config %y --instance %i\n")},
+	}), WithSearchPath("/usr/lib/systemd/system"))
+	files, err := l.Files("app@one.service")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, err := ParseUnitName(files.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := files.Merge()
+	if err := u.ExpandSpecifiers(name, &SpecifierContext{FragmentPath: files.Path}); err != nil {
+		t.Fatal(err)
+	}
+	want := "/usr/lib/systemd/system/../libexec/app --config /usr/lib/systemd/system/app@.service --instance one"
+	if got, _ := u.Value("Service", "ExecStart"); got != want {
+		t.Errorf("ExecStart = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,178 @@
+package systemdconfig
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strings"
+	"time"
+)
+
+// DurationInfinity is the time span "infinity", e.g. TimeoutStartSec=
+// infinity, which disables a timeout.
+const DurationInfinity time.Duration = math.MaxInt64
+
+// ErrInvalidDuration is wrapped by the error ParseTimespan returns for a
+// malformed time span.
+var ErrInvalidDuration = errors.New("invalid time span")
+
+// timeUnit is a unit of a time span and its length.
+type timeUnit struct {
+	name string
+	d    time.Duration
+}
+
+const (
+	month = 2629800 * time.Second
+	year  = 31557600 * time.Second
+	week  = 7 * day
+	day   = 24 * time.Hour
+)
+
+// parseTimeUnits are the units ParseTimespan accepts, as in systemd's
+// parse_sec(), longer names first so that the longest one matches.
+var parseTimeUnits = []timeUnit{
+	{"seconds", time.Second}, {"second", time.Second}, {"sec", time.Second}, {"s", time.Second},
+	{"minutes", time.Minute}, {"minute", time.Minute}, {"min", time.Minute},
+	{"months", month}, {"month", month}, {"M", month},
+	{"msec", time.Millisecond}, {"ms", time.Millisecond}, {"m", time.Minute},
+	{"hours", time.Hour}, {"hour", time.Hour}, {"hr", time.Hour}, {"h", time.Hour},
+	{"days", day}, {"day", day}, {"d", day},
+	{"weeks", week}, {"week", week}, {"w", week},
+	{"years", year}, {"year", year}, {"y", year},
+	{"usec", time.Microsecond}, {"us", time.Microsecond}, {"μs", time.Microsecond}, {"µs", time.Microsecond},
+	{"nsec", time.Nanosecond}, {"ns", time.Nanosecond},
+}
+
+// formatTimeUnits are the units FormatTimespan writes, as in systemd's
+// format_timespan(), largest first.
+var formatTimeUnits = []timeUnit{
+	{"y", year}, {"month", month}, {"w", week}, {"d", day}, {"h", time.Hour}, {"min", time.Minute},
+	{"s", time.Second}, {"ms", time.Millisecond}, {"us", time.Microsecond}, {"ns", time.Nanosecond},
+}
+
+// ParseTimespan parses a time span in the syntax of systemd.time(7): a
+// sequence of numbers, each optionally with a fraction and followed by a
+// unit such as "h", "min", "s" or "ms", e.g. "1h 30min", "5s", "1.5d"
+// or "2min30s". A number without a unit is in seconds, and "infinity"
+// yields DurationInfinity.
+func ParseTimespan(s string) (time.Duration, error) {
+	return parseTimespan(s, time.Second)
+}
+
+// parseTimespan parses a time span whose numbers without unit are in the
+// given default unit.
+func parseTimespan(s string, unit time.Duration) (time.Duration, error) {
+	invalid := func() (time.Duration, error) {
+		return 0, fmt.Errorf("%w %q", ErrInvalidDuration, s)
+	}
+	rest := strings.Trim(s, whitespace)
+	if rest == "infinity" {
+		return DurationInfinity, nil
+	}
+	if rest == "" {
+		return invalid()
+	}
+
+	var total time.Duration
+	for rest != "" {
+		whole, frac, digits, n := parseDecimal(rest)
+		if n == 0 {
+			return invalid()
+		}
+		rest = strings.TrimLeft(rest[n:], whitespace)
+
+		u := unit
+		for _, tu := range parseTimeUnits {
+			if strings.HasPrefix(rest, tu.name) {
+				u = tu.d
+				rest = rest[len(tu.name):]
+				break
+			}
+		}
+		rest = strings.TrimLeft(rest, whitespace)
+
+		d, ok := scale(whole, frac, digits, uint64(u))
+		if !ok || d > uint64(math.MaxInt64-total) {
+			return invalid()
+		}
+		total += time.Duration(d)
+	}
+	return total, nil
+}
+
+// parseDecimal parses the unsigned decimal number, with an optional
+// fraction, at the start of s. It returns the whole part, the fraction
+// and its number of digits, and the length of the number, 0 when there
+// is none or it overflows.
+func parseDecimal(s string) (whole, frac uint64, digits, n int) {
+	for n < len(s) && '0' <= s[n] && s[n] <= '9' {
+		if whole > (math.MaxUint64-9)/10 {
+			return 0, 0, 0, 0
+		}
+		whole = whole*10 + uint64(s[n]-'0')
+		n++
+	}
+	if n == len(s) || s[n] != '.' {
+		return whole, 0, 0, n
+	}
+	end := n + 1
+	for end < len(s) && '0' <= s[end] && s[end] <= '9' {
+		// digits beyond the precision of any unit are ignored
+		if digits < 18 {
+			frac = frac*10 + uint64(s[end]-'0')
+			digits++
+		}
+		end++
+	}
+	if n == 0 && end == n+1 {
+		return 0, 0, 0, 0
+	}
+	return whole, frac, digits, end
+}
+
+// scale returns whole.frac, with the given number of fraction digits,
+// times unit, truncated, and whether it fits in an int64.
+func scale(whole, frac uint64, digits int, unit uint64) (uint64, bool) {
+	if whole != 0 && unit > math.MaxInt64/whole {
+		return 0, false
+	}
+	v := whole * unit
+	if digits > 0 {
+		// frac < 10^digits, so the quotient is below unit
+		hi, lo := bits.Mul64(frac, unit)
+		f, _ := bits.Div64(hi, lo, pow10(digits))
+		v += f
+	}
+	return v, v <= math.MaxInt64
+}
+
+// pow10 returns 10 to the n.
+func pow10(n int) uint64 {
+	p := uint64(1)
+	for range n {
+		p *= 10
+	}
+	return p
+}
+
+// FormatTimespan formats d the way systemd prints time spans, e.g. "1h
+// 30min" or "2s 500ms": "infinity" for DurationInfinity, "0" for zero.
+// Negative durations are not time spans and are formatted as "0".
+func FormatTimespan(d time.Duration) string {
+	if d == DurationInfinity {
+		return "infinity"
+	}
+	if d <= 0 {
+		return "0"
+	}
+	var parts []string
+	for _, u := range formatTimeUnits {
+		if n := d / u.d; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, u.name))
+			d -= n * u.d
+		}
+	}
+	return strings.Join(parts, " ")
+}
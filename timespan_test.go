@@ -0,0 +1,67 @@
+package systemdconfig
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseTimespan(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"5", 5 * time.Second},
+		{"5s", 5 * time.Second},
+		{"1h 30min", 90 * time.Minute},
+		{"2min30s", 150 * time.Second},
+		{" 1h\t5m ", time.Hour + 5*time.Minute},
+		{"100ms", 100 * time.Millisecond},
+		{"1.5d", 36 * time.Hour},
+		{"0.25s", 250 * time.Millisecond},
+		{".5min", 30 * time.Second},
+		{"3 weeks 2 days", 23 * day},
+		{"1M", month},
+		{"1y", year},
+		{"10us 5ns", 10*time.Microsecond + 5},
+		{"2 hours 1 minute 3 seconds", 2*time.Hour + time.Minute + 3*time.Second},
+		{"0", 0},
+		{"infinity", DurationInfinity},
+	}
+	for _, tt := range tests {
+		got, err := ParseTimespan(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseTimespan(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "s", "-5s", "5x", "1h infinity", ".", "300y", "99999999999999999999"} {
+		if _, err := ParseTimespan(in); !errors.Is(err, ErrInvalidDuration) {
+			t.Errorf("ParseTimespan(%q) error = %v, want ErrInvalidDuration", in, err)
+		}
+	}
+}
+
+func TestFormatTimespan(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{0, "0"},
+		{-time.Second, "0"},
+		{DurationInfinity, "infinity"},
+		{90 * time.Minute, "1h 30min"},
+		{2500 * time.Millisecond, "2s 500ms"},
+		{8*day + time.Microsecond, "1w 1d 1us"},
+		{year + month + 5, "1y 1month 5ns"},
+	}
+	for _, tt := range tests {
+		got := FormatTimespan(tt.in)
+		if got != tt.want {
+			t.Errorf("FormatTimespan(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+		if back, err := ParseTimespan(got); err != nil || (tt.in > 0 && back != tt.in) {
+			t.Errorf("ParseTimespan(%q) = %v, %v, want %v", got, back, err, tt.in)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package systemdconfig
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/javadh75/systemd-config/internal/timename"
+)
+
+// ErrInvalidTimestamp is wrapped by the error ParseTimestamp returns for a
+// malformed timestamp.
+var ErrInvalidTimestamp = errors.New("invalid timestamp")
+
+// timestampLayout is how systemd prints timestamps, e.g. "Fri 2012-11-23
+// 11:12:13 CET".
+const timestampLayout = "Mon 2006-01-02 15:04:05 MST"
+
+// ParseTimestamp parses a timestamp in the syntax of systemd.time(7), as
+// systemd-analyze timestamp does, relative to now:
+//
+//   - a date and time, "2012-11-23 11:12:13", with an optional weekday
+//     that must match ("Fri 2012-11-23 11:12:13"), a two-digit year
+//     ("12-11-23"), a "T" instead of the space, a fraction of a second
+//     ("11:12:13.5"), and without seconds ("11:12"), the time
+//     (midnight) or the date (today);
+//   - "now", "today", "yesterday", "tomorrow" and "epoch";
+//   - a time span relative to now: "+3h30min", "-5s", "11min ago" or
+//     "3h left";
+//   - "@" followed by seconds since the epoch, with an optional
+//     fraction but no unit, e.g. "@1700000000" or "@1700000000.5".
+//
+// A date and time are in the location of now, unless followed by "UTC",
+// a name from the IANA database such as "Europe/Berlin", or a "Z" or
+// numeric offset ("11:12:13+01:00"). The result is in the location of
+// now.
+func ParseTimestamp(s string, now time.Time) (time.Time, error) {
+	invalid := func() (time.Time, error) {
+		return time.Time{}, fmt.Errorf("%w %q", ErrInvalidTimestamp, s)
+	}
+	relative := func(span string, sign time.Duration) (time.Time, error) {
+		d, err := parseTimespan(span, time.Second)
+		if err != nil || d == DurationInfinity {
+			return invalid()
+		}
+		return now.Add(sign * d), nil
+	}
+
+	t := strings.Trim(s, whitespace)
+	switch {
+	case t == "now":
+		return now, nil
+	case strings.HasPrefix(t, "+"):
+		return relative(t[1:], 1)
+	case strings.HasPrefix(t, "-"):
+		return relative(t[1:], -1)
+	case strings.HasSuffix(t, " ago"):
+		return relative(strings.TrimSuffix(t, " ago"), -1)
+	case strings.HasSuffix(t, " left"):
+		return relative(strings.TrimSuffix(t, " left"), 1)
+	case strings.HasPrefix(t, "@"):
+		// only a number of seconds, unlike the time spans above
+		whole, frac, digits, n := parseDecimal(t[1:])
+		if n == 0 || n != len(t)-1 {
+			return invalid()
+		}
+		d, ok := scale(whole, frac, digits, uint64(time.Second))
+		if !ok {
+			return invalid()
+		}
+		return time.Unix(0, int64(d)).In(now.Location()), nil
+	}
+
+	fields := strings.Fields(t)
+	loc := now.Location()
+	if n := len(fields); n > 1 {
+		if l, ok := timename.Location(fields[n-1]); ok {
+			loc, fields = l, fields[:n-1]
+		}
+	}
+	if len(fields) == 0 {
+		return invalid()
+	}
+
+	wall := now.In(loc)
+	if len(fields) == 1 {
+		y, m, d := wall.Date()
+		switch fields[0] {
+		case "today":
+			return time.Date(y, m, d, 0, 0, 0, 0, loc).In(now.Location()), nil
+		case "yesterday":
+			return time.Date(y, m, d-1, 0, 0, 0, 0, loc).In(now.Location()), nil
+		case "tomorrow":
+			return time.Date(y, m, d+1, 0, 0, 0, 0, loc).In(now.Location()), nil
+		case "epoch":
+			return time.Unix(0, 0).In(now.Location()), nil
+		}
+	}
+
+	var (
+		weekday    time.Weekday
+		hasWeekday bool
+	)
+	if c := fields[0][0]; 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' {
+		if weekday, hasWeekday = timename.Weekday(fields[0]); !hasWeekday {
+			return invalid()
+		}
+		fields = fields[1:]
+	}
+	if len(fields) == 1 {
+		if date, clock, ok := strings.Cut(fields[0], "T"); ok {
+			fields = []string{date, clock}
+		}
+	}
+
+	var (
+		year, month, day = wall.Date()
+		clock            time.Duration
+		ok               bool
+	)
+	switch {
+	case len(fields) == 2:
+		if year, month, day, ok = parseDate(fields[0]); !ok {
+			return invalid()
+		}
+		if clock, loc, ok = parseClock(fields[1], loc); !ok {
+			return invalid()
+		}
+	case len(fields) == 1 && strings.Contains(fields[0], ":"):
+		if clock, loc, ok = parseClock(fields[0], loc); !ok {
+			return invalid()
+		}
+		// today, where the time is
+		year, month, day = now.In(loc).Date()
+	case len(fields) == 1:
+		if year, month, day, ok = parseDate(fields[0]); !ok {
+			return invalid()
+		}
+	default:
+		return invalid()
+	}
+
+	result := time.Date(year, month, day, 0, 0, 0, int(clock), loc)
+	if hasWeekday && result.Weekday() != weekday {
+		return invalid()
+	}
+	return result.In(now.Location()), nil
+}
+
+// FormatTimestamp formats t the way systemd prints timestamps, e.g. "Fri
+// 2012-11-23 11:12:13 CET", in the location of t.
+func FormatTimestamp(t time.Time) string {
+	return t.Format(timestampLayout)
+}
+
+// parseDate parses "YYYY-MM-DD" or "YY-MM-DD", where two-digit years 69
+// to 99 are in the 1900s and the others in the 2000s.
+func parseDate(s string) (int, time.Month, int, bool) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 3 || len(parts[0]) != 2 && len(parts[0]) != 4 {
+		return 0, 0, 0, false
+	}
+	year, ok1 := parseField(parts[0], 0, 9999)
+	month, ok2 := parseField(parts[1], 1, 12)
+	day, ok3 := parseField(parts[2], 1, 31)
+	if !ok1 || !ok2 || !ok3 {
+		return 0, 0, 0, false
+	}
+	if len(parts[0]) == 2 {
+		year += 2000
+		if year >= 2069 {
+			year -= 100
+		}
+	}
+	if time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC).Day() != day {
+		return 0, 0, 0, false
+	}
+	return year, time.Month(month), day, true
+}
+
+// parseClock parses "HH:MM[:SS[.fraction]]", optionally followed by "Z"
+// or a numeric offset such as "+01:00", which replaces loc. It returns
+// the time of day.
+func parseClock(s string, loc *time.Location) (time.Duration, *time.Location, bool) {
+	if strings.HasSuffix(s, "Z") {
+		s, loc = s[:len(s)-1], time.UTC
+	} else if i := strings.LastIndexAny(s, "+-"); i >= 0 {
+		offset, ok := parseOffset(s[i:])
+		if !ok {
+			return 0, nil, false
+		}
+		s, loc = s[:i], time.FixedZone(s[i:], offset)
+	}
+
+	parts := strings.Split(s, ":")
+	var sec, nsec int
+	switch len(parts) {
+	case 3:
+		whole, frac, hasFrac := strings.Cut(parts[2], ".")
+		var ok bool
+		if sec, ok = parseField(whole, 0, 59); !ok {
+			return 0, nil, false
+		}
+		if hasFrac {
+			if frac == "" || len(frac) > 9 {
+				return 0, nil, false
+			}
+			if nsec, ok = parseField((frac + "000000000")[:9], 0, 999999999); !ok {
+				return 0, nil, false
+			}
+		}
+	case 2:
+	default:
+		return 0, nil, false
+	}
+	hour, ok1 := parseField(parts[0], 0, 23)
+	minute, ok2 := parseField(parts[1], 0, 59)
+	d := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute +
+		time.Duration(sec)*time.Second + time.Duration(nsec)
+	return d, loc, ok1 && ok2
+}
+
+// parseOffset parses a UTC offset, "+HH", "+HHMM" or "+HH:MM", in
+// seconds.
+func parseOffset(s string) (int, bool) {
+	digits := strings.Replace(s[1:], ":", "", 1)
+	if len(digits) == 2 {
+		digits += "00"
+	}
+	if len(digits) != 4 {
+		return 0, false
+	}
+	hours, ok1 := parseField(digits[:2], 0, 23)
+	minutes, ok2 := parseField(digits[2:], 0, 59)
+	offset := hours*3600 + minutes*60
+	if s[0] == '-' {
+		offset = -offset
+	}
+	return offset, ok1 && ok2
+}
+
+// parseField parses a number of one or more decimal digits in [low,
+// high].
+func parseField(s string, low, high int) (int, bool) {
+	if s == "" || strings.TrimLeft(s, "0123456789") != "" || len(s) > 9 {
+		return 0, false
+	}
+	n, _ := strconv.Atoi(s)
+	return n, low <= n && n <= high
+}
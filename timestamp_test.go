@@ -0,0 +1,111 @@
+package systemdconfig
+
+import (
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func TestParseTimestamp(t *testing.T) {
+	pst := time.FixedZone("PST", -8*3600)
+	now := time.Date(2012, 11, 23, 18, 15, 22, 0, pst)
+	local := func(month time.Month, day, hour, min, sec, nsec int) time.Time {
+		return time.Date(2012, month, day, hour, min, sec, nsec, pst)
+	}
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		// the examples of systemd.time(7)
+		{"Fri 2012-11-23 11:12:13", local(11, 23, 11, 12, 13, 0)},
+		{"2012-11-23 11:12:13", local(11, 23, 11, 12, 13, 0)},
+		{"2012-11-23 11:12:13 UTC", local(11, 23, 3, 12, 13, 0)},
+		{"2012-11-23", local(11, 23, 0, 0, 0, 0)},
+		{"12-11-23", local(11, 23, 0, 0, 0, 0)},
+		{"11:12:13", local(11, 23, 11, 12, 13, 0)},
+		{"11:12", local(11, 23, 11, 12, 0, 0)},
+		{"now", now},
+		{"today", local(11, 23, 0, 0, 0, 0)},
+		{"today UTC", local(11, 23, 16, 0, 0, 0)},
+		{"yesterday", local(11, 22, 0, 0, 0, 0)},
+		{"tomorrow", local(11, 24, 0, 0, 0, 0)},
+		{"tomorrow Pacific/Auckland", local(11, 24, 3, 0, 0, 0)},
+		{"+3h30min", local(11, 23, 21, 45, 22, 0)},
+		{"-5s", local(11, 23, 18, 15, 17, 0)},
+		{"11min ago", local(11, 23, 18, 4, 22, 0)},
+		{"3h left", local(11, 23, 21, 15, 22, 0)},
+		{"@1395716396", time.Date(2014, 3, 25, 2, 59, 56, 0, time.UTC)},
+
+		{" friday 2012-11-23 11:12 ", local(11, 23, 11, 12, 0, 0)},
+		{"2012-11-23T11:12:13Z", local(11, 23, 3, 12, 13, 0)},
+		{"2012-11-23 11:12:13+01:00", local(11, 23, 2, 12, 13, 0)},
+		{"2012-11-23 11:12:13-0330", local(11, 23, 6, 42, 13, 0)},
+		{"11:12:13.5", local(11, 23, 11, 12, 13, 5e8)},
+		{"2012-11-23 11:12 Europe/Berlin", local(11, 23, 2, 12, 0, 0)},
+		{"2012-11-23 11:12:13 utc", local(11, 23, 3, 12, 13, 0)},
+		{"69-01-01", time.Date(1969, 1, 1, 0, 0, 0, 0, pst)},
+		{"68-01-01", time.Date(2068, 1, 1, 0, 0, 0, 0, pst)},
+		{"@1.5", time.Date(1970, 1, 1, 0, 0, 1, 5e8, time.UTC)},
+		{"epoch", time.Unix(0, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseTimestamp(tt.in, now)
+			if err != nil {
+				t.Fatalf("ParseTimestamp() error = %v", err)
+			}
+			if !got.Equal(tt.want) || got.Location() != pst {
+				t.Errorf("ParseTimestamp() = %v, want %v", got, tt.want.In(pst))
+			}
+		})
+	}
+}
+
+func TestParseTimestamp_Invalid(t *testing.T) {
+	now := time.Date(2012, 11, 23, 18, 15, 22, 0, time.UTC)
+	for _, in := range []string{
+		"",
+		"Sat 2012-11-23 11:12:13",
+		"Fri",
+		"2012-13-01",
+		"2012-02-30",
+		"2012-1-1-1",
+		"123-01-01",
+		"25:00",
+		"11:60",
+		"11:12:60",
+		"11:12:13.",
+		"11:12:13.1234567890",
+		"11:12:13+25:00",
+		"1:2:3:4",
+		"@",
+		"@1 UTC",
+		"@1h",
+		"@5min",
+		"@1 s",
+		"@-1",
+		"@99999999999999999999",
+		"+",
+		"+infinity",
+		"ago",
+		"yesterday 11:00",
+		"2012-11-23 11:12:13 Mars/Olympus",
+		"2012-11-23 11:12:13 extra",
+	} {
+		if got, err := ParseTimestamp(in, now); !errors.Is(err, ErrInvalidTimestamp) {
+			t.Errorf("ParseTimestamp(%q) = %v, %v, want ErrInvalidTimestamp", in, got, err)
+		}
+	}
+}
+
+func TestFormatTimestamp(t *testing.T) {
+	ts := time.Date(2012, 11, 23, 11, 12, 13, 0, time.FixedZone("CET", 3600))
+	if got, want := FormatTimestamp(ts), "Fri 2012-11-23 11:12:13 CET"; got != want {
+		t.Errorf("FormatTimestamp() = %q, want %q", got, want)
+	}
+	back, err := ParseTimestamp(FormatTimestamp(ts.UTC()), ts)
+	if err != nil || !back.Equal(ts) {
+		t.Errorf("ParseTimestamp(FormatTimestamp()) = %v, %v, want %v", back, err, ts)
+	}
+}
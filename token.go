@@ -0,0 +1,133 @@
+package systemdconfig
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// TokenKind classifies a Token.
+type TokenKind int
+
+const (
+	// SectionStart is a section header.
+	SectionStart TokenKind = iota + 1
+	// Assignment is an option assignment, including its continuation
+	// lines and the comment lines interleaved with them.
+	Assignment
+	// Comment is a comment line, or several if it ends in a backslash.
+	Comment
+	// Blank is an empty or whitespace-only line.
+	Blank
+)
+
+var tokenKindNames = map[TokenKind]string{
+	SectionStart: "SectionStart",
+	Assignment:   "Assignment",
+	Comment:      "Comment",
+	Blank:        "Blank",
+}
+
+func (k TokenKind) String() string {
+	if name, ok := tokenKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("TokenKind(%d)", int(k))
+}
+
+// Token is a syntactic element of a unit file, as returned by
+// Tokenizer.Next.
+type Token struct {
+	Kind TokenKind
+	// Pos and End delimit the token in the input, like the Pos and End
+	// methods of Section and OptionValue do. A Blank token ends where its
+	// line ending starts.
+	Pos, End Position
+
+	// Section is the section name of a SectionStart token, and the name
+	// of the enclosing section of an Assignment token.
+	Section string
+	// Option and Value are the option name and the value of an
+	// Assignment token, trimmed and with continuations joined as in a
+	// parsed Unit.
+	Option, Value string
+	// Text is the text of a Comment token without its comment marker and
+	// the single space following it. The lines of a continued comment are
+	// separated by newlines.
+	Text string
+}
+
+// String returns the canonical form of the token: a section header, an
+// assignment, a '#' comment line, or an empty string for a blank line.
+func (t Token) String() string {
+	switch t.Kind {
+	case SectionStart:
+		return "[" + t.Section + "]"
+	case Assignment:
+		return t.Option + "=" + t.Value
+	case Comment:
+		lines := strings.Split(t.Text, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimSuffix("# "+line, " ")
+		}
+		return strings.Join(lines, "\n")
+	}
+	return ""
+}
+
+// queued is a token or syntax error waiting to be returned by
+// Tokenizer.Next.
+type queued struct {
+	tok Token
+	err error
+}
+
+// A Tokenizer reads a systemd config from an input stream and returns
+// its tokens one at a time, without building a Unit, so that arbitrarily
+// large or numerous files can be filtered or transformed in constant
+// memory.
+type Tokenizer struct {
+	l    *lexer
+	next lexStep
+	// err is returned once the queue is drained: io.EOF or the error
+	// that stopped the lexer
+	err error
+}
+
+// NewTokenizer returns a new tokenizer that reads from r. It accepts the
+// options of a Decoder, except WithLossless, which has no effect.
+func NewTokenizer(r io.Reader, opts ...DecoderOption) *Tokenizer {
+	cfg := newDecoderConfig(r, opts)
+	cfg.lossless = false
+	l := newLexer(r, cfg)
+	l.stream = true
+	return &Tokenizer{l: l, next: l.start()}
+}
+
+// Next returns the next token. At the end of the input it returns io.EOF.
+// A syntax error is returned as a *ParseError; after it, Next keeps
+// returning it, unless the tokenizer recovers from errors (WithRecovery),
+// in which case the next call continues after the offending line. The
+// assignments following an unterminated section header are skipped.
+func (t *Tokenizer) Next() (Token, error) {
+	for {
+		if len(t.l.queue) > 0 {
+			q := t.l.queue[0]
+			t.l.queue = t.l.queue[1:]
+			return q.tok, q.err
+		}
+		if t.err != nil {
+			return Token{}, t.err
+		}
+		if t.next == nil {
+			t.err = io.EOF
+			continue
+		}
+
+		var err error
+		t.next, err = t.next()
+		if err != nil {
+			t.err = err
+		}
+	}
+}
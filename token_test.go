@@ -0,0 +1,167 @@
+package systemdconfig
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// tokens reads every token from t, formatted as "kind pos-end canonical",
+// and the errors returned along the way.
+func tokens(t *testing.T, tok *Tokenizer) ([]string, []error) {
+	t.Helper()
+	var got []string
+	var errs []error
+	for range 1000 {
+		token, err := tok.Next()
+		if errors.Is(err, io.EOF) {
+			return got, errs
+		}
+		if err != nil {
+			errs = append(errs, err)
+			var perr *ParseError
+			if !errors.As(err, &perr) || perr.Kind.fatal() || !tok.l.recover {
+				return got, errs
+			}
+			continue
+		}
+		got = append(got, token.Kind.String()+" "+token.Pos.String()+"-"+token.End.String()+" "+token.String())
+	}
+	t.Fatal("tokenizer did not stop")
+	return nil, nil
+}
+
+func TestTokenizer(t *testing.T) {
+	in := "# header\n" +
+		"[Unit]\n" +
+		"Description=Test \\\n" +
+		"# skipped\n" +
+		"  service\n" +
+		"\r\n" +
+		"  \n" +
+		"[Service]\n" +
+		"; continued \\\n" +
+		"comment\n" +
+		"ExecStart=/bin/a \\\n" +
+		"\n" +
+		"Type=simple"
+	want := []string{
+		"Comment 1:1-1:9 # header",
+		"SectionStart 2:1-2:7 [Unit]",
+		"Assignment 3:1-5:10 Description=Test    service",
+		"Blank 6:1-6:1 ",
+		"Blank 7:1-7:3 ",
+		"SectionStart 8:1-8:10 [Service]",
+		"Comment 9:1-10:8 # continued \\\n# comment",
+		"Assignment 11:1-11:19 ExecStart=/bin/a",
+		"Blank 12:1-12:1 ",
+		"Assignment 13:1-13:12 Type=simple",
+	}
+
+	got, errs := tokens(t, NewTokenizer(strings.NewReader(in)))
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("tokens:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestTokenizer_Section(t *testing.T) {
+	tok := NewTokenizer(strings.NewReader("[Match]\nName=eth0\n[Network]\nDHCP=yes\n"))
+	var got []string
+	for {
+		token, err := tok.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token.Kind == Assignment {
+			got = append(got, token.Section+"."+token.Option)
+		}
+	}
+	if strings.Join(got, " ") != "Match.Name Network.DHCP" {
+		t.Errorf("assignments: %v", got)
+	}
+}
+
+func TestTokenizer_Errors(t *testing.T) {
+	in := "[Unit]\nbogus\n[Broken\nOrphan=1\n[Service]\nType=simple\n"
+
+	t.Run("Sticky", func(t *testing.T) {
+		tok := NewTokenizer(strings.NewReader(in))
+		got, errs := tokens(t, tok)
+		if strings.Join(got, "\n") != "SectionStart 1:1-1:7 [Unit]" {
+			t.Errorf("tokens before the error: %q", got)
+		}
+		var perr *ParseError
+		if len(errs) != 1 || !errors.As(errs[0], &perr) || perr.Kind != MissingEquals {
+			t.Fatalf("errors: %v, want one MissingEquals", errs)
+		}
+		if _, err := tok.Next(); err != errs[0] {
+			t.Errorf("Next after error: %v, want the same error again", err)
+		}
+	})
+
+	t.Run("Recovery", func(t *testing.T) {
+		got, errs := tokens(t, NewTokenizer(strings.NewReader(in), WithRecovery()))
+		want := []string{
+			"SectionStart 1:1-1:7 [Unit]",
+			"SectionStart 5:1-5:10 [Service]",
+			"Assignment 6:1-6:12 Type=simple",
+		}
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("tokens:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+		}
+		var kinds []string
+		for _, err := range errs {
+			var perr *ParseError
+			if !errors.As(err, &perr) {
+				t.Fatalf("error %v is not a *ParseError", err)
+			}
+			kinds = append(kinds, perr.Pos.String()+" "+perr.Kind.String())
+		}
+		if strings.Join(kinds, ", ") != "2:1 missing '=', 3:1 unterminated section header" {
+			t.Errorf("errors: %v", kinds)
+		}
+	})
+
+	t.Run("FatalLimit", func(t *testing.T) {
+		tok := NewTokenizer(strings.NewReader(in), WithRecovery(), WithMaxSections(1))
+		_, errs := tokens(t, tok)
+		if len(errs) == 0 || !errors.Is(errs[len(errs)-1], ErrTooManySections) {
+			t.Errorf("errors: %v, want ErrTooManySections last", errs)
+		}
+	})
+}
+
+func TestTokenKind_String(t *testing.T) {
+	for kind, want := range map[TokenKind]string{
+		SectionStart:  "SectionStart",
+		Blank:         "Blank",
+		TokenKind(42): "TokenKind(42)",
+	} {
+		if got := kind.String(); got != want {
+			t.Errorf("%d: got %q, want %q", int(kind), got, want)
+		}
+	}
+}
+
+func TestToken_String(t *testing.T) {
+	for _, tc := range []struct {
+		tok  Token
+		want string
+	}{
+		{Token{Kind: SectionStart, Section: "Unit"}, "[Unit]"},
+		{Token{Kind: Assignment, Option: "Type", Value: "simple"}, "Type=simple"},
+		{Token{Kind: Comment, Text: "a\n\nb"}, "# a\n#\n# b"},
+		{Token{Kind: Blank}, ""},
+	} {
+		if got := tc.tok.String(); got != tc.want {
+			t.Errorf("%v: got %q, want %q", tc.tok, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+package systemdconfig
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrOptionNotFound is wrapped by the ValueError the typed getters
+	// return for an absent or empty option.
+	ErrOptionNotFound = errors.New("option not set")
+	// ErrInvalidBool is wrapped by the error ParseBool returns.
+	ErrInvalidBool = errors.New("invalid boolean")
+	// ErrInvalidBytes is wrapped by the error ParseBytes returns.
+	ErrInvalidBytes = errors.New("invalid size")
+	// ErrInvalidInt is wrapped by the ValueError Unit.Int returns.
+	ErrInvalidInt = errors.New("invalid integer")
+)
+
+// ValueError describes an option whose value a typed getter such as
+// Unit.Bool cannot return.
+type ValueError struct {
+	Section, Option string
+	// Value is the value of the option, empty when it is not set.
+	Value string
+	// Err is ErrOptionNotFound, or the error parsing Value.
+	Err error
+}
+
+// Error returns the error in the "Section.Option: message" form.
+func (e *ValueError) Error() string {
+	return fmt.Sprintf("%s.%s: %v", e.Section, e.Option, e.Err)
+}
+
+// Unwrap returns e.Err, so that e.g. errors.Is(err, ErrOptionNotFound)
+// works.
+func (e *ValueError) Unwrap() error {
+	return e.Err
+}
+
+// typedValue returns the value of the named option, as Unit.Value does,
+// parsed by parse. An absent option and an empty value, which resets the
+// option to its default in systemd, yield ErrOptionNotFound.
+func typedValue[T any](u *Unit, section, option string, parse func(string) (T, error)) (T, error) {
+	var zero T
+	value, _ := u.Value(section, option)
+	if value == "" {
+		return zero, &ValueError{Section: section, Option: option, Err: ErrOptionNotFound}
+	}
+	v, err := parse(value)
+	if err != nil {
+		return zero, &ValueError{Section: section, Option: option, Value: value, Err: err}
+	}
+	return v, nil
+}
+
+// Set sets the named option in the named section to value: it replaces
+// the value of the last occurrence of the option, which is the one in
+// effect, or else adds the option to the last section with that name,
+// adding the section if there is none. It returns the assignment.
+func (u *Unit) Set(section, option, value string) *OptionValue {
+	var last *Section
+	for i := len(u.Sections) - 1; i >= 0; i-- {
+		s := u.Sections[i]
+		if s.Name != section {
+			continue
+		}
+		for j := len(s.Options) - 1; j >= 0; j-- {
+			if s.Options[j].Option == option {
+				s.Options[j].Value = value
+				return s.Options[j]
+			}
+		}
+		if last == nil {
+			last = s
+		}
+	}
+	if last == nil {
+		last = u.AddSection(section)
+	}
+	return last.AddOption(option, value)
+}
+
+// Bool returns the value of the named option parsed by ParseBool. The
+// error is a *ValueError; it wraps ErrOptionNotFound when the option is
+// absent or empty, which resets it to its default in systemd.
+func (u *Unit) Bool(section, option string) (bool, error) {
+	return typedValue(u, section, option, ParseBool)
+}
+
+// SetBool sets the named option to "yes" or "no", see Set.
+func (u *Unit) SetBool(section, option string, v bool) *OptionValue {
+	return u.Set(section, option, FormatBool(v))
+}
+
+// Duration returns the value of the named option parsed by
+// ParseTimespan. The error is a *ValueError; it wraps ErrOptionNotFound
+// when the option is absent or empty.
+func (u *Unit) Duration(section, option string) (time.Duration, error) {
+	return typedValue(u, section, option, ParseTimespan)
+}
+
+// SetDuration sets the named option to d formatted by FormatTimespan, see
+// Set.
+func (u *Unit) SetDuration(section, option string, d time.Duration) *OptionValue {
+	return u.Set(section, option, FormatTimespan(d))
+}
+
+// Bytes returns the value of the named option parsed by ParseBytes. The
+// error is a *ValueError; it wraps ErrOptionNotFound when the option is
+// absent or empty.
+func (u *Unit) Bytes(section, option string) (uint64, error) {
+	return typedValue(u, section, option, ParseBytes)
+}
+
+// SetBytes sets the named option to n formatted by FormatBytes, see Set.
+func (u *Unit) SetBytes(section, option string, n uint64) *OptionValue {
+	return u.Set(section, option, FormatBytes(n))
+}
+
+// Int returns the value of the named option as a decimal integer. The
+// error is a *ValueError; it wraps ErrOptionNotFound when the option is
+// absent or empty, and ErrInvalidInt when it is not an integer.
+func (u *Unit) Int(section, option string) (int64, error) {
+	return typedValue(u, section, option, func(s string) (int64, error) {
+		v, err := strconv.ParseInt(strings.Trim(s, whitespace), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w %q", ErrInvalidInt, s)
+		}
+		return v, nil
+	})
+}
+
+// SetInt sets the named option to v in decimal, see Set.
+func (u *Unit) SetInt(section, option string, v int64) *OptionValue {
+	return u.Set(section, option, strconv.FormatInt(v, 10))
+}
+
+// ParseBool parses a boolean as systemd's parse_boolean() does: "1",
+// "yes", "y", "true", "t" and "on" are true, "0", "no", "n", "false", "f"
+// and "off" are false, regardless of case.
+func ParseBool(s string) (bool, error) {
+	switch strings.ToLower(strings.Trim(s, whitespace)) {
+	case "1", "yes", "y", "true", "t", "on":
+		return true, nil
+	case "0", "no", "n", "false", "f", "off":
+		return false, nil
+	}
+	return false, fmt.Errorf("%w %q", ErrInvalidBool, s)
+}
+
+// FormatBool formats v the way systemd prints booleans, "yes" or "no".
+func FormatBool(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
+
+// sizeUnits are the suffixes of sizes, as in systemd's parse_size() with
+// base 1024, largest first.
+var sizeUnits = []struct {
+	suffix string
+	factor uint64
+}{
+	{"E", 1 << 60}, {"P", 1 << 50}, {"T", 1 << 40}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1},
+}
+
+// ParseBytes parses a size in bytes as systemd's parse_size() does with
+// base 1024: a number, optionally with a fraction, followed by one of
+// the suffixes K, M, G, T, P and E, or B or no suffix for bytes. Several
+// such numbers with decreasing suffixes are added up, e.g. "1G 512M".
+func ParseBytes(s string) (uint64, error) {
+	invalid := func() (uint64, error) {
+		return 0, fmt.Errorf("%w %q", ErrInvalidBytes, s)
+	}
+	rest := strings.Trim(s, whitespace)
+	if rest == "" {
+		return invalid()
+	}
+
+	var total uint64
+	// next is the first of sizeUnits the next number may use
+	next := 0
+	for rest != "" {
+		whole, frac, digits, n := parseDecimal(rest)
+		if n == 0 {
+			return invalid()
+		}
+		rest = strings.TrimLeft(rest[n:], whitespace)
+
+		factor := uint64(1)
+		unit := len(sizeUnits)
+		for i := next; i < len(sizeUnits); i++ {
+			if strings.HasPrefix(rest, sizeUnits[i].suffix) {
+				factor, unit = sizeUnits[i].factor, i
+				rest = rest[len(sizeUnits[i].suffix):]
+				break
+			}
+		}
+		if unit == len(sizeUnits) && rest != "" {
+			// only the last number may go without a suffix
+			return invalid()
+		}
+		next = unit + 1
+		rest = strings.TrimLeft(rest, whitespace)
+
+		v, ok := scale(whole, frac, digits, factor)
+		if !ok || v > math.MaxUint64-total {
+			return invalid()
+		}
+		total += v
+	}
+	return total, nil
+}
+
+// FormatBytes formats n with the largest of the suffixes K, M, G, T, P
+// and E that divides it, e.g. "512M", and without one otherwise.
+func FormatBytes(n uint64) string {
+	for _, u := range sizeUnits {
+		if n != 0 && u.factor > 1 && n%u.factor == 0 {
+			return strconv.FormatUint(n/u.factor, 10) + u.suffix
+		}
+	}
+	return strconv.FormatUint(n, 10)
+}
@@ -0,0 +1,127 @@
+package systemdconfig
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnit_TypedGetters(t *testing.T) {
+	unit, err := Deserialize(strings.NewReader(`[Service]
+RemainAfterExit=On
+TimeoutStartSec=1min 30s
+MemoryMax=1.5G
+Nice=-5
+Restart=sometimes
+LimitNOFILE=
+[Service]
+TimeoutStopSec=infinity
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := unit.Bool("Service", "RemainAfterExit"); err != nil || !got {
+		t.Errorf("Bool() = %v, %v, want true", got, err)
+	}
+	if got, err := unit.Duration("Service", "TimeoutStartSec"); err != nil || got != 90*time.Second {
+		t.Errorf("Duration() = %v, %v, want 1m30s", got, err)
+	}
+	if got, err := unit.Duration("Service", "TimeoutStopSec"); err != nil || got != DurationInfinity {
+		t.Errorf("Duration() = %v, %v, want DurationInfinity", got, err)
+	}
+	if got, err := unit.Bytes("Service", "MemoryMax"); err != nil || got != 3<<29 {
+		t.Errorf("Bytes() = %v, %v, want %d", got, err, uint64(3<<29))
+	}
+	if got, err := unit.Int("Service", "Nice"); err != nil || got != -5 {
+		t.Errorf("Int() = %v, %v, want -5", got, err)
+	}
+
+	var verr *ValueError
+	_, err = unit.Bool("Service", "Restart")
+	if !errors.Is(err, ErrInvalidBool) || !errors.As(err, &verr) || verr.Value != "sometimes" {
+		t.Errorf("Bool() error = %v, want a ValueError wrapping ErrInvalidBool", err)
+	}
+	if got := err.Error(); got != `Service.Restart: invalid boolean "sometimes"` {
+		t.Errorf("Bool() error = %q", got)
+	}
+	if _, err := unit.Int("Service", "TimeoutStartSec"); !errors.Is(err, ErrInvalidInt) {
+		t.Errorf("Int() error = %v, want ErrInvalidInt", err)
+	}
+	if _, err := unit.Bytes("Service", "Restart"); !errors.Is(err, ErrInvalidBytes) {
+		t.Errorf("Bytes() error = %v, want ErrInvalidBytes", err)
+	}
+	if _, err := unit.Duration("Service", "Restart"); !errors.Is(err, ErrInvalidDuration) {
+		t.Errorf("Duration() error = %v, want ErrInvalidDuration", err)
+	}
+	for _, option := range []string{"Missing", "LimitNOFILE"} {
+		if _, err := unit.Int("Service", option); !errors.Is(err, ErrOptionNotFound) {
+			t.Errorf("Int(%q) error = %v, want ErrOptionNotFound", option, err)
+		}
+	}
+}
+
+func TestUnit_TypedSetters(t *testing.T) {
+	unit, err := Deserialize(strings.NewReader("[Service]\nRestart=no\nType=simple\n[Service]\nType=notify\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	unit.SetBool("Service", "Restart", true)
+	unit.SetDuration("Service", "TimeoutStartSec", 90*time.Second)
+	unit.SetBytes("Service", "MemoryMax", 512<<20)
+	unit.SetInt("Service", "Nice", -5)
+	unit.Set("Service", "Type", "oneshot")
+	unit.SetBool("Install", "Enabled", false)
+
+	want := "[Service]\nRestart=yes\nType=simple\n\n[Service]\nType=oneshot\nTimeoutStartSec=1min 30s\nMemoryMax=512M\nNice=-5\n\n[Install]\nEnabled=no\n"
+	if got := unit.String(); got != want {
+		t.Errorf("unit after setters:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestParseBool(t *testing.T) {
+	for in, want := range map[string]bool{"1": true, "YES": true, "y": true, "true": true, "t": true, "on": true, "0": false, "No": false, "n": false, "false": false, "F": false, "off": false} {
+		if got, err := ParseBool(in); err != nil || got != want {
+			t.Errorf("ParseBool(%q) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParseBool("2"); !errors.Is(err, ErrInvalidBool) {
+		t.Errorf("ParseBool(\"2\") error = %v", err)
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	tests := []struct {
+		in   string
+		want uint64
+	}{
+		{"512", 512},
+		{"512B", 512},
+		{"1K", 1024},
+		{"1.5K", 1536},
+		{"2 M", 2 << 20},
+		{"1G 512M", 1<<30 + 512<<20},
+		{"1T", 1 << 40},
+		{"0.5E", 1 << 59},
+	}
+	for _, tt := range tests {
+		got, err := ParseBytes(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseBytes(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, in := range []string{"", "K", "-1K", "1X", "1M 1G", "1 2", "16E"} {
+		if _, err := ParseBytes(in); !errors.Is(err, ErrInvalidBytes) {
+			t.Errorf("ParseBytes(%q) error = %v, want ErrInvalidBytes", in, err)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	for n, want := range map[uint64]string{0: "0", 1000: "1000", 1024: "1K", 1536: "1536", 512 << 20: "512M", 3 << 40: "3T"} {
+		if got := FormatBytes(n); got != want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
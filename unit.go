@@ -5,6 +5,15 @@ import "bytes"
 // Unit represents a systemd config unit file.
 type Unit struct {
 	Sections []*Section
+
+	// comments are the trailing comments written after the last section.
+	comments []string
+	// tail holds the blank and comment lines after the last node of a unit
+	// parsed in lossless mode.
+	tail *source
+	// bom reports whether a unit parsed in lossless mode started with a
+	// byte order mark.
+	bom bool
 }
 
 // NewUnit returns a new systemd config unit file.
@@ -72,6 +81,22 @@ func (u *Unit) String() string {
 	return buf.String()
 }
 
+// TrailingComments returns the text of the comments after the last
+// section and option of a unit parsed by DeserializeLossless, or those set
+// by SetTrailingComments. Each comment is one line without its comment
+// marker ('#' or ';') and the single space following it.
+func (u *Unit) TrailingComments() []string {
+	return u.comments
+}
+
+// SetTrailingComments replaces the comments written after the last
+// section; call it without arguments to remove them. They are written as
+// '#' comment lines, separated from the last section by a blank line. A
+// comment containing newlines is written as several comment lines.
+func (u *Unit) SetTrailingComments(comments ...string) {
+	u.comments = splitLines(comments)
+}
+
 // AddSection appends a new empty section with the given name and returns it.
 func (u *Unit) AddSection(name string) *Section {
 	s := NewSection(name)
@@ -0,0 +1,190 @@
+package systemdconfig
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidUnitName is wrapped by the error ParseUnitName, and a Loader,
+// return for a name that is not a valid unit name.
+var ErrInvalidUnitName = errors.New("invalid unit name")
+
+// UnitNameMax is the maximum length of a unit name systemd accepts.
+const UnitNameMax = 255
+
+// unitTypes are the unit types of systemd.unit(5).
+var unitTypes = []string{
+	"automount", "device", "mount", "path", "scope", "service", "slice", "socket", "swap", "target", "timer",
+}
+
+// UnitName is a unit name split into its parts, e.g. "getty", "tty1" and
+// "service" for "getty@tty1.service". The name of a template,
+// "getty@.service", has the prefix and type of its instances and no
+// instance.
+type UnitName struct {
+	// Prefix is the part of the name before the "@" or, for names of
+	// units that are no templates or instances, before the type.
+	Prefix string
+	// Instance is the part of the name of an instance between the "@"
+	// and the type, still escaped, e.g. "dev-ttyS0" for
+	// "serial-getty@dev-ttyS0.service".
+	Instance string
+	// Type is the unit type, e.g. "service".
+	Type string
+	// Templated reports whether the name has an "@", as the names of
+	// templates and their instances do.
+	Templated bool
+}
+
+// ParseUnitName parses a unit name, e.g. "docker.service",
+// "getty@.service" or "getty@tty1.service". As in systemd, the prefix
+// and instance consist of ASCII letters and digits and ":", "-", "_",
+// "." and "\", the instance may also contain "@", the type is one of
+// systemd.unit(5) and the name is at most UnitNameMax bytes long. The
+// error wraps ErrInvalidUnitName.
+func ParseUnitName(name string) (UnitName, error) {
+	invalid := func(reason string) (UnitName, error) {
+		return UnitName{}, fmt.Errorf("%w %q: %s", ErrInvalidUnitName, name, reason)
+	}
+	if len(name) > UnitNameMax {
+		return invalid(fmt.Sprintf("longer than %d bytes", UnitNameMax))
+	}
+	dot := strings.LastIndexByte(name, '.')
+	if dot < 0 {
+		return invalid("no unit type")
+	}
+	var n UnitName
+	n.Type = name[dot+1:]
+	if !slices.Contains(unitTypes, n.Type) {
+		return invalid(fmt.Sprintf("unknown unit type %q", n.Type))
+	}
+	n.Prefix, n.Instance, n.Templated = strings.Cut(name[:dot], "@")
+	if n.Prefix == "" {
+		return invalid("empty prefix")
+	}
+	if !validUnitNameChars(n.Prefix, false) || !validUnitNameChars(n.Instance, true) {
+		return invalid("invalid character")
+	}
+	return n, nil
+}
+
+// validUnitNameChars reports whether s consists of the characters
+// systemd allows in the prefix, or the instance, of a unit name.
+func validUnitNameChars(s string, instance bool) bool {
+	for i := range len(s) {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case strings.IndexByte(":-_.\\", c) >= 0:
+		case c == '@' && instance:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// IsTemplate reports whether n is the name of a template, e.g.
+// "getty@.service".
+func (n UnitName) IsTemplate() bool {
+	return n.Templated && n.Instance == ""
+}
+
+// IsInstance reports whether n is the name of an instance of a template,
+// e.g. "getty@tty1.service".
+func (n UnitName) IsInstance() bool {
+	return n.Templated && n.Instance != ""
+}
+
+// Template returns the name of the template of n, e.g. "getty@.service"
+// for "getty@tty1.service", or "" if n is neither a template nor an
+// instance.
+func (n UnitName) Template() string {
+	if !n.Templated {
+		return ""
+	}
+	return n.Prefix + "@." + n.Type
+}
+
+// String returns the unit name.
+func (n UnitName) String() string {
+	if !n.Templated {
+		return n.Prefix + "." + n.Type
+	}
+	return n.Prefix + "@" + n.Instance + "." + n.Type
+}
+
+// EscapeUnitName escapes s for use in a unit name, as "systemd-escape"
+// does: "/" becomes "-", and "-", "\", a leading "." and every byte but
+// ASCII letters, digits, ":", "_" and "." become "\xNN" escapes, e.g.
+// "Hello-World/1" becomes "Hello\x2dWorld-1".
+func EscapeUnitName(s string) string {
+	var b strings.Builder
+	for i := range len(s) {
+		c := s[i]
+		switch {
+		case c == '/':
+			b.WriteByte('-')
+		case c == '.' && i == 0, c == '-', c == '\\', !validUnitNameChars(string(c), false):
+			fmt.Fprintf(&b, "\\x%02x", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// UnescapeUnitName reverses EscapeUnitName: "-" becomes "/" and "\xNN"
+// escapes the byte they stand for. Other escapes wrap ErrInvalidEscape.
+func UnescapeUnitName(s string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '-':
+			b.WriteByte('/')
+		case '\\':
+			v, ok := parseDigits(s[min(i+2, len(s)):], 2, 16)
+			if !ok || s[i+1] != 'x' {
+				return "", fmt.Errorf("%w %q", ErrInvalidEscape, s[i:min(i+4, len(s))])
+			}
+			b.WriteByte(byte(v))
+			i += 3
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), nil
+}
+
+// EscapeUnitPath escapes a path for use in a unit name, as
+// "systemd-escape --path" does: the path, cleaned as by path.Clean, is
+// escaped without its leading and trailing "/", and the root directory
+// becomes "-", e.g. "/dev/disk/by-label/data" becomes
+// "dev-disk-by\x2dlabel-data".
+func EscapeUnitPath(p string) string {
+	p = strings.Trim(path.Clean("/"+p), "/")
+	if p == "" {
+		return "-"
+	}
+	return EscapeUnitName(p)
+}
+
+// UnescapeUnitPath reverses EscapeUnitPath. The error wraps
+// ErrInvalidEscape for an invalid escape, or for a path that is not
+// clean, and so was not escaped by EscapeUnitPath.
+func UnescapeUnitPath(s string) (string, error) {
+	if s == "-" {
+		return "/", nil
+	}
+	p, err := UnescapeUnitName(s)
+	if err != nil {
+		return "", err
+	}
+	if p == "" || path.Clean("/"+p) != "/"+p {
+		return "", fmt.Errorf("%w %q: not a clean path", ErrInvalidEscape, s)
+	}
+	return "/" + p, nil
+}
@@ -0,0 +1,133 @@
+package systemdconfig
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseUnitName(t *testing.T) {
+	tests := []struct {
+		name               string
+		want               UnitName
+		template, instance bool
+		templateName       string
+	}{
+		{
+			name: "docker.service",
+			want: UnitName{Prefix: "docker", Type: "service"},
+		},
+		{
+			name:     "getty@.service",
+			want:     UnitName{Prefix: "getty", Type: "service", Templated: true},
+			template: true, templateName: "getty@.service",
+		},
+		{
+			name:     "getty@tty1.service",
+			want:     UnitName{Prefix: "getty", Instance: "tty1", Type: "service", Templated: true},
+			instance: true, templateName: "getty@.service",
+		},
+		{
+			name:     "systemd-fsck@dev-disk-by\\x2duuid-1234.service",
+			want:     UnitName{Prefix: "systemd-fsck", Instance: "dev-disk-by\\x2duuid-1234", Type: "service", Templated: true},
+			instance: true, templateName: "systemd-fsck@.service",
+		},
+		{
+			name:     "foo@bar@baz.v1.socket",
+			want:     UnitName{Prefix: "foo", Instance: "bar@baz.v1", Type: "socket", Templated: true},
+			instance: true, templateName: "foo@.socket",
+		},
+		{
+			name: "-.slice",
+			want: UnitName{Prefix: "-", Type: "slice"},
+		},
+		{
+			name: "dbus.org.freedesktop.hostname1.service",
+			want: UnitName{Prefix: "dbus.org.freedesktop.hostname1", Type: "service"},
+		},
+	}
+	for _, tt := range tests {
+		n, err := ParseUnitName(tt.name)
+		if err != nil {
+			t.Errorf("ParseUnitName(%q) error = %v", tt.name, err)
+			continue
+		}
+		if n != tt.want {
+			t.Errorf("ParseUnitName(%q) = %+v, want %+v", tt.name, n, tt.want)
+		}
+		if n.IsTemplate() != tt.template || n.IsInstance() != tt.instance || n.Template() != tt.templateName {
+			t.Errorf("%q: IsTemplate, IsInstance, Template = %v, %v, %q", tt.name, n.IsTemplate(), n.IsInstance(), n.Template())
+		}
+		if n.String() != tt.name {
+			t.Errorf("%q: String() = %q", tt.name, n.String())
+		}
+	}
+}
+
+func TestParseUnitName_Invalid(t *testing.T) {
+	for _, name := range []string{
+		"",
+		"noext",
+		"foo.conf",
+		".service",
+		"@tty1.service",
+		"foo bar.service",
+		"foo/bar.service",
+		"../foo.service",
+		"föö.service",
+		"foo@in stance.service",
+		strings.Repeat("a", UnitNameMax-len(".service")+1) + ".service",
+	} {
+		if _, err := ParseUnitName(name); !errors.Is(err, ErrInvalidUnitName) {
+			t.Errorf("ParseUnitName(%q) error = %v, want %v", name, err, ErrInvalidUnitName)
+		}
+	}
+	if _, err := ParseUnitName(strings.Repeat("a", UnitNameMax-len(".service")) + ".service"); err != nil {
+		t.Errorf("ParseUnitName of a name of UnitNameMax bytes error = %v", err)
+	}
+}
+
+func TestEscapeUnitName(t *testing.T) {
+	tests := []struct{ s, escaped string }{
+		{"tty1", "tty1"},
+		{"Hello-World/1", "Hello\\x2dWorld-1"},
+		{".hidden/a b", "\\x2ehidden-a\\x20b"},
+		{"back\\slash:x_y.z", "back\\x5cslash:x_y.z"},
+		{"ü", "\\xc3\\xbc"},
+	}
+	for _, tt := range tests {
+		if got := EscapeUnitName(tt.s); got != tt.escaped {
+			t.Errorf("EscapeUnitName(%q) = %q, want %q", tt.s, got, tt.escaped)
+		}
+		if got, err := UnescapeUnitName(tt.escaped); err != nil || got != tt.s {
+			t.Errorf("UnescapeUnitName(%q) = %q, %v, want %q", tt.escaped, got, err, tt.s)
+		}
+	}
+	for _, s := range []string{"a\\", "a\\x", "a\\x2", "a\\x2g", "a\\y20"} {
+		if _, err := UnescapeUnitName(s); !errors.Is(err, ErrInvalidEscape) {
+			t.Errorf("UnescapeUnitName(%q) error = %v, want %v", s, err, ErrInvalidEscape)
+		}
+	}
+}
+
+func TestEscapeUnitPath(t *testing.T) {
+	tests := []struct{ path, escaped, unescaped string }{
+		{"/", "-", "/"},
+		{"/dev/disk/by-label/data", "dev-disk-by\\x2dlabel-data", "/dev/disk/by-label/data"},
+		{"//var//lib/", "var-lib", "/var/lib"},
+		{"home/user/../other", "home-other", "/home/other"},
+	}
+	for _, tt := range tests {
+		if got := EscapeUnitPath(tt.path); got != tt.escaped {
+			t.Errorf("EscapeUnitPath(%q) = %q, want %q", tt.path, got, tt.escaped)
+		}
+		if got, err := UnescapeUnitPath(tt.escaped); err != nil || got != tt.unescaped {
+			t.Errorf("UnescapeUnitPath(%q) = %q, %v, want %q", tt.escaped, got, err, tt.unescaped)
+		}
+	}
+	for _, s := range []string{"", "-var", "var-", "var--lib", "var-..-lib", "\\x"} {
+		if _, err := UnescapeUnitPath(s); !errors.Is(err, ErrInvalidEscape) {
+			t.Errorf("UnescapeUnitPath(%q) error = %v, want %v", s, err, ErrInvalidEscape)
+		}
+	}
+}
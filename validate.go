@@ -0,0 +1,182 @@
+package systemdconfig
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// ErrInvalidSectionName is wrapped by the ValidationError for a
+	// section name outside systemd's grammar, or that cannot be written.
+	ErrInvalidSectionName = errors.New("invalid section name")
+	// ErrInvalidOptionName is wrapped by the ValidationError for an option
+	// name outside systemd's grammar, or that cannot be written.
+	ErrInvalidOptionName = errors.New("invalid option name")
+	// ErrInvalidValue is wrapped by the ValidationError for a value that
+	// cannot be written so that it parses back unchanged.
+	ErrInvalidValue = errors.New("invalid value")
+	// ErrInvalidComment is wrapped by the ValidationError for a comment
+	// that cannot be written so that it parses back unchanged.
+	ErrInvalidComment = errors.New("invalid comment")
+)
+
+// Validate checks every section and option of u (see Section.Validate)
+// and its trailing comments. It returns nil or the errors joined with
+// errors.Join; each is a *ValidationError.
+func (u *Unit) Validate() error {
+	var errs []error
+	for _, s := range u.Sections {
+		if err := s.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if reason := commentsProblem(u.comments, false); reason != "" {
+		errs = append(errs, &ValidationError{Reason: reason, Err: ErrInvalidComment})
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks that the name of s follows systemd's section grammar: a
+// non-empty name without brackets, quotes, backslashes or control
+// characters. It also validates the comments of s and every option (see
+// OptionValue.Validate). It returns nil or the errors joined with
+// errors.Join; each is a *ValidationError.
+func (s *Section) Validate() error {
+	var errs []error
+	if err := s.check(true); err != nil {
+		errs = append(errs, err)
+	}
+	for _, o := range s.Options {
+		if err := o.check(true, len(o.Option)+len("="), encoderConfig{}); err != nil {
+			err.Section = s.Name
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validate checks that the option name of uo follows systemd's key
+// grammar, an ASCII letter followed by letters, digits, '-' and '_', and
+// that the value and comments of uo can be written by WriteTo so that
+// they parse back unchanged: a value must not start or end with
+// whitespace, end in a backslash or contain a newline, and a value too
+// long for a line must contain spaces to wrap it at. It returns nil or a
+// *ValidationError.
+func (uo *OptionValue) Validate() error {
+	// a nil *ValidationError must not become a non-nil error
+	if err := uo.check(true, len(uo.Option)+len("="), encoderConfig{}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// check returns the first problem with the section header of s and its
+// comments. Unless strict, only problems that keep the header from
+// parsing back unchanged are reported.
+func (s *Section) check(strict bool) *ValidationError {
+	if reason := sectionNameProblem(s.Name, strict); reason != "" {
+		return &ValidationError{Pos: s.pos, Section: s.Name, Reason: reason, Err: ErrInvalidSectionName}
+	}
+	if reason := commentsProblem(s.comments, true); reason != "" {
+		return &ValidationError{Pos: s.pos, Section: s.Name, Reason: reason, Err: ErrInvalidComment}
+	}
+	return nil
+}
+
+// check returns the first problem with the assignment of uo and its
+// comments. Unless strict, only problems that keep the assignment from
+// parsing back unchanged when written with the given output options,
+// after prefix bytes of option name and separator, are reported.
+func (uo *OptionValue) check(strict bool, prefix int, cfg encoderConfig) *ValidationError {
+	if reason := optionNameProblem(uo.Option, strict); reason != "" {
+		return &ValidationError{Pos: uo.pos, Option: uo.Option, Reason: reason, Err: ErrInvalidOptionName}
+	}
+	if _, reason := encodeValue(prefix, uo.Value, cfg); reason != "" {
+		return &ValidationError{Pos: uo.pos, Option: uo.Option, Reason: reason, Err: ErrInvalidValue}
+	}
+	if reason := commentsProblem(uo.comments, true); reason != "" {
+		return &ValidationError{Pos: uo.pos, Option: uo.Option, Reason: reason, Err: ErrInvalidComment}
+	}
+	return nil
+}
+
+// sectionNameProblem describes why name is not a valid section name, or
+// returns an empty string.
+func sectionNameProblem(name string, strict bool) string {
+	if strings.ContainsRune(name, '\n') {
+		return "contains a newline"
+	}
+	if strings.ContainsRune(name, ']') {
+		return "contains ']'"
+	}
+	if len(name)+len("[]") > LineMax {
+		return "too long"
+	}
+	if !strict {
+		return ""
+	}
+	if name == "" {
+		return "empty"
+	}
+	if !utf8.ValidString(name) {
+		return "not valid UTF-8"
+	}
+	// the characters systemd's string_is_safe rejects, and brackets
+	for _, r := range name {
+		if unicode.IsControl(r) || strings.ContainsRune(`[]"'\`, r) {
+			return fmt.Sprintf("contains %q", r)
+		}
+	}
+	return ""
+}
+
+// optionNameProblem describes why name is not a valid option name, or
+// returns an empty string.
+func optionNameProblem(name string, strict bool) string {
+	if !strict {
+		switch {
+		case strings.ContainsAny(name, "=\n\r"):
+			return fmt.Sprintf("contains %q", name[strings.IndexAny(name, "=\n\r")])
+		case strings.TrimSpace(name) != name:
+			return "starts or ends with whitespace"
+		case name != "" && (name[0] == '[' || IsComment(rune(name[0]))):
+			return fmt.Sprintf("starts with %q", name[0])
+		case !utf8.ValidString(name):
+			return "not valid UTF-8"
+		}
+		return ""
+	}
+	if name == "" {
+		return "empty"
+	}
+	for i, r := range name {
+		switch {
+		case 'A' <= r && r <= 'Z', 'a' <= r && r <= 'z':
+		case i > 0 && ('0' <= r && r <= '9' || r == '-' || r == '_'):
+		default:
+			return fmt.Sprintf("contains %q", r)
+		}
+	}
+	return ""
+}
+
+// commentsProblem describes why comments cannot be written so that they
+// parse back unchanged, or returns an empty string. When attached, the
+// comments precede a node, which a last comment ending in a backslash
+// would turn into a comment line.
+func commentsProblem(comments []string, attached bool) string {
+	for i, c := range comments {
+		switch {
+		case strings.HasSuffix(c, "\r"):
+			return fmt.Sprintf("comment %q ends in a carriage return", c)
+		case len("# ")+len(c) > LineMax:
+			return "comment too long"
+		case attached && i == len(comments)-1 && strings.HasSuffix(strings.TrimSuffix(c, " "), `\`):
+			return fmt.Sprintf("comment %q ends in a backslash", c)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,186 @@
+package systemdconfig
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestUnit_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		build   func(u *Unit)
+		wantErr error
+		// writable reports whether WriteTo accepts the unit
+		writable bool
+	}{
+		{
+			name: "Valid",
+			build: func(u *Unit) {
+				u.AddSection("X-Vendor Extras").AddOption("X-Foo_Bar2", "a \\ b")
+				u.SetTrailingComments("trailing \\")
+			},
+			writable: true,
+		},
+		{
+			name:    "SectionNameBracket",
+			build:   func(u *Unit) { u.AddSection("Unit]") },
+			wantErr: ErrInvalidSectionName,
+		},
+		{
+			name:    "SectionNameNewline",
+			build:   func(u *Unit) { u.AddSection("Unit\n[Service") },
+			wantErr: ErrInvalidSectionName,
+		},
+		{
+			name:     "SectionNameEmpty",
+			build:    func(u *Unit) { u.AddSection("") },
+			wantErr:  ErrInvalidSectionName,
+			writable: true,
+		},
+		{
+			name:     "SectionNameControl",
+			build:    func(u *Unit) { u.AddSection("Unit\x1b") },
+			wantErr:  ErrInvalidSectionName,
+			writable: true,
+		},
+		{
+			name:     "SectionNameQuote",
+			build:    func(u *Unit) { u.AddSection(`"Unit"`) },
+			wantErr:  ErrInvalidSectionName,
+			writable: true,
+		},
+		{
+			name:    "OptionNameEquals",
+			build:   func(u *Unit) { u.AddSection("Service").AddOption("A=B", "c") },
+			wantErr: ErrInvalidOptionName,
+		},
+		{
+			name:    "OptionNameNewline",
+			build:   func(u *Unit) { u.AddSection("Service").AddOption("A\nB", "c") },
+			wantErr: ErrInvalidOptionName,
+		},
+		{
+			name:    "OptionNameHeader",
+			build:   func(u *Unit) { u.AddSection("Service").AddOption("[Unit]", "c") },
+			wantErr: ErrInvalidOptionName,
+		},
+		{
+			name:    "OptionNameComment",
+			build:   func(u *Unit) { u.AddSection("Service").AddOption("#A", "c") },
+			wantErr: ErrInvalidOptionName,
+		},
+		{
+			name:    "OptionNameSpace",
+			build:   func(u *Unit) { u.AddSection("Service").AddOption(" A", "c") },
+			wantErr: ErrInvalidOptionName,
+		},
+		{
+			name:     "OptionNameInnerSpace",
+			build:    func(u *Unit) { u.AddSection("Service").AddOption("Exec Start", "c") },
+			wantErr:  ErrInvalidOptionName,
+			writable: true,
+		},
+		{
+			name:     "OptionNameDigit",
+			build:    func(u *Unit) { u.AddSection("Service").AddOption("1A", "c") },
+			wantErr:  ErrInvalidOptionName,
+			writable: true,
+		},
+		{
+			name:    "ValueNewline",
+			build:   func(u *Unit) { u.AddSection("Service").AddOption("ExecStart", "a\n[Unit]") },
+			wantErr: ErrInvalidValue,
+		},
+		{
+			name:    "ValueSpace",
+			build:   func(u *Unit) { u.AddSection("Service").AddOption("ExecStart", "a ") },
+			wantErr: ErrInvalidValue,
+		},
+		{
+			name:    "ValueBackslash",
+			build:   func(u *Unit) { u.AddSection("Service").AddOption("ExecStart", `a\`) },
+			wantErr: ErrInvalidValue,
+		},
+		{
+			name:    "ValueTooLong",
+			build:   func(u *Unit) { u.AddSection("Service").AddOption("ExecStart", strings.Repeat("x", LineMax)) },
+			wantErr: ErrInvalidValue,
+		},
+		{
+			name:    "CommentBackslash",
+			build:   func(u *Unit) { u.AddSection("Service").SetComments("continued \\ ") },
+			wantErr: ErrInvalidComment,
+		},
+		{
+			name: "CommentCarriageReturn",
+			build: func(u *Unit) {
+				u.AddSection("Service").AddOption("Type", "simple").SetComments("a\r")
+			},
+			wantErr: ErrInvalidComment,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewUnit()
+			tt.build(u)
+
+			err := u.Validate()
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+			var verr *ValidationError
+			if err != nil && !errors.As(err, &verr) {
+				t.Errorf("Validate() error %v is not a *ValidationError", err)
+			}
+
+			var buf bytes.Buffer
+			n, err := u.WriteTo(&buf)
+			if tt.writable {
+				if err != nil {
+					t.Fatalf("WriteTo() error = %v", err)
+				}
+				reparsed, err := DeserializeLossless(&buf)
+				if err != nil || !reparsed.Match(u) {
+					t.Errorf("reparsed = %v, %v, want %v", reparsed, err, u)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) || !errors.As(err, &verr) {
+				t.Errorf("WriteTo() error = %v, want a *ValidationError wrapping %v", err, tt.wantErr)
+			}
+			if n != 0 || buf.Len() != 0 {
+				t.Errorf("WriteTo() wrote %d bytes after an error", n)
+			}
+		})
+	}
+}
+
+func TestValidate_Parsed(t *testing.T) {
+	unit, err := Deserialize(strings.NewReader("[Service]\nExecStart=/bin/true\n\n[Service\"]\nExec Start=/bin/false\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, err := range unit.Validate().(interface{ Unwrap() []error }).Unwrap() {
+		got = append(got, err.Error())
+	}
+	want := []string{
+		`4:1: invalid section name: section "Service\"": contains '"'`,
+		`5:1: invalid option name: option "Exec Start" in section "Service\"": contains ' '`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Validate() errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if err := unit.Sections[0].Validate(); err != nil {
+		t.Errorf("Section.Validate() = %v, want nil", err)
+	}
+	if err := NewOptionValue("Type", "simple").Validate(); err != nil {
+		t.Errorf("OptionValue.Validate() = %v, want nil", err)
+	}
+	if err := NewOptionValue("Type", "simple ").Validate(); err.Error() != `invalid value: option "Type": starts or ends with whitespace` {
+		t.Errorf("OptionValue.Validate() = %v", err)
+	}
+}